@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"ualaTwitter/cmd/api/routes/handlers/admin"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/dataset"
+	"ualaTwitter/internal/platform/httphelper"
+)
+
+// requestTimeout bounds every call but the dataset streams, which last as
+// long as the dataset needs.
+const requestTimeout = 30 * time.Second
+
+// apiOperator calls a running API. Users are registered through the
+// public endpoint; everything else needs the admin token.
+type apiOperator struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+func newAPIOperator(baseURL, token string) *apiOperator {
+	return &apiOperator{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{},
+	}
+}
+
+func (o *apiOperator) CreateUser(ctx context.Context, name, document, password string) (createdUser, error) {
+	var out createdUser
+	body := map[string]string{"name": name, "document": document, "password": password}
+	return out, o.call(ctx, http.MethodPost, "/v1/users", body, &out)
+}
+
+func (o *apiOperator) FollowGraph(ctx context.Context, userID string) (followGraph, error) {
+	var out followGraph
+	return out, o.call(ctx, http.MethodGet, "/admin/users/"+url.PathEscape(userID)+"/follow-graph", nil, &out)
+}
+
+func (o *apiOperator) RecountLikes(ctx context.Context) (likeRecount, error) {
+	var out likeRecount
+	return out, o.call(ctx, http.MethodPost, "/admin/likes/recount", nil, &out)
+}
+
+func (o *apiOperator) PurgeTweets(ctx context.Context, userID string) (tweetPurge, error) {
+	var out tweetPurge
+	return out, o.call(ctx, http.MethodDelete, "/admin/users/"+url.PathEscape(userID)+"/tweets", nil, &out)
+}
+
+func (o *apiOperator) ResetPassword(ctx context.Context, userID, password string) error {
+	body := map[string]string{"password": password}
+	return o.call(ctx, http.MethodPut, "/admin/users/"+url.PathEscape(userID)+"/password", body, nil)
+}
+
+func (o *apiOperator) Stats(ctx context.Context) (stats, error) {
+	out := stats{Source: "api"}
+	return out, o.call(ctx, http.MethodGet, "/admin/stats", nil, &out)
+}
+
+func (o *apiOperator) Export(ctx context.Context, w io.Writer) error {
+	resp, err := o.do(ctx, http.MethodGet, "/admin/export", "", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+func (o *apiOperator) Import(ctx context.Context, r io.Reader) (dataset.Report, error) {
+	resp, err := o.do(ctx, http.MethodPost, "/admin/import", admin.JSONLinesContentType, r)
+	if err != nil {
+		return dataset.Report{}, err
+	}
+	defer resp.Body.Close()
+
+	var report dataset.Report
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return dataset.Report{}, fmt.Errorf("POST /admin/import: decode response: %w", err)
+	}
+	return report, nil
+}
+
+func (o *apiOperator) Close() {
+	o.client.CloseIdleConnections()
+}
+
+// call sends body as JSON and decodes a 2xx answer into out, unless out is
+// nil.
+func (o *apiOperator) call(ctx context.Context, method, path string, body, out any) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	var (
+		reader      io.Reader
+		contentType string
+	)
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader, contentType = bytes.NewReader(raw), "application/json"
+	}
+
+	resp, err := o.do(ctx, method, path, contentType, reader)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s %s: decode response: %w", method, path, err)
+	}
+	return nil
+}
+
+// do sends a request and returns 2xx responses for the caller to close.
+// Problem documents become errors carrying their title and detail.
+func (o *apiOperator) do(ctx context.Context, method, path, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, o.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if o.token != "" {
+		req.Header.Set(auth.AdminTokenHeader, o.token)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, problemError(method, path, resp)
+	}
+	return resp, nil
+}
+
+func problemError(method, path string, resp *http.Response) error {
+	var problem httphelper.Problem
+	if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil || problem.Title == "" {
+		return fmt.Errorf("%s %s: %s", method, path, resp.Status)
+	}
+	msg := problem.Title
+	if problem.Detail != "" {
+		msg += ": " + problem.Detail
+	}
+	return fmt.Errorf("%s %s: %d %s", method, path, resp.StatusCode, msg)
+}
@@ -0,0 +1,284 @@
+// Command admin operates the service: it creates users, inspects follow
+// graphs, repairs like counters, purges tweets, reports stats and exports
+// or imports datasets, either through a running API (--api) or directly on
+// Postgres.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// errUsage marks errors already explained by the printed usage.
+var errUsage = errors.New("usage")
+
+// action runs a parsed command against an operator. A nil result prints
+// nothing.
+type action func(ctx context.Context, op operator, std stdio) (any, error)
+
+// stdio holds the streams dataset commands read and write by default.
+type stdio struct {
+	in  io.Reader
+	out io.Writer
+}
+
+type command struct {
+	name    string
+	args    string
+	summary string
+	// apiOnly commands touch data that Postgres does not hold, or that the
+	// running API would not see.
+	apiOnly bool
+	parse   func(fs *flag.FlagSet, args []string) (action, error)
+}
+
+var commands = []command{
+	{
+		name: "create-user", args: "--name NAME --document DOCUMENT --password PASSWORD", apiOnly: true,
+		summary: "Register a user with a password",
+		parse: func(fs *flag.FlagSet, args []string) (action, error) {
+			name := fs.String("name", "", "user name")
+			document := fs.String("document", "", "identity document")
+			password := fs.String("password", os.Getenv("ADMIN_USER_PASSWORD"), "password; also ADMIN_USER_PASSWORD")
+			if err := parseCommand(fs, args, 0); err != nil {
+				return nil, err
+			}
+			return func(ctx context.Context, op operator, _ stdio) (any, error) {
+				return op.CreateUser(ctx, *name, *document, *password)
+			}, nil
+		},
+	},
+	{
+		name: "follow-graph", args: "USER_ID", apiOnly: true,
+		summary: "List who a user follows and who follows them",
+		parse: func(fs *flag.FlagSet, args []string) (action, error) {
+			if err := parseCommand(fs, args, 1); err != nil {
+				return nil, err
+			}
+			return func(ctx context.Context, op operator, _ stdio) (any, error) {
+				return op.FollowGraph(ctx, fs.Arg(0))
+			}, nil
+		},
+	},
+	{
+		name:    "recount-likes",
+		apiOnly: true,
+		summary: "Rebuild tweet like counters from the like set",
+		parse: func(fs *flag.FlagSet, args []string) (action, error) {
+			if err := parseCommand(fs, args, 0); err != nil {
+				return nil, err
+			}
+			return func(ctx context.Context, op operator, _ stdio) (any, error) {
+				return op.RecountLikes(ctx)
+			}, nil
+		},
+	},
+	{
+		name: "purge-tweets", args: "USER_ID", apiOnly: true,
+		summary: "Delete every tweet of a user along with their likes",
+		parse: func(fs *flag.FlagSet, args []string) (action, error) {
+			if err := parseCommand(fs, args, 1); err != nil {
+				return nil, err
+			}
+			return func(ctx context.Context, op operator, _ stdio) (any, error) {
+				return op.PurgeTweets(ctx, fs.Arg(0))
+			}, nil
+		},
+	},
+	{
+		name: "reset-password", args: "--password PASSWORD USER_ID", apiOnly: true,
+		summary: "Set a user's password, e.g. after an import",
+		parse: func(fs *flag.FlagSet, args []string) (action, error) {
+			password := fs.String("password", os.Getenv("ADMIN_USER_PASSWORD"), "password; also ADMIN_USER_PASSWORD")
+			if err := parseCommand(fs, args, 1); err != nil {
+				return nil, err
+			}
+			return func(ctx context.Context, op operator, _ stdio) (any, error) {
+				return nil, op.ResetPassword(ctx, fs.Arg(0), *password)
+			}, nil
+		},
+	},
+	{
+		name:    "stats",
+		summary: "Print entity counts and timeline cache counters",
+		parse: func(fs *flag.FlagSet, args []string) (action, error) {
+			if err := parseCommand(fs, args, 0); err != nil {
+				return nil, err
+			}
+			return func(ctx context.Context, op operator, _ stdio) (any, error) {
+				return op.Stats(ctx)
+			}, nil
+		},
+	},
+	{
+		name: "export", args: "[--output FILE]",
+		summary: "Write users, follows, tweets and likes as JSON Lines",
+		parse: func(fs *flag.FlagSet, args []string) (action, error) {
+			output := fs.String("output", "", "file to write; stdout when empty")
+			if err := parseCommand(fs, args, 0); err != nil {
+				return nil, err
+			}
+			return func(ctx context.Context, op operator, std stdio) (any, error) {
+				if *output == "" {
+					return nil, op.Export(ctx, std.out)
+				}
+				f, err := os.Create(*output)
+				if err != nil {
+					return nil, err
+				}
+				if err := op.Export(ctx, f); err != nil {
+					f.Close()
+					return nil, err
+				}
+				return nil, f.Close()
+			}, nil
+		},
+	},
+	{
+		name: "import", args: "[--input FILE]",
+		summary: "Load a JSON Lines dataset and report rejected lines",
+		parse: func(fs *flag.FlagSet, args []string) (action, error) {
+			input := fs.String("input", "", "file to read; stdin when empty")
+			if err := parseCommand(fs, args, 0); err != nil {
+				return nil, err
+			}
+			return func(ctx context.Context, op operator, std stdio) (any, error) {
+				r := std.in
+				if *input != "" {
+					f, err := os.Open(*input)
+					if err != nil {
+						return nil, err
+					}
+					defer f.Close()
+					r = f
+				}
+				report, err := op.Import(ctx, r)
+				if err != nil {
+					return nil, err
+				}
+				if err := printJSON(std.out, report); err != nil {
+					return nil, err
+				}
+				if n := len(report.Rejected); n > 0 {
+					return nil, fmt.Errorf("%d line(s) rejected", n)
+				}
+				return nil, nil
+			}, nil
+		},
+	},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	stop()
+
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	default:
+		fmt.Fprintf(os.Stderr, "admin: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run executes one command and prints its result as JSON to stdout.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("admin", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	apiURL := fs.String("api", os.Getenv("ADMIN_API_URL"), "base URL of a running API; also ADMIN_API_URL. Without it, Postgres is used directly")
+	token := fs.String("token", os.Getenv("ADMIN_TOKEN"), "admin token for --api; also ADMIN_TOKEN")
+	configFile := fs.String("config", "", "API config file for direct Postgres access; also CONFIG_FILE")
+	fs.Usage = func() { usage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	cmd, ok := lookup(fs.Arg(0))
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n", fs.Arg(0))
+		fs.Usage()
+		return errUsage
+	}
+	cmdFlags := flag.NewFlagSet("admin "+cmd.name, flag.ContinueOnError)
+	cmdFlags.SetOutput(stderr)
+	act, err := cmd.parse(cmdFlags, fs.Args()[1:])
+	if err != nil {
+		return err
+	}
+
+	var op operator
+	switch {
+	case *apiURL != "":
+		op = newAPIOperator(*apiURL, *token)
+	case cmd.apiOnly:
+		return errNeedsAPI
+	default:
+		pg, err := newPostgresOperator(ctx, *configFile)
+		if err != nil {
+			return err
+		}
+		op = pg
+	}
+	defer op.Close()
+
+	result, err := act(ctx, op, stdio{in: stdin, out: stdout})
+	if err != nil || result == nil {
+		return err
+	}
+	return printJSON(stdout, result)
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func lookup(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// parseCommand parses the command flags and requires exactly positional
+// arguments after them.
+func parseCommand(fs *flag.FlagSet, args []string, positional int) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != positional {
+		fmt.Fprintf(fs.Output(), "%s: expected %d argument(s), got %d\n", fs.Name(), positional, fs.NArg())
+		return errUsage
+	}
+	return nil
+}
+
+func usage(fs *flag.FlagSet) {
+	w := fs.Output()
+	fmt.Fprintln(w, "Usage: admin [flags] COMMAND [ARGS]")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-14s %s\n", c.name, c.summary)
+		if c.args != "" {
+			fmt.Fprintf(w, "  %-14s   %s %s\n", "", c.name, c.args)
+		}
+	}
+	fmt.Fprintln(w, "\nFlags:")
+	fs.PrintDefaults()
+}
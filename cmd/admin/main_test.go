@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ualaTwitter/cmd/api/routes/handlers/admin"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/httphelper"
+)
+
+const (
+	testToken   = "admin-secret"
+	testDataset = `{"type":"user","id":"usr_1","name":"ana","document":"123","created_at":"2024-05-01T10:00:00Z"}` + "\n"
+)
+
+// fakeAPI answers the endpoints the CLI calls with canned bodies and
+// rejects admin calls without the token.
+func fakeAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	route := func(pattern, body string) {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(auth.AdminTokenHeader) != testToken {
+				w.Header().Set("Content-Type", httphelper.ProblemContentType)
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"title":"Unauthorized","status":401,"code":"unauthorized"}`))
+				return
+			}
+			_, _ = w.Write([]byte(body))
+		})
+	}
+	route("GET /admin/stats", `{"repositories":{"users":2},"timeline_cache":{"hits":1,"misses":2,"invalidations":3}}`)
+	route("GET /admin/users/usr_1/follow-graph", `{"user_id":"usr_1","following":["usr_2"],"followers":[]}`)
+	route("POST /admin/likes/recount", `{"checked":2,"corrected":[{"tweet_id":"t1","was":3,"now":1}]}`)
+	route("DELETE /admin/users/usr_1/tweets", `{"tweets":4,"likes":6}`)
+	mux.HandleFunc("DELETE /admin/users/ghost/tweets", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", httphelper.ProblemContentType)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"title":"Not Found","status":404,"detail":"user not found","code":"not_found"}`))
+	})
+	route("GET /admin/export", testDataset)
+	mux.HandleFunc("POST /admin/import", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, admin.JSONLinesContentType, r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if string(body) == testDataset {
+			_, _ = w.Write([]byte(`{"imported":{"user":1},"skipped":{},"rejected":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"imported":{},"skipped":{},"rejected":[{"line":1,"type":"user","reason":"name is required"}]}`))
+	})
+	mux.HandleFunc("PUT /admin/users/usr_1/password", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, testToken, r.Header.Get(auth.AdminTokenHeader))
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"password": "s3cret-pass"}, body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /v1/users", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"name": "ana", "document": "123", "password": "s3cret-pass"}, body)
+		_, _ = w.Write([]byte(`{"id":"usr_9"}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRun_API(t *testing.T) {
+	server := fakeAPI(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "stats",
+			args: []string{"stats"},
+			want: `{"source":"api","repositories":{"users":2},"timeline_cache":{"hits":1,"misses":2,"invalidations":3}}`,
+		},
+		{
+			name: "follow graph",
+			args: []string{"follow-graph", "usr_1"},
+			want: `{"user_id":"usr_1","following":["usr_2"],"followers":[]}`,
+		},
+		{
+			name: "recount likes",
+			args: []string{"recount-likes"},
+			want: `{"checked":2,"corrected":[{"tweet_id":"t1","was":3,"now":1}]}`,
+		},
+		{
+			name: "purge tweets",
+			args: []string{"purge-tweets", "usr_1"},
+			want: `{"tweets":4,"likes":6}`,
+		},
+		{
+			name: "create user",
+			args: []string{"create-user", "--name", "ana", "--document", "123", "--password", "s3cret-pass"},
+			want: `{"id":"usr_9"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"--api", server.URL + "/", "--token", testToken}, tc.args...)
+
+			require.NoError(t, run(ctx, args, nil, &stdout, &stderr))
+			assert.JSONEq(t, tc.want, stdout.String())
+		})
+	}
+
+	t.Run("problem documents become errors", func(t *testing.T) {
+		err := run(ctx, []string{"--api", server.URL, "--token", testToken, "purge-tweets", "ghost"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+		assert.EqualError(t, err, "DELETE /admin/users/ghost/tweets: 404 Not Found: user not found")
+	})
+
+	t.Run("reset password", func(t *testing.T) {
+		var stdout bytes.Buffer
+		args := []string{"--api", server.URL, "--token", testToken, "reset-password", "--password", "s3cret-pass", "usr_1"}
+
+		require.NoError(t, run(ctx, args, nil, &stdout, &bytes.Buffer{}))
+		assert.Empty(t, stdout.String())
+	})
+
+	t.Run("export to a file", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "dataset.jsonl")
+		var stdout bytes.Buffer
+		args := []string{"--api", server.URL, "--token", testToken, "export", "--output", output}
+
+		require.NoError(t, run(ctx, args, nil, &stdout, &bytes.Buffer{}))
+		assert.Empty(t, stdout.String())
+		written, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.Equal(t, testDataset, string(written))
+	})
+
+	t.Run("import from stdin", func(t *testing.T) {
+		var stdout bytes.Buffer
+		args := []string{"--api", server.URL, "--token", testToken, "import"}
+
+		require.NoError(t, run(ctx, args, strings.NewReader(testDataset), &stdout, &bytes.Buffer{}))
+		assert.JSONEq(t, `{"imported":{"user":1},"skipped":{},"rejected":[]}`, stdout.String())
+	})
+
+	t.Run("import with rejected lines", func(t *testing.T) {
+		var stdout bytes.Buffer
+		args := []string{"--api", server.URL, "--token", testToken, "import"}
+
+		err := run(ctx, args, strings.NewReader(`{"type":"user"}`), &stdout, &bytes.Buffer{})
+		assert.EqualError(t, err, "1 line(s) rejected")
+		assert.JSONEq(t, `{"imported":{},"skipped":{},"rejected":[{"line":1,"type":"user","reason":"name is required"}]}`, stdout.String())
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		err := run(ctx, []string{"--api", server.URL, "--token", "", "stats"}, nil, &bytes.Buffer{}, &bytes.Buffer{})
+		assert.ErrorContains(t, err, "401 Unauthorized")
+	})
+}
+
+func TestRun_Usage(t *testing.T) {
+	t.Setenv("ADMIN_API_URL", "")
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		args []string
+		want error
+	}{
+		{name: "no command", args: nil, want: errUsage},
+		{name: "unknown command", args: []string{"reindex"}, want: errUsage},
+		{name: "missing argument", args: []string{"follow-graph"}, want: errUsage},
+		{name: "extra argument", args: []string{"stats", "now"}, want: errUsage},
+		{name: "in-memory data without the API", args: []string{"purge-tweets", "usr_1"}, want: errNeedsAPI},
+		{name: "create user without the API", args: []string{"create-user", "--name", "ana", "--document", "123", "--password", "s3cret-pass"}, want: errNeedsAPI},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			assert.ErrorIs(t, run(ctx, tc.args, nil, &stdout, &stderr), tc.want)
+			assert.Empty(t, stdout.String())
+		})
+	}
+}
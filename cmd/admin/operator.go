@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"ualaTwitter/internal/platform/dataset"
+)
+
+// errNeedsAPI is returned in direct mode for data that only the running
+// API holds. Users it serves are read from memory too, so they are created
+// through it.
+var errNeedsAPI = errors.New("users, tweets, likes and follows live in the API process; run with --api")
+
+// operator carries out the commands, either through the admin endpoints of
+// a running API or directly on Postgres.
+type operator interface {
+	CreateUser(ctx context.Context, name, document, password string) (createdUser, error)
+	FollowGraph(ctx context.Context, userID string) (followGraph, error)
+	RecountLikes(ctx context.Context) (likeRecount, error)
+	PurgeTweets(ctx context.Context, userID string) (tweetPurge, error)
+	ResetPassword(ctx context.Context, userID, password string) error
+	Stats(ctx context.Context) (stats, error)
+	Export(ctx context.Context, w io.Writer) error
+	Import(ctx context.Context, r io.Reader) (dataset.Report, error)
+	Close()
+}
+
+// The results mirror the API responses, so both operators print the same
+// JSON.
+
+type createdUser struct {
+	ID string `json:"id"`
+}
+
+type followGraph struct {
+	UserID    string   `json:"user_id"`
+	Following []string `json:"following"`
+	Followers []string `json:"followers"`
+}
+
+type likeRecount struct {
+	Checked   int              `json:"checked"`
+	Corrected []likeCorrection `json:"corrected"`
+}
+
+type likeCorrection struct {
+	TweetID string `json:"tweet_id"`
+	Was     int    `json:"was"`
+	Now     int    `json:"now"`
+}
+
+type tweetPurge struct {
+	Tweets int `json:"tweets"`
+	Likes  int `json:"likes"`
+}
+
+type stats struct {
+	// Source is where the numbers come from, api or postgres.
+	Source        string              `json:"source"`
+	Repositories  map[string]int      `json:"repositories"`
+	TimelineCache *timelineCacheStats `json:"timeline_cache,omitempty"`
+}
+
+type timelineCacheStats struct {
+	Hits          uint64 `json:"hits"`
+	Misses        uint64 `json:"misses"`
+	Invalidations uint64 `json:"invalidations"`
+}
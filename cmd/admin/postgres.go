@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"ualaTwitter/cmd/api/config"
+	"ualaTwitter/internal/platform/cache"
+	"ualaTwitter/internal/platform/dataset"
+	"ualaTwitter/internal/platform/repository/postgres"
+	"ualaTwitter/internal/usecase/export_dataset"
+	"ualaTwitter/internal/usecase/import_dataset"
+)
+
+// postgresOperator works on the database with the API's repositories and
+// use cases. Only users and credentials are stored there, so datasets
+// carry users only.
+type postgresOperator struct {
+	pool          *pgxpool.Pool
+	exportDataset *export_dataset.ExportDatasetService
+	importDataset *import_dataset.ImportDatasetService
+}
+
+// newPostgresOperator connects with the API configuration: configFile,
+// CONFIG_FILE and the environment variables the API reads.
+func newPostgresOperator(ctx context.Context, configFile string) (*postgresOperator, error) {
+	var args []string
+	if configFile != "" {
+		args = []string{"--config", configFile}
+	}
+	cfg, err := config.Load(args)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := pgxpool.New(ctx, cfg.PostgresDSN)
+	if err != nil {
+		return nil, fmt.Errorf("connect to Postgres: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("connect to Postgres: %w", err)
+	}
+
+	users := postgres.NewPostgresUserRepository(pool)
+	backend := dataset.NewPostgresBackend(users)
+	// Nothing caches timelines outside the API.
+	importDataset := import_dataset.NewImportDatasetService(backend, cache.NopInvalidator{})
+	importDataset.MaxContentLength = cfg.MaxContentLength
+
+	return &postgresOperator{
+		pool:          pool,
+		exportDataset: export_dataset.NewExportDatasetService(backend),
+		importDataset: importDataset,
+	}, nil
+}
+
+// CreateUser is refused: the API serves users from memory, so one written
+// only to Postgres would sign in yet be unknown to every other endpoint.
+func (o *postgresOperator) CreateUser(context.Context, string, string, string) (createdUser, error) {
+	return createdUser{}, errNeedsAPI
+}
+
+func (o *postgresOperator) FollowGraph(context.Context, string) (followGraph, error) {
+	return followGraph{}, errNeedsAPI
+}
+
+func (o *postgresOperator) RecountLikes(context.Context) (likeRecount, error) {
+	return likeRecount{}, errNeedsAPI
+}
+
+func (o *postgresOperator) PurgeTweets(context.Context, string) (tweetPurge, error) {
+	return tweetPurge{}, errNeedsAPI
+}
+
+func (o *postgresOperator) ResetPassword(context.Context, string, string) error {
+	return errNeedsAPI
+}
+
+func (o *postgresOperator) Stats(ctx context.Context) (stats, error) {
+	counts, err := postgres.CountRows(ctx, o.pool)
+	if err != nil {
+		return stats{}, err
+	}
+	return stats{Source: "postgres", Repositories: counts}, nil
+}
+
+func (o *postgresOperator) Export(ctx context.Context, w io.Writer) error {
+	_, err := o.exportDataset.Execute(ctx, export_dataset.Input{Writer: w})
+	return err
+}
+
+func (o *postgresOperator) Import(ctx context.Context, r io.Reader) (dataset.Report, error) {
+	return o.importDataset.Execute(ctx, import_dataset.Input{Data: r})
+}
+
+func (o *postgresOperator) Close() {
+	o.pool.Close()
+}
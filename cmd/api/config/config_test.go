@@ -0,0 +1,251 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/platform/ratelimit"
+)
+
+// testJWTKeys is a valid JWT_KEYS value, required outside local.
+const testJWTKeys = "k1:HS256:MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+func TestLoad_Table(t *testing.T) {
+	t.Setenv("POSTGRES_DSN", "postgres://db/uala")
+	t.Setenv("JWT_KEYS", testJWTKeys)
+
+	tests := []struct {
+		name           string
+		setEnv         string
+		expectedEnvVal string
+		expectedErr    string
+	}{
+		{
+			name:           "uses loadDev for empty APP_ENV",
+			setEnv:         "",
+			expectedEnvVal: ENVLOCAL,
+		},
+		{
+			name:           "uses loadDev for APP_ENV = local",
+			setEnv:         ENVLOCAL,
+			expectedEnvVal: ENVLOCAL,
+		},
+		{
+			name:           "uses loadStaging for APP_ENV = stg",
+			setEnv:         ENVSTG,
+			expectedEnvVal: ENVSTG,
+		},
+		{
+			name:           "uses loadProd for APP_ENV = live",
+			setEnv:         ENVLIVE,
+			expectedEnvVal: ENVLIVE,
+		},
+		{
+			name:        "rejects unknown APP_ENV",
+			setEnv:      "notarealenv",
+			expectedErr: `env: must be one of local, stg or live, got "notarealenv"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("APP_ENV", tc.setEnv)
+			cfg, err := Load(nil)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedEnvVal, cfg.Env)
+			assert.Equal(t, tc.expectedEnvVal, cfg.Tracing.Environment)
+		})
+	}
+
+	t.Run("loadDev returns local config", func(t *testing.T) {
+		cfg := loadDev()
+		assert.Equal(t, ENVLOCAL, cfg.Env)
+	})
+	t.Run("loadStaging returns stg config", func(t *testing.T) {
+		cfg := loadStaging()
+		assert.Equal(t, ENVSTG, cfg.Env)
+	})
+	t.Run("loadProd returns live config", func(t *testing.T) {
+		cfg := loadProd()
+		assert.Equal(t, ENVLIVE, cfg.Env)
+	})
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	t.Run("local logs readable debug output and stops at once", func(t *testing.T) {
+		t.Setenv("APP_ENV", ENVLOCAL)
+		cfg, err := Load(nil)
+		require.NoError(t, err)
+		assert.Equal(t, logger.FormatConsole, cfg.Logging.Format)
+		assert.Equal(t, "debug", cfg.Logging.Level)
+		assert.False(t, cfg.Logging.Sampling)
+		assert.Zero(t, cfg.ShutdownDelay)
+		assert.NotEmpty(t, cfg.PostgresDSN, "targets the docker-compose database")
+	})
+
+	t.Run("deployed environments log sampled JSON and wait for load balancers", func(t *testing.T) {
+		t.Setenv("APP_ENV", ENVLIVE)
+		t.Setenv("POSTGRES_DSN", "postgres://db/uala")
+		t.Setenv("JWT_KEYS", testJWTKeys)
+		cfg, err := Load(nil)
+		require.NoError(t, err)
+		assert.Equal(t, logger.FormatJSON, cfg.Logging.Format)
+		assert.Equal(t, "info", cfg.Logging.Level)
+		assert.True(t, cfg.Logging.Sampling)
+		assert.Equal(t, 5*time.Second, cfg.ShutdownDelay)
+		assert.Equal(t, "30/1m,burst=10", cfg.RateLimits[ratelimit.LimitPostTweet])
+	})
+
+	t.Run("deployed environments require a database", func(t *testing.T) {
+		t.Setenv("APP_ENV", ENVSTG)
+		_, err := Load(nil)
+		assert.ErrorContains(t, err, "postgres.dsn: is required")
+	})
+
+	t.Run("deployed environments require JWT keys", func(t *testing.T) {
+		t.Setenv("APP_ENV", ENVLIVE)
+		t.Setenv("POSTGRES_DSN", "postgres://db/uala")
+		_, err := Load(nil)
+		assert.ErrorContains(t, err, "auth.jwt_keys: is required in live")
+	})
+}
+
+func TestLoad_Layers(t *testing.T) {
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(yamlFile, []byte(`
+server:
+  port: 8081
+  write_timeout: 1m
+limits:
+  tweet_max_length: 500
+logging:
+  level: warn
+legacy:
+  sunset_at: 2027-06-01T00:00:00Z
+`), 0o600))
+
+	t.Run("file overrides defaults", func(t *testing.T) {
+		cfg, err := Load([]string{"--config", yamlFile})
+		require.NoError(t, err)
+		assert.Equal(t, "8081", cfg.ServerPort)
+		assert.Equal(t, time.Minute, cfg.HTTPWriteTimeout)
+		assert.Equal(t, 500, cfg.MaxContentLength)
+		assert.Equal(t, "warn", cfg.Logging.Level)
+		assert.Equal(t, time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC), cfg.LegacySunsetAt.UTC())
+		assert.Equal(t, 15*time.Second, cfg.HTTPReadTimeout, "unset keys keep their default")
+	})
+
+	t.Run("env overrides file and flags override env", func(t *testing.T) {
+		t.Setenv("CONFIG_FILE", yamlFile)
+		t.Setenv("PORT", "8082")
+		t.Setenv("LOG_LEVEL", "error")
+		cfg, err := Load([]string{"--logging.level=debug"})
+		require.NoError(t, err)
+		assert.Equal(t, "8082", cfg.ServerPort)
+		assert.Equal(t, "debug", cfg.Logging.Level)
+		assert.Equal(t, 500, cfg.MaxContentLength)
+	})
+
+	t.Run("environment can come from the file", func(t *testing.T) {
+		tomlFile := filepath.Join(dir, "config.toml")
+		require.NoError(t, os.WriteFile(tomlFile, []byte(`
+env = "stg"
+
+[postgres]
+dsn = "postgres://db/uala"
+max_conns = 20
+
+[auth]
+jwt_keys = "k1:HS256:MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+[limits]
+timeline_max_limit = 200
+`), 0o600))
+
+		cfg, err := Load([]string{"--config", tomlFile})
+		require.NoError(t, err)
+		assert.Equal(t, ENVSTG, cfg.Env)
+		assert.Equal(t, logger.FormatJSON, cfg.Logging.Format, "stg defaults")
+		assert.Equal(t, 20, cfg.PostgresMaxConns)
+		assert.Equal(t, 200, cfg.TimelinePagination.MaxLimit)
+	})
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	t.Run("every bad setting is listed", func(t *testing.T) {
+		t.Setenv("PORT", "http")
+		t.Setenv("TIMELINE_CACHE_TTL", "soon")
+		t.Setenv("RATE_LIMIT_POST_TWEET", "many")
+		t.Setenv("RATE_LIMIT_BACKEND", "disk")
+		cfg, err := Load([]string{"--limits.timeline_default_limit=500", "--tracing.exporter=zipkin"})
+
+		assert.Nil(t, cfg)
+		var invalid *InvalidError
+		require.ErrorAs(t, err, &invalid)
+		msg := err.Error()
+		assert.Contains(t, msg, `server.port: must be a port number, got "http"`)
+		assert.Contains(t, msg, "timeline_cache.ttl: time: invalid duration \"soon\" (from env TIMELINE_CACHE_TTL)")
+		assert.Contains(t, msg, "rate_limits.post_tweet:")
+		assert.Contains(t, msg, `rate_limits.backend: must be memory or redis, got "disk"`)
+		assert.Contains(t, msg, "limits.timeline_default_limit: must be between 1 and limits.timeline_max_limit")
+		assert.Contains(t, msg, `tracing.exporter: must be none, stdout or otlp, got "zipkin"`)
+		assert.Len(t, invalid.Errs, 6)
+	})
+
+	t.Run("unknown file keys are rejected", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yml")
+		require.NoError(t, os.WriteFile(path, []byte("server:\n  prot: 8080\n"), 0o600))
+		_, err := Load([]string{"--config", path})
+		assert.ErrorContains(t, err, "server.prot: unknown setting in file "+path)
+	})
+
+	t.Run("unsupported file format", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		require.NoError(t, os.WriteFile(path, []byte("{}"), 0o600))
+		_, err := Load([]string{"--config", path})
+		assert.ErrorContains(t, err, `unsupported format ".json"`)
+	})
+
+	t.Run("unknown flags are rejected", func(t *testing.T) {
+		_, err := Load([]string{"--server.prot=8080"})
+		assert.ErrorContains(t, err, "flag provided but not defined: -server.prot")
+	})
+}
+
+func TestPrint(t *testing.T) {
+	t.Setenv("ADMIN_TOKEN", "s3cret-admin")
+	cfg, err := Load([]string{"--server.port=8081"})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, Print(&out, cfg))
+
+	printed := out.String()
+	assert.NotContains(t, printed, "s3cret-admin")
+	assert.NotContains(t, printed, "postgres:postgres", "the DSN carries a password")
+	assert.Contains(t, printed, "admin_token: '"+logger.Redacted+"'")
+	assert.Contains(t, printed, `jwt_keys: ""`, "unset secrets print empty")
+	assert.Contains(t, printed, `port: "8081"`)
+
+	t.Run("output loads back", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "printed.yaml")
+		require.NoError(t, os.WriteFile(path, out.Bytes(), 0o600))
+		t.Setenv("ADMIN_TOKEN", "")
+
+		reloaded, err := Load([]string{"--config", path, "--postgres.dsn", cfg.PostgresDSN, "--auth.admin_token", cfg.AdminToken})
+		require.NoError(t, err)
+		assert.Equal(t, cfg, reloaded)
+	})
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// readFile decodes a YAML or TOML file, picked by extension, into values
+// keyed like the settings: nested tables join their names with dots.
+func readFile(path string) (map[string]string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	doc := map[string]any{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, &doc)
+	case ".toml":
+		err = toml.Unmarshal(raw, &doc)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported format %q, use .yaml, .yml or .toml", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	flattenInto(values, "", doc)
+	return values, nil
+}
+
+func flattenInto(values map[string]string, prefix string, doc map[string]any) {
+	for name, v := range doc {
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		switch v := v.(type) {
+		case map[string]any:
+			flattenInto(values, key, v)
+		case time.Time:
+			values[key] = v.Format(time.RFC3339)
+		case nil:
+			values[key] = ""
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"ualaTwitter/internal/platform/logger"
+)
+
+// Print writes cfg as a YAML config file, with secrets redacted. Unset
+// secrets print empty so it is visible they are missing.
+func Print(w io.Writer, cfg *Config) error {
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+	for _, s := range settings {
+		value, tag := s.get(cfg), s.tag
+		if s.secret && value != "" {
+			value, tag = logger.Redacted, "!!str"
+		}
+
+		table := doc
+		parts := strings.Split(s.key, ".")
+		for _, part := range parts[:len(parts)-1] {
+			table = child(table, part)
+		}
+		table.Content = append(table.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: parts[len(parts)-1]},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value},
+		)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// child returns the mapping under name in table, adding it when missing.
+func child(table *yaml.Node, name string) *yaml.Node {
+	for i := 0; i < len(table.Content); i += 2 {
+		if table.Content[i].Value == name {
+			return table.Content[i+1]
+		}
+	}
+	next := &yaml.Node{Kind: yaml.MappingNode}
+	table.Content = append(table.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, next)
+	return next
+}
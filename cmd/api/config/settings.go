@@ -0,0 +1,192 @@
+package config
+
+import (
+	"strconv"
+	"time"
+
+	"ualaTwitter/internal/platform/ratelimit"
+)
+
+const keyEnv = "env"
+
+// setting is one configurable value. key names it in files (nested by its
+// dots) and as a flag; env is its environment variable. Secrets are
+// redacted when printed, and tag is the YAML type it prints as.
+type setting struct {
+	key    string
+	env    string
+	secret bool
+	tag    string
+	set    func(cfg *Config, raw string) error
+	get    func(cfg *Config) string
+}
+
+func (s setting) apply(cfg *Config, raw, source string) error {
+	if err := s.set(cfg, raw); err != nil {
+		return &SettingError{Key: s.key, Source: source, Err: err}
+	}
+	return nil
+}
+
+func (s setting) redacted() setting {
+	s.secret = true
+	return s
+}
+
+func (s setting) tagged(tag string) setting {
+	s.tag = tag
+	return s
+}
+
+// SettingError is a value that could not be parsed.
+type SettingError struct {
+	Key    string
+	Source string
+	Err    error
+}
+
+func (e *SettingError) Error() string {
+	return e.Key + ": " + e.Err.Error() + " (from " + e.Source + ")"
+}
+
+func (e *SettingError) Unwrap() error {
+	return e.Err
+}
+
+func field[T any](key, env string, ptr func(*Config) *T, parse func(string) (T, error), format func(T) string) setting {
+	return setting{
+		key: key,
+		env: env,
+		tag: "!!str",
+		set: func(cfg *Config, raw string) error {
+			v, err := parse(raw)
+			if err != nil {
+				return err
+			}
+			*ptr(cfg) = v
+			return nil
+		},
+		get: func(cfg *Config) string { return format(*ptr(cfg)) },
+	}
+}
+
+func stringField(key, env string, ptr func(*Config) *string) setting {
+	return field(key, env, ptr, func(s string) (string, error) { return s, nil }, func(s string) string { return s })
+}
+
+func intField(key, env string, ptr func(*Config) *int) setting {
+	return field(key, env, ptr, strconv.Atoi, strconv.Itoa).tagged("!!int")
+}
+
+func boolField(key, env string, ptr func(*Config) *bool) setting {
+	return field(key, env, ptr, strconv.ParseBool, strconv.FormatBool).tagged("!!bool")
+}
+
+func floatField(key, env string, ptr func(*Config) *float64) setting {
+	return field(key, env, ptr,
+		func(s string) (float64, error) { return strconv.ParseFloat(s, 64) },
+		func(f float64) string { return strconv.FormatFloat(f, 'g', -1, 64) },
+	).tagged("!!float")
+}
+
+func durationField(key, env string, ptr func(*Config) *time.Duration) setting {
+	return field(key, env, ptr, time.ParseDuration, time.Duration.String)
+}
+
+func timeField(key, env string, ptr func(*Config) *time.Time) setting {
+	return field(key, env, ptr,
+		func(s string) (time.Time, error) { return time.Parse(time.RFC3339, s) },
+		func(t time.Time) string { return t.Format(time.RFC3339) },
+	)
+}
+
+// rateLimitField sets one entry of Config.RateLimits.
+func rateLimitField(key, env, name string) setting {
+	return setting{
+		key: key,
+		env: env,
+		tag: "!!str",
+		set: func(cfg *Config, raw string) error {
+			cfg.RateLimits[name] = raw
+			return nil
+		},
+		get: func(cfg *Config) string { return cfg.RateLimits[name] },
+	}
+}
+
+// settings lists every setting in the order it is printed.
+var settings = []setting{
+	stringField(keyEnv, "APP_ENV", func(c *Config) *string { return &c.Env }),
+	stringField("version", "APP_VERSION", func(c *Config) *string { return &c.Version }),
+
+	stringField("server.port", "PORT", func(c *Config) *string { return &c.ServerPort }),
+	stringField("server.grpc_port", "GRPC_PORT", func(c *Config) *string { return &c.GRPCPort }),
+	durationField("server.read_header_timeout", "HTTP_READ_HEADER_TIMEOUT", func(c *Config) *time.Duration { return &c.HTTPReadHeaderTimeout }),
+	durationField("server.read_timeout", "HTTP_READ_TIMEOUT", func(c *Config) *time.Duration { return &c.HTTPReadTimeout }),
+	durationField("server.write_timeout", "HTTP_WRITE_TIMEOUT", func(c *Config) *time.Duration { return &c.HTTPWriteTimeout }),
+	durationField("server.idle_timeout", "HTTP_IDLE_TIMEOUT", func(c *Config) *time.Duration { return &c.HTTPIdleTimeout }),
+	durationField("server.dataset_timeout", "HTTP_DATASET_TIMEOUT", func(c *Config) *time.Duration { return &c.HTTPDatasetTimeout }),
+	durationField("server.shutdown_delay", "SHUTDOWN_DELAY", func(c *Config) *time.Duration { return &c.ShutdownDelay }),
+	durationField("server.shutdown_timeout", "SHUTDOWN_TIMEOUT", func(c *Config) *time.Duration { return &c.ShutdownTimeout }),
+
+	stringField("postgres.dsn", "POSTGRES_DSN", func(c *Config) *string { return &c.PostgresDSN }).redacted(),
+	intField("postgres.max_conns", "POSTGRES_MAX_CONNS", func(c *Config) *int { return &c.PostgresMaxConns }),
+	intField("postgres.min_conns", "POSTGRES_MIN_CONNS", func(c *Config) *int { return &c.PostgresMinConns }),
+
+	stringField("timeline_cache.backend", "TIMELINE_CACHE_BACKEND", func(c *Config) *string { return &c.TimelineCacheBackend }),
+	intField("timeline_cache.size", "TIMELINE_CACHE_SIZE", func(c *Config) *int { return &c.TimelineCacheSize }),
+	durationField("timeline_cache.ttl", "TIMELINE_CACHE_TTL", func(c *Config) *time.Duration { return &c.TimelineCacheTTL }),
+	stringField("redis.addr", "REDIS_ADDR", func(c *Config) *string { return &c.RedisAddr }),
+	stringField("redis.username", "REDIS_USERNAME", func(c *Config) *string { return &c.RedisUsername }),
+	stringField("redis.password", "REDIS_PASSWORD", func(c *Config) *string { return &c.RedisPassword }).redacted(),
+	boolField("redis.tls", "REDIS_TLS", func(c *Config) *bool { return &c.RedisTLS }),
+	intField("redis.pool_size", "REDIS_POOL_SIZE", func(c *Config) *int { return &c.RedisPoolSize }),
+
+	stringField("auth.jwt_keys", "JWT_KEYS", func(c *Config) *string { return &c.JWTKeys }).redacted(),
+	stringField("auth.jwt_active_kid", "JWT_ACTIVE_KID", func(c *Config) *string { return &c.JWTActiveKID }),
+	stringField("auth.jwt_issuer", "JWT_ISSUER", func(c *Config) *string { return &c.JWTIssuer }),
+	durationField("auth.jwt_ttl", "JWT_TTL", func(c *Config) *time.Duration { return &c.JWTTTL }),
+	stringField("auth.admin_token", "ADMIN_TOKEN", func(c *Config) *string { return &c.AdminToken }).redacted(),
+
+	rateLimitField("rate_limits.post_tweet", "RATE_LIMIT_POST_TWEET", ratelimit.LimitPostTweet),
+	rateLimitField("rate_limits.like_tweet", "RATE_LIMIT_LIKE_TWEET", ratelimit.LimitLikeTweet),
+	rateLimitField("rate_limits.follow_user", "RATE_LIMIT_FOLLOW_USER", ratelimit.LimitFollowUser),
+	rateLimitField("rate_limits.issue_token", "RATE_LIMIT_ISSUE_TOKEN", ratelimit.LimitIssueToken),
+	rateLimitField("rate_limits.create_user", "RATE_LIMIT_CREATE_USER", ratelimit.LimitCreateUser),
+	stringField("rate_limits.backend", "RATE_LIMIT_BACKEND", func(c *Config) *string { return &c.RateLimitBackend }),
+	durationField("idempotency.ttl", "IDEMPOTENCY_TTL", func(c *Config) *time.Duration { return &c.IdempotencyTTL }),
+	intField("idempotency.max_entries", "IDEMPOTENCY_MAX_ENTRIES", func(c *Config) *int { return &c.IdempotencyMaxEntries }),
+
+	intField("limits.tweet_max_length", "TWEET_MAX_LENGTH", func(c *Config) *int { return &c.MaxContentLength }),
+	intField("limits.timeline_default_limit", "TIMELINE_DEFAULT_LIMIT", func(c *Config) *int { return &c.TimelinePagination.DefaultLimit }),
+	intField("limits.timeline_max_limit", "TIMELINE_MAX_LIMIT", func(c *Config) *int { return &c.TimelinePagination.MaxLimit }),
+	intField("limits.timeline_max_offset", "TIMELINE_MAX_OFFSET", func(c *Config) *int { return &c.TimelinePagination.MaxOffset }),
+	stringField("feature_flags.file", "FEATURE_FLAGS_FILE", func(c *Config) *string { return &c.FeatureFlagsFile }),
+	durationField("feature_flags.reload_interval", "FEATURE_FLAGS_RELOAD_INTERVAL", func(c *Config) *time.Duration { return &c.FeatureFlagsReloadInterval }),
+	intField("graphql.max_depth", "GRAPHQL_MAX_DEPTH", func(c *Config) *int { return &c.GraphQLLimits.MaxDepth }),
+	intField("graphql.max_complexity", "GRAPHQL_MAX_COMPLEXITY", func(c *Config) *int { return &c.GraphQLLimits.MaxComplexity }),
+
+	stringField("tracing.exporter", "TRACING_EXPORTER", func(c *Config) *string { return &c.Tracing.Exporter }),
+	stringField("tracing.otlp_endpoint", "TRACING_OTLP_ENDPOINT", func(c *Config) *string { return &c.Tracing.OTLPEndpoint }),
+	floatField("tracing.sample_ratio", "TRACING_SAMPLE_RATIO", func(c *Config) *float64 { return &c.Tracing.SampleRatio }),
+
+	stringField("logging.format", "LOG_FORMAT", func(c *Config) *string { return &c.Logging.Format }),
+	stringField("logging.level", "LOG_LEVEL", func(c *Config) *string { return &c.Logging.Level }),
+	boolField("logging.sampling", "LOG_SAMPLING", func(c *Config) *bool { return &c.Logging.Sampling }),
+	stringField("logging.file", "LOG_FILE", func(c *Config) *string { return &c.Logging.File }),
+	intField("logging.file_max_size_mb", "LOG_FILE_MAX_SIZE_MB", func(c *Config) *int { return &c.Logging.FileMaxSizeMB }),
+	intField("logging.file_max_backups", "LOG_FILE_MAX_BACKUPS", func(c *Config) *int { return &c.Logging.FileMaxBackups }),
+	intField("logging.file_max_age_days", "LOG_FILE_MAX_AGE_DAYS", func(c *Config) *int { return &c.Logging.FileMaxAgeDays }),
+
+	timeField("legacy.deprecated_at", "LEGACY_ROUTES_DEPRECATED_AT", func(c *Config) *time.Time { return &c.LegacyDeprecatedAt }),
+	timeField("legacy.sunset_at", "LEGACY_ROUTES_SUNSET_AT", func(c *Config) *time.Time { return &c.LegacySunsetAt }),
+}
+
+func lookup(key string) *setting {
+	for i := range settings {
+		if settings[i].key == key {
+			return &settings[i]
+		}
+	}
+	return nil
+}
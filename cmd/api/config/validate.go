@@ -0,0 +1,169 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/platform/ratelimit"
+	"ualaTwitter/internal/platform/tracing"
+)
+
+type problems []error
+
+func (p *problems) add(key, format string, args ...any) {
+	*p = append(*p, fmt.Errorf("%s: "+format, append([]any{key}, args...)...))
+}
+
+// validate checks the loaded values, naming settings by key. It reports
+// every problem rather than stopping at the first.
+func validate(cfg *Config) []error {
+	var p problems
+
+	if cfg.PostgresDSN == "" {
+		p.add("postgres.dsn", "is required")
+	}
+	if cfg.PostgresMaxConns < 1 {
+		p.add("postgres.max_conns", "must be at least 1")
+	}
+	if cfg.PostgresMinConns < 0 || cfg.PostgresMinConns > cfg.PostgresMaxConns {
+		p.add("postgres.min_conns", "must be between 0 and postgres.max_conns")
+	}
+
+	if !validPort(cfg.ServerPort) {
+		p.add("server.port", "must be a port number, got %q", cfg.ServerPort)
+	}
+	if cfg.GRPCPort != "" && cfg.GRPCPort != "off" && !validPort(cfg.GRPCPort) {
+		p.add("server.grpc_port", "must be a port number or \"off\", got %q", cfg.GRPCPort)
+	}
+	if cfg.GRPCPort == cfg.ServerPort {
+		p.add("server.grpc_port", "must differ from server.port")
+	}
+	positive(&p, "server.read_header_timeout", cfg.HTTPReadHeaderTimeout)
+	positive(&p, "server.read_timeout", cfg.HTTPReadTimeout)
+	positive(&p, "server.write_timeout", cfg.HTTPWriteTimeout)
+	positive(&p, "server.idle_timeout", cfg.HTTPIdleTimeout)
+	positive(&p, "server.dataset_timeout", cfg.HTTPDatasetTimeout)
+	positive(&p, "server.shutdown_timeout", cfg.ShutdownTimeout)
+	if cfg.ShutdownDelay < 0 {
+		p.add("server.shutdown_delay", "must not be negative")
+	}
+
+	switch cfg.TimelineCacheBackend {
+	case CacheBackendLRU:
+		if cfg.TimelineCacheSize < 1 {
+			p.add("timeline_cache.size", "must be at least 1")
+		}
+	case CacheBackendRedis:
+	default:
+		p.add("timeline_cache.backend", "must be %s or %s, got %q", CacheBackendLRU, CacheBackendRedis, cfg.TimelineCacheBackend)
+	}
+	switch cfg.RateLimitBackend {
+	case RateLimitBackendMemory, RateLimitBackendRedis:
+	default:
+		p.add("rate_limits.backend", "must be %s or %s, got %q", RateLimitBackendMemory, RateLimitBackendRedis, cfg.RateLimitBackend)
+	}
+	if cfg.TimelineCacheBackend == CacheBackendRedis || cfg.RateLimitBackend == RateLimitBackendRedis {
+		if cfg.RedisAddr == "" {
+			p.add("redis.addr", "is required with a redis backend")
+		}
+		if cfg.RedisPoolSize < 1 {
+			p.add("redis.pool_size", "must be at least 1")
+		}
+	}
+	if cfg.TimelineCacheTTL < 0 {
+		p.add("timeline_cache.ttl", "must not be negative")
+	}
+
+	// Outside local every replica and restart must sign with the same keys,
+	// or sessions break across them.
+	if keys, err := auth.ParseKeys(cfg.JWTKeys); err != nil {
+		p.add("auth.jwt_keys", "%v", err)
+	} else if len(keys) == 0 && cfg.Env != ENVLOCAL {
+		p.add("auth.jwt_keys", "is required in %s", cfg.Env)
+	}
+	positive(&p, "auth.jwt_ttl", cfg.JWTTTL)
+
+	for _, s := range settings {
+		if !strings.HasPrefix(s.key, "rate_limits.") || s.key == "rate_limits.backend" {
+			continue
+		}
+		spec := s.get(cfg)
+		if spec == "" || spec == "off" {
+			continue
+		}
+		if _, err := ratelimit.ParseLimit(spec); err != nil {
+			p.add(s.key, "%v", err)
+		}
+	}
+	positive(&p, "idempotency.ttl", cfg.IdempotencyTTL)
+	if cfg.IdempotencyMaxEntries < 1 {
+		p.add("idempotency.max_entries", "must be at least 1")
+	}
+
+	if cfg.MaxContentLength < 1 {
+		p.add("limits.tweet_max_length", "must be at least 1")
+	}
+	if cfg.TimelinePagination.MaxLimit < 1 {
+		p.add("limits.timeline_max_limit", "must be at least 1")
+	}
+	if cfg.TimelinePagination.DefaultLimit < 1 || cfg.TimelinePagination.DefaultLimit > cfg.TimelinePagination.MaxLimit {
+		p.add("limits.timeline_default_limit", "must be between 1 and limits.timeline_max_limit")
+	}
+	if cfg.TimelinePagination.MaxOffset < 0 {
+		p.add("limits.timeline_max_offset", "must not be negative")
+	}
+	positive(&p, "feature_flags.reload_interval", cfg.FeatureFlagsReloadInterval)
+	if cfg.GraphQLLimits.MaxDepth < 0 {
+		p.add("graphql.max_depth", "must not be negative")
+	}
+	if cfg.GraphQLLimits.MaxComplexity < 0 {
+		p.add("graphql.max_complexity", "must not be negative")
+	}
+
+	switch cfg.Tracing.Exporter {
+	case "", tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	default:
+		p.add("tracing.exporter", "must be %s, %s or %s, got %q", tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP, cfg.Tracing.Exporter)
+	}
+	if cfg.Tracing.SampleRatio < 0 || cfg.Tracing.SampleRatio > 1 {
+		p.add("tracing.sample_ratio", "must be between 0 and 1")
+	}
+
+	if cfg.Logging.Format != logger.FormatJSON && cfg.Logging.Format != logger.FormatConsole {
+		p.add("logging.format", "must be %s or %s, got %q", logger.FormatJSON, logger.FormatConsole, cfg.Logging.Format)
+	}
+	if _, err := zapcore.ParseLevel(cfg.Logging.Level); err != nil {
+		p.add("logging.level", "%v", err)
+	}
+	if cfg.Logging.FileMaxSizeMB < 1 {
+		p.add("logging.file_max_size_mb", "must be at least 1")
+	}
+	if cfg.Logging.FileMaxBackups < 0 {
+		p.add("logging.file_max_backups", "must not be negative")
+	}
+	if cfg.Logging.FileMaxAgeDays < 0 {
+		p.add("logging.file_max_age_days", "must not be negative")
+	}
+
+	if !cfg.LegacySunsetAt.After(cfg.LegacyDeprecatedAt) {
+		p.add("legacy.sunset_at", "must be after legacy.deprecated_at")
+	}
+	return p
+}
+
+func positive(p *problems, key string, d time.Duration) {
+	if d <= 0 {
+		p.add(key, "must be positive")
+	}
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
@@ -0,0 +1,56 @@
+package grpcapi
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/errors/usecase"
+)
+
+// internalMessage replaces the message of unexpected failures so their
+// causes never reach clients, as on the HTTP API.
+const internalMessage = "an unexpected error occurred"
+
+// toStatus maps use case errors to gRPC status codes the way
+// httphelper.StatusFromError maps them to HTTP statuses.
+func toStatus(err error) error {
+	var ue *usecase.UseCaseError
+	if !errors.As(err, &ue) {
+		return status.Error(codes.Internal, internalMessage)
+	}
+
+	message := ue.Message
+	if message == "" && len(ue.Causes) > 0 && ue.Causes[0] != nil {
+		message = ue.Causes[0].Error()
+	}
+
+	switch ue.Type {
+	case usecase.TypeInvalidParam:
+		return status.Error(codes.InvalidArgument, message)
+	case usecase.TypeNotFound:
+		return status.Error(codes.NotFound, message)
+	case usecase.TypeForbidden:
+		return status.Error(codes.PermissionDenied, message)
+	case usecase.TypeConflict:
+		return status.Error(codes.AlreadyExists, message)
+	case usecase.TypeUnauthorized:
+		return status.Error(codes.Unauthenticated, message)
+	default:
+		return status.Error(codes.Internal, internalMessage)
+	}
+}
+
+// authStatus maps errors of auth.Authenticator.Authenticate.
+func authStatus(err error) error {
+	switch {
+	case errors.Is(err, auth.ErrSessionLookup):
+		return status.Error(codes.Internal, internalMessage)
+	case errors.As(err, new(*usecase.UseCaseError)):
+		return toStatus(err)
+	default:
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+}
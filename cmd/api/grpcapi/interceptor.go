@@ -0,0 +1,105 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	twitterv1 "ualaTwitter/api/twitter/v1"
+	"ualaTwitter/internal/domain/apikey"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/logger"
+)
+
+// publicMethods need no credentials.
+var publicMethods = map[string]bool{
+	twitterv1.UserService_CreateUser_FullMethodName: true,
+}
+
+// methodScopes is the API key scope each authenticated method needs; user
+// sessions may call all of them. Methods missing here are rejected, so a
+// new RPC cannot be exposed by accident.
+var methodScopes = map[string]string{
+	twitterv1.UserService_FollowUser_FullMethodName:        apikey.ScopeFollowsWrite,
+	twitterv1.TweetService_PostTweet_FullMethodName:        apikey.ScopeTweetsWrite,
+	twitterv1.TweetService_LikeTweet_FullMethodName:        apikey.ScopeLikesWrite,
+	twitterv1.TimelineService_GetTimeline_FullMethodName:   apikey.ScopeTimelineRead,
+	twitterv1.TimelineService_WatchTimeline_FullMethodName: apikey.ScopeTimelineRead,
+}
+
+type authenticator interface {
+	Authenticate(ctx context.Context, authorization, apiKey string) (context.Context, error)
+}
+
+// authorize authenticates the caller from the "authorization" or
+// "x-api-key" metadata and checks the method scope.
+func authorize(ctx context.Context, authn authenticator, method string) (context.Context, error) {
+	if publicMethods[method] {
+		return ctx, nil
+	}
+	scope, ok := methodScopes[method]
+	if !ok {
+		return ctx, status.Error(codes.PermissionDenied, "method is not exposed")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx, err := authn.Authenticate(ctx, first(md, "authorization"), first(md, strings.ToLower(auth.APIKeyHeader)))
+	if err != nil {
+		return ctx, authStatus(err)
+	}
+	if userID, ok := auth.UserIDFromContext(ctx); ok {
+		logger.AddFields(ctx, zap.String("user_id", userID))
+	}
+	if !auth.Allowed(ctx, scope) {
+		return ctx, status.Error(codes.PermissionDenied, auth.ErrMissingScope.Error()+": "+scope)
+	}
+	return ctx, nil
+}
+
+func unaryAuth(authn authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorize(withLogger(ctx, info.FullMethod), authn, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamAuth(authn authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(withLogger(ss.Context(), info.FullMethod), authn, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// withLogger gives the call a logger tagged with the method, as the HTTP
+// access log middleware does for requests.
+func withLogger(ctx context.Context, method string) context.Context {
+	return logger.NewContext(ctx, logger.FromContext(ctx).With(zap.String("rpc_method", method)))
+}
+
+// authenticatedStream exposes the authenticated context to stream handlers.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
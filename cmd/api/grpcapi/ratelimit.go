@@ -0,0 +1,77 @@
+package grpcapi
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	twitterv1 "ualaTwitter/api/twitter/v1"
+	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/platform/ratelimit"
+)
+
+// methodLimits is the budget each method spends, the same one as its HTTP
+// route, so a caller cannot double its budget by switching transports.
+var methodLimits = map[string]string{
+	twitterv1.UserService_CreateUser_FullMethodName: ratelimit.LimitCreateUser,
+	twitterv1.UserService_FollowUser_FullMethodName: ratelimit.LimitFollowUser,
+	twitterv1.TweetService_PostTweet_FullMethodName: ratelimit.LimitPostTweet,
+	twitterv1.TweetService_LikeTweet_FullMethodName: ratelimit.LimitLikeTweet,
+}
+
+type rateLimiter interface {
+	Take(ctx context.Context, name, caller string) (ratelimit.Result, bool, error)
+}
+
+// limit spends one request of the method's budget. Rejected calls get
+// ResourceExhausted and a retry-after header in seconds. If the store fails
+// the call is let through, as on the HTTP API.
+func limit(ctx context.Context, limiter rateLimiter, method string, setHeader func(metadata.MD) error) error {
+	name, ok := methodLimits[method]
+	if !ok {
+		return nil
+	}
+
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+	result, limited, err := limiter.Take(ctx, name, ratelimit.CallerKey(ctx, remoteAddr))
+	if err != nil {
+		logger.FromContext(ctx).Warn("rate limit store failed, allowing call", zap.String("budget", name), zap.Error(err))
+		return nil
+	}
+	if !limited || result.Allowed {
+		return nil
+	}
+
+	_ = setHeader(metadata.Pairs("retry-after", strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds())))))
+	return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+}
+
+// unaryLimit runs after authentication, so calls are limited per user.
+func unaryLimit(limiter rateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		setHeader := func(md metadata.MD) error { return grpc.SetHeader(ctx, md) }
+		if err := limit(ctx, limiter, info.FullMethod, setHeader); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamLimit(limiter rateLimiter) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := limit(ss.Context(), limiter, info.FullMethod, ss.SetHeader); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
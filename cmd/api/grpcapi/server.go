@@ -0,0 +1,272 @@
+// Package grpcapi serves the use cases over gRPC, next to the HTTP API.
+// Both transports share the use cases and authentication; only the
+// framing differs.
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	twitterv1 "ualaTwitter/api/twitter/v1"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/usecase/create_user"
+	"ualaTwitter/internal/usecase/follow_user"
+	"ualaTwitter/internal/usecase/get_timeline"
+	"ualaTwitter/internal/usecase/like_tweet"
+	"ualaTwitter/internal/usecase/post_tweet"
+)
+
+// defaultWatchLimit is the page WatchTimeline sends first and re-reads on
+// every change.
+const defaultWatchLimit = 20
+
+type createUserService interface {
+	Execute(ctx context.Context, input create_user.Input) (create_user.Output, error)
+}
+
+type followUserService interface {
+	Execute(ctx context.Context, input follow_user.Input) error
+}
+
+type postTweetService interface {
+	Execute(ctx context.Context, input post_tweet.Input) (string, error)
+}
+
+type likeTweetService interface {
+	Execute(ctx context.Context, input like_tweet.Input) error
+}
+
+type getTimelineService interface {
+	Execute(ctx context.Context, input get_timeline.Input) ([]get_timeline.TweetTimeline, error)
+}
+
+type timelineWatcher interface {
+	Subscribe(userID string) (<-chan struct{}, func())
+}
+
+// Services are the use cases exposed over gRPC.
+type Services struct {
+	CreateUser  createUserService
+	FollowUser  followUserService
+	PostTweet   postTweetService
+	LikeTweet   likeTweetService
+	GetTimeline getTimelineService
+	// Watcher signals timeline changes to WatchTimeline streams.
+	Watcher timelineWatcher
+}
+
+// NewServer returns a gRPC server with every service registered behind
+// the authentication and rate limit interceptors. A nil limiter disables
+// rate limiting.
+func NewServer(authn authenticator, limiter rateLimiter, services Services, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unaryAuth(authn)),
+		grpc.ChainStreamInterceptor(streamAuth(authn)),
+	)
+	if limiter != nil {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(unaryLimit(limiter)),
+			grpc.ChainStreamInterceptor(streamLimit(limiter)),
+		)
+	}
+	server := grpc.NewServer(opts...)
+	twitterv1.RegisterUserServiceServer(server, &userServer{services: services})
+	twitterv1.RegisterTweetServiceServer(server, &tweetServer{services: services})
+	twitterv1.RegisterTimelineServiceServer(server, &timelineServer{services: services})
+	return server
+}
+
+type userServer struct {
+	twitterv1.UnimplementedUserServiceServer
+	services Services
+}
+
+func (s *userServer) CreateUser(ctx context.Context, req *twitterv1.CreateUserRequest) (*twitterv1.CreateUserResponse, error) {
+	output, err := s.services.CreateUser.Execute(ctx, create_user.Input{
+		Name:     req.GetName(),
+		Document: req.GetDocument(),
+		Password: req.GetPassword(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &twitterv1.CreateUserResponse{Id: output.ID}, nil
+}
+
+func (s *userServer) FollowUser(ctx context.Context, req *twitterv1.FollowUserRequest) (*twitterv1.FollowUserResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.services.FollowUser.Execute(ctx, follow_user.Input{
+		FollowerID: userID,
+		FolloweeID: req.GetFolloweeId(),
+	}); err != nil {
+		return nil, toStatus(err)
+	}
+	return &twitterv1.FollowUserResponse{}, nil
+}
+
+type tweetServer struct {
+	twitterv1.UnimplementedTweetServiceServer
+	services Services
+}
+
+func (s *tweetServer) PostTweet(ctx context.Context, req *twitterv1.PostTweetRequest) (*twitterv1.PostTweetResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id, err := s.services.PostTweet.Execute(ctx, post_tweet.Input{
+		UserID:  userID,
+		Content: req.GetContent(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &twitterv1.PostTweetResponse{Id: id}, nil
+}
+
+func (s *tweetServer) LikeTweet(ctx context.Context, req *twitterv1.LikeTweetRequest) (*twitterv1.LikeTweetResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.services.LikeTweet.Execute(ctx, like_tweet.Input{
+		TweetID: req.GetTweetId(),
+		UserID:  userID,
+	}); err != nil {
+		return nil, toStatus(err)
+	}
+	return &twitterv1.LikeTweetResponse{}, nil
+}
+
+type timelineServer struct {
+	twitterv1.UnimplementedTimelineServiceServer
+	services Services
+}
+
+func (s *timelineServer) GetTimeline(ctx context.Context, req *twitterv1.GetTimelineRequest) (*twitterv1.GetTimelineResponse, error) {
+	userID, err := callerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mode, err := timelineMode(req.GetMode())
+	if err != nil {
+		return nil, err
+	}
+	tweets, err := s.services.GetTimeline.Execute(ctx, get_timeline.Input{
+		UserID:       userID,
+		Limit:        int(req.GetLimit()),
+		Offset:       int(req.GetOffset()),
+		Mode:         mode,
+		ExpandAuthor: req.GetExpandAuthor(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &twitterv1.GetTimelineResponse{Tweets: make([]*twitterv1.Tweet, 0, len(tweets))}
+	for _, t := range tweets {
+		resp.Tweets = append(resp.Tweets, toTweet(t))
+	}
+	return resp, nil
+}
+
+// WatchTimeline sends the newest page of the caller's timeline, then every
+// tweet that appears on it until the client goes away. Each change re-reads
+// the page and sends the tweets the stream has not sent yet, oldest first.
+func (s *timelineServer) WatchTimeline(req *twitterv1.WatchTimelineRequest, stream twitterv1.TimelineService_WatchTimelineServer) error {
+	ctx := stream.Context()
+	userID, err := callerID(ctx)
+	if err != nil {
+		return err
+	}
+	if s.services.Watcher == nil {
+		return status.Error(codes.Unimplemented, "timeline watching is disabled")
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultWatchLimit
+	}
+	input := get_timeline.Input{
+		UserID:       userID,
+		Limit:        limit,
+		Mode:         get_timeline.ModeChronological,
+		ExpandAuthor: req.GetExpandAuthor(),
+	}
+
+	// Subscribe before the first read so no change between both is lost.
+	changes, cancel := s.services.Watcher.Subscribe(userID)
+	defer cancel()
+
+	sent := map[string]bool{}
+	for {
+		tweets, err := s.services.GetTimeline.Execute(ctx, input)
+		if err != nil {
+			return toStatus(err)
+		}
+
+		page := make(map[string]bool, len(tweets))
+		for i := len(tweets) - 1; i >= 0; i-- {
+			page[tweets[i].ID] = true
+			if sent[tweets[i].ID] {
+				continue
+			}
+			if err := stream.Send(&twitterv1.WatchTimelineResponse{Tweet: toTweet(tweets[i])}); err != nil {
+				return err
+			}
+		}
+		// Only the current page can repeat, so the set stays bounded.
+		sent = page
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, open := <-changes:
+			// The watcher closes on shutdown; clients should reconnect.
+			if !open {
+				return status.Error(codes.Unavailable, "server is shutting down")
+			}
+		}
+	}
+}
+
+func timelineMode(mode twitterv1.TimelineMode) (string, error) {
+	switch mode {
+	case twitterv1.TimelineMode_TIMELINE_MODE_UNSPECIFIED, twitterv1.TimelineMode_TIMELINE_MODE_CHRONOLOGICAL:
+		return get_timeline.ModeChronological, nil
+	case twitterv1.TimelineMode_TIMELINE_MODE_RANKED:
+		return get_timeline.ModeRanked, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "unknown timeline mode %d", mode)
+	}
+}
+
+func toTweet(t get_timeline.TweetTimeline) *twitterv1.Tweet {
+	tweet := &twitterv1.Tweet{
+		Id:        t.ID,
+		UserId:    t.UserID,
+		Content:   t.Content,
+		CreatedAt: timestamppb.New(t.CreatedAt),
+		Likes:     int64(t.Likes),
+		LikedByMe: t.LikedByMe,
+	}
+	if t.Author != nil {
+		tweet.Author = &twitterv1.Author{Id: t.Author.ID, Name: t.Author.Name}
+	}
+	return tweet
+}
+
+func callerID(ctx context.Context) (string, error) {
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "missing user ID")
+	}
+	return userID, nil
+}
@@ -0,0 +1,325 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	twitterv1 "ualaTwitter/api/twitter/v1"
+	"ualaTwitter/internal/domain/apikey"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/platform/notify"
+	"ualaTwitter/internal/platform/ratelimit"
+	"ualaTwitter/internal/usecase/create_user"
+	"ualaTwitter/internal/usecase/follow_user"
+	"ualaTwitter/internal/usecase/get_timeline"
+	"ualaTwitter/internal/usecase/like_tweet"
+	"ualaTwitter/internal/usecase/post_tweet"
+)
+
+// fakeAuthenticator accepts "Bearer good" as usr_1 and the API key
+// "key_read" as usr_1 with timeline:read.
+type fakeAuthenticator struct{}
+
+func (fakeAuthenticator) Authenticate(ctx context.Context, authorization, apiKey string) (context.Context, error) {
+	switch {
+	case apiKey == "key_read":
+		return auth.WithScopes(auth.WithUserID(ctx, "usr_1"), []string{apikey.ScopeTimelineRead}), nil
+	case apiKey != "":
+		return ctx, auth.ErrInvalidToken
+	case authorization == "Bearer good":
+		return auth.WithUserID(ctx, "usr_1"), nil
+	case authorization == "Bearer broken":
+		return ctx, auth.ErrSessionLookup
+	case authorization == "":
+		return ctx, auth.ErrMissingToken
+	default:
+		return ctx, auth.ErrInvalidToken
+	}
+}
+
+type fakeCreateUser struct{}
+
+func (fakeCreateUser) Execute(_ context.Context, input create_user.Input) (create_user.Output, error) {
+	if input.Name == "" {
+		return create_user.Output{}, usecase.InvalidParam("name is required")
+	}
+	return create_user.Output{ID: "usr_new"}, nil
+}
+
+type fakeFollowUser struct{ input follow_user.Input }
+
+func (f *fakeFollowUser) Execute(_ context.Context, input follow_user.Input) error {
+	f.input = input
+	return nil
+}
+
+type fakePostTweet struct{ err error }
+
+func (f fakePostTweet) Execute(_ context.Context, _ post_tweet.Input) (string, error) {
+	return "tw_1", f.err
+}
+
+type fakeLikeTweet struct{}
+
+func (fakeLikeTweet) Execute(_ context.Context, _ like_tweet.Input) error {
+	return usecase.NotFound("tweet not found")
+}
+
+// fakeTimeline serves the tweets it holds, newest first.
+type fakeTimeline struct {
+	mu     sync.Mutex
+	tweets []get_timeline.TweetTimeline
+	input  get_timeline.Input
+}
+
+func (f *fakeTimeline) Execute(_ context.Context, input get_timeline.Input) ([]get_timeline.TweetTimeline, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.input = input
+	return append([]get_timeline.TweetTimeline(nil), f.tweets...), nil
+}
+
+func (f *fakeTimeline) add(t get_timeline.TweetTimeline) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tweets = append([]get_timeline.TweetTimeline{t}, f.tweets...)
+}
+
+type env struct {
+	users    twitterv1.UserServiceClient
+	tweets   twitterv1.TweetServiceClient
+	timeline twitterv1.TimelineServiceClient
+}
+
+func newEnv(t *testing.T, services Services) env {
+	t.Helper()
+	return newLimitedEnv(t, nil, services)
+}
+
+func newLimitedEnv(t *testing.T, limiter rateLimiter, services Services) env {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(fakeAuthenticator{}, limiter, services)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return env{
+		users:    twitterv1.NewUserServiceClient(conn),
+		tweets:   twitterv1.NewTweetServiceClient(conn),
+		timeline: twitterv1.NewTimelineServiceClient(conn),
+	}
+}
+
+func withAuth(ctx context.Context, pairs ...string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+func TestServer_Unary(t *testing.T) {
+	follow := &fakeFollowUser{}
+	e := newEnv(t, Services{
+		CreateUser:  fakeCreateUser{},
+		FollowUser:  follow,
+		PostTweet:   fakePostTweet{err: errors.New("db down")},
+		LikeTweet:   fakeLikeTweet{},
+		GetTimeline: &fakeTimeline{},
+	})
+	ctx := context.Background()
+	bearer := withAuth(ctx, "authorization", "Bearer good")
+
+	t.Run("create user is public", func(t *testing.T) {
+		resp, err := e.users.CreateUser(ctx, &twitterv1.CreateUserRequest{Name: "Ana"})
+		require.NoError(t, err)
+		assert.Equal(t, "usr_new", resp.GetId())
+	})
+
+	t.Run("invalid param maps to InvalidArgument", func(t *testing.T) {
+		_, err := e.users.CreateUser(ctx, &twitterv1.CreateUserRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "name is required")
+	})
+
+	t.Run("follow uses the authenticated caller", func(t *testing.T) {
+		_, err := e.users.FollowUser(bearer, &twitterv1.FollowUserRequest{FolloweeId: "usr_2"})
+		require.NoError(t, err)
+		assert.Equal(t, follow_user.Input{FollowerID: "usr_1", FolloweeID: "usr_2"}, follow.input)
+	})
+
+	t.Run("missing credentials", func(t *testing.T) {
+		_, err := e.users.FollowUser(ctx, &twitterv1.FollowUserRequest{FolloweeId: "usr_2"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, err := e.users.FollowUser(withAuth(ctx, "authorization", "Bearer bad"), &twitterv1.FollowUserRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("session lookup failure is internal", func(t *testing.T) {
+		_, err := e.users.FollowUser(withAuth(ctx, "authorization", "Bearer broken"), &twitterv1.FollowUserRequest{})
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, internalMessage, status.Convert(err).Message())
+	})
+
+	t.Run("API key without the scope", func(t *testing.T) {
+		_, err := e.tweets.PostTweet(withAuth(ctx, "x-api-key", "key_read"), &twitterv1.PostTweetRequest{Content: "hi"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("API key with the scope", func(t *testing.T) {
+		_, err := e.timeline.GetTimeline(withAuth(ctx, "x-api-key", "key_read"), &twitterv1.GetTimelineRequest{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("unexpected errors do not leak", func(t *testing.T) {
+		_, err := e.tweets.PostTweet(bearer, &twitterv1.PostTweetRequest{Content: "hi"})
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, internalMessage, status.Convert(err).Message())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := e.tweets.LikeTweet(bearer, &twitterv1.LikeTweetRequest{TweetId: "tw_x"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), map[string]ratelimit.Limit{
+		ratelimit.LimitCreateUser: {Requests: 1, Window: time.Hour},
+		ratelimit.LimitFollowUser: {Requests: 1, Window: time.Hour},
+	})
+	e := newLimitedEnv(t, limiter, Services{
+		CreateUser:  fakeCreateUser{},
+		FollowUser:  &fakeFollowUser{},
+		GetTimeline: &fakeTimeline{},
+	})
+	ctx := context.Background()
+	bearer := withAuth(ctx, "authorization", "Bearer good")
+
+	t.Run("public methods are limited per client", func(t *testing.T) {
+		_, err := e.users.CreateUser(ctx, &twitterv1.CreateUserRequest{Name: "Ana"})
+		require.NoError(t, err)
+
+		var header metadata.MD
+		_, err = e.users.CreateUser(ctx, &twitterv1.CreateUserRequest{Name: "Ana"}, grpc.Header(&header))
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, []string{"3600"}, header.Get("retry-after"))
+	})
+
+	t.Run("authenticated methods are limited per user", func(t *testing.T) {
+		_, err := e.users.FollowUser(bearer, &twitterv1.FollowUserRequest{FolloweeId: "usr_2"})
+		require.NoError(t, err)
+
+		_, err = e.users.FollowUser(bearer, &twitterv1.FollowUserRequest{FolloweeId: "usr_3"})
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("methods without a budget are not limited", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			_, err := e.timeline.GetTimeline(bearer, &twitterv1.GetTimelineRequest{})
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestServer_GetTimeline(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	timeline := &fakeTimeline{tweets: []get_timeline.TweetTimeline{
+		{ID: "tw_1", UserID: "usr_2", Content: "hello", Likes: 3, CreatedAt: createdAt, LikedByMe: true, Author: &get_timeline.Author{ID: "usr_2", Name: "Bob"}},
+	}}
+	e := newEnv(t, Services{GetTimeline: timeline})
+
+	resp, err := e.timeline.GetTimeline(withAuth(context.Background(), "authorization", "Bearer good"), &twitterv1.GetTimelineRequest{
+		Limit: 5, Offset: 1, Mode: twitterv1.TimelineMode_TIMELINE_MODE_RANKED, ExpandAuthor: true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, get_timeline.Input{UserID: "usr_1", Limit: 5, Offset: 1, Mode: get_timeline.ModeRanked, ExpandAuthor: true}, timeline.input)
+	require.Len(t, resp.GetTweets(), 1)
+	tweet := resp.GetTweets()[0]
+	assert.Equal(t, "tw_1", tweet.GetId())
+	assert.Equal(t, int64(3), tweet.GetLikes())
+	assert.True(t, tweet.GetLikedByMe())
+	assert.Equal(t, createdAt, tweet.GetCreatedAt().AsTime())
+	assert.Equal(t, "Bob", tweet.GetAuthor().GetName())
+}
+
+func TestServer_WatchTimeline(t *testing.T) {
+	hub := notify.NewHub()
+	timeline := &fakeTimeline{tweets: []get_timeline.TweetTimeline{
+		{ID: "tw_2", Content: "second"},
+		{ID: "tw_1", Content: "first"},
+	}}
+	e := newEnv(t, Services{GetTimeline: timeline, Watcher: hub})
+
+	ctx, cancel := context.WithCancel(withAuth(context.Background(), "authorization", "Bearer good"))
+	defer cancel()
+	stream, err := e.timeline.WatchTimeline(ctx, &twitterv1.WatchTimelineRequest{})
+	require.NoError(t, err)
+
+	recv := func() string {
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		return resp.GetTweet().GetId()
+	}
+
+	// The first page arrives oldest first.
+	assert.Equal(t, "tw_1", recv())
+	assert.Equal(t, "tw_2", recv())
+	assert.Equal(t, defaultWatchLimit, timeline.input.Limit)
+
+	timeline.add(get_timeline.TweetTimeline{ID: "tw_3", Content: "third"})
+	require.NoError(t, hub.Invalidate(context.Background(), "usr_1"))
+	assert.Equal(t, "tw_3", recv())
+
+	cancel()
+	assert.Eventually(t, func() bool { return hub.Subscribers() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestServer_WatchTimeline_Shutdown(t *testing.T) {
+	hub := notify.NewHub()
+	timeline := &fakeTimeline{tweets: []get_timeline.TweetTimeline{{ID: "tw_1"}}}
+	e := newEnv(t, Services{GetTimeline: timeline, Watcher: hub})
+
+	stream, err := e.timeline.WatchTimeline(withAuth(context.Background(), "authorization", "Bearer good"), &twitterv1.WatchTimelineRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	hub.Close()
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestServer_WatchTimeline_Disabled(t *testing.T) {
+	e := newEnv(t, Services{GetTimeline: &fakeTimeline{}})
+
+	stream, err := e.timeline.WatchTimeline(withAuth(context.Background(), "authorization", "Bearer good"), &twitterv1.WatchTimelineRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -2,76 +2,448 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
+	"flag"
 	"github.com/gorilla/mux"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"log"
-	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"ualaTwitter/cmd/api/config"
+	"ualaTwitter/cmd/api/grpcapi"
+	"ualaTwitter/cmd/api/routes/handlers/admin"
+	authhandler "ualaTwitter/cmd/api/routes/handlers/auth"
+	"ualaTwitter/cmd/api/routes/handlers/graph"
 	"ualaTwitter/cmd/api/routes/handlers/health"
+	"ualaTwitter/internal/domain/credential"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/cache"
+	"ualaTwitter/internal/platform/dataset"
+	"ualaTwitter/internal/platform/featureflag"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/platform/idempotency"
 	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/platform/metrics"
+	"ualaTwitter/internal/platform/notify"
+	"ualaTwitter/internal/platform/password"
+	"ualaTwitter/internal/platform/ratelimit"
 	"ualaTwitter/internal/platform/repository/memory"
+	"ualaTwitter/internal/platform/repository/observed"
 	"ualaTwitter/internal/platform/repository/postgres"
+	"ualaTwitter/internal/platform/tracing"
+	"ualaTwitter/internal/usecase/authenticate_api_key"
+	"ualaTwitter/internal/usecase/change_password"
+	"ualaTwitter/internal/usecase/create_api_key"
 	"ualaTwitter/internal/usecase/create_user"
+	"ualaTwitter/internal/usecase/export_dataset"
+	"ualaTwitter/internal/usecase/import_dataset"
 
 	"ualaTwitter/cmd/api/routes"
 	"ualaTwitter/cmd/api/routes/handlers/tweet"
 	"ualaTwitter/cmd/api/routes/handlers/user"
 
 	"ualaTwitter/internal/usecase/follow_user"
+	"ualaTwitter/internal/usecase/get_follow_graph"
 	"ualaTwitter/internal/usecase/get_timeline"
+	"ualaTwitter/internal/usecase/issue_token"
 	"ualaTwitter/internal/usecase/like_tweet"
+	"ualaTwitter/internal/usecase/list_api_keys"
 	"ualaTwitter/internal/usecase/post_tweet"
+	"ualaTwitter/internal/usecase/purge_user_tweets"
+	"ualaTwitter/internal/usecase/recount_likes"
+	"ualaTwitter/internal/usecase/reset_password"
+	"ualaTwitter/internal/usecase/revoke_api_key"
 )
 
 func main() {
-	cfg := config.Load()
+	args := os.Args[1:]
+	if len(args) >= 2 && args[0] == "config" && args[1] == "print" {
+		printConfig(args[2:])
+		return
+	}
+
+	cfg := loadConfig(args)
+	if err := logger.Init(cfg.Logging); err != nil {
+		log.Fatalf("Failed to configure logging: %v", err)
+	}
 	ctx := context.Background()
-	conn := initializePsx(ctx, cfg.PostgresDSN)
+	pool := initializePsx(ctx, cfg)
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
+	if err != nil {
+		logger.Log.Fatal("failed to configure tracing", zap.Error(err))
+	}
+
+	// === Metrics ===
+	m := metrics.New()
 
 	// === Repositories ===
-	memoryUserRepository := memory.NewInMemoryUserRepository()
-	psxUserRepository := postgres.NewPostgresUserRepository(conn)
-	tweetRepo := memory.NewInMemoryTweetRepository()
-	likeRepo := memory.NewInMemoryLikeRepository()
+	memoryUsers := memory.NewInMemoryUserRepository()
+	memoryTweets := memory.NewInMemoryTweetRepository()
+	memoryLikes := memory.NewInMemoryLikeRepository()
+	repositorySizes := map[string]func() int{
+		"users":        memoryUsers.Count,
+		"tweets":       memoryTweets.Count,
+		"likes":        memoryLikes.Count,
+		"follow_edges": memoryUsers.FollowEdges,
+	}
+	for name, size := range repositorySizes {
+		m.RegisterRepositorySize(name, size)
+	}
+
+	memoryUserRepository := observed.NewUserRepository(memoryUsers, m, "users")
+	psxUserRepository := observed.NewUserStore(postgres.NewPostgresUserRepository(pool), m, "users_postgres")
+	tweetRepo := observed.NewTweetRepository(memoryTweets, m, "tweets")
+	likeRepo := observed.NewLikeRepository(memoryLikes, m, "likes")
+	credentialRepo := observed.NewCredentialRepository(postgres.NewPostgresCredentialRepository(pool), m, "credentials")
+	apiKeyRepo := observed.NewAPIKeyRepository(postgres.NewPostgresAPIKeyRepository(pool), m, "api_keys")
+
+	// === Caches ===
+	timelineCacheStore := initializeTimelineCache(cfg)
+	rateLimitStore := initializeRateLimitStore(cfg)
+	rateLimiter := initializeRateLimiter(cfg, rateLimitStore)
+
+	// === Feature flags ===
+	featureFlags := featureflag.NewStore(cfg.FeatureFlagsFile)
+	if err := featureFlags.Load(); err != nil {
+		logger.Log.Fatal("failed to load feature flags", zap.Error(err))
+	}
+	// Background workers run until shutdown cancels them.
+	workers, stopWorkers := context.WithCancel(ctx)
+	go featureFlags.Watch(workers, cfg.FeatureFlagsReloadInterval)
+
+	// === Auth ===
+	tokenManager := initializeTokenManager(cfg)
+	passwordHasher := password.NewArgon2Hasher(password.DefaultParams())
 
 	// === Usecases ===
-	postTweetService := post_tweet.NewPostTweetService(tweetRepo, memoryUserRepository)
-	followUserService := follow_user.NewFollowUserService(memoryUserRepository)
-	getTimelineService := get_timeline.NewGetTimelineService(tweetRepo, memoryUserRepository)
-	createUserService := create_user.NewCreateUserService(psxUserRepository, memoryUserRepository)
-	likeTweetService := like_tweet.NewLikeTweetService(tweetRepo, likeRepo)
+	timelineRanker := get_timeline.NewWeightedRanker(
+		get_timeline.NewLikeAffinitySource(likeRepo, tweetRepo),
+		get_timeline.DefaultRankingWeights(),
+	)
+	timelineBuilder := get_timeline.NewGetTimelineService(tweetRepo, memoryUserRepository, likeRepo, timelineRanker)
+	timelineBuilder.FanIn = m
+	timelineBuilder.Pagination = cfg.TimelinePagination
+	timelineBuilder.Flags = featureFlags
+	getTimelineService := get_timeline.NewCachedGetTimelineService(
+		timelineBuilder,
+		timelineCacheStore,
+		cfg.TimelineCacheTTL,
+	)
+	getTimelineService.Pagination = cfg.TimelinePagination
+	// Writes drop cached timelines and wake the gRPC timeline watchers.
+	timelineHub := notify.NewHub()
+	timelineInvalidators := cache.Invalidators{getTimelineService, timelineHub}
+	postTweetBuilder := post_tweet.NewPostTweetService(tweetRepo, memoryUserRepository, timelineInvalidators)
+	postTweetBuilder.MaxContentLength = cfg.MaxContentLength
+	postTweetService := instrument(m, "post_tweet", postTweetBuilder.Execute)
+	followUserService := instrumentCommand(m, "follow_user", follow_user.NewFollowUserService(memoryUserRepository, timelineInvalidators).Execute)
+	createUserService := instrument(m, "create_user", create_user.NewCreateUserService(psxUserRepository, memoryUserRepository, credentialRepo, passwordHasher).Execute)
+	likeTweetService := instrumentCommand(m, "like_tweet", like_tweet.NewLikeTweetService(tweetRepo, likeRepo, memoryUserRepository, timelineInvalidators).Execute)
+	getTimeline := instrument(m, "get_timeline", getTimelineService.Execute)
+	issueTokenService := instrument(m, "issue_token", issue_token.NewIssueTokenService(credentialRepo, passwordHasher, tokenManager).Execute)
+	changePasswordService := instrumentCommand(m, "change_password", change_password.NewChangePasswordService(credentialRepo, memoryUserRepository, passwordHasher).Execute)
+	createAPIKeyService := instrument(m, "create_api_key", create_api_key.NewCreateAPIKeyService(apiKeyRepo).Execute)
+	listAPIKeysService := instrument(m, "list_api_keys", list_api_keys.NewListAPIKeysService(apiKeyRepo).Execute)
+	revokeAPIKeyService := instrumentCommand(m, "revoke_api_key", revoke_api_key.NewRevokeAPIKeyService(apiKeyRepo).Execute)
+	authenticateAPIKeyService := instrument(m, "authenticate_api_key", authenticate_api_key.NewAuthenticateAPIKeyService(apiKeyRepo).Execute)
+	// Maintenance use cases reach past the domain interfaces, so they get
+	// the bare memory repositories.
+	followGraphService := instrument(m, "get_follow_graph", get_follow_graph.NewGetFollowGraphService(memoryUserRepository).Execute)
+	recountLikesService := instrument(m, "recount_likes", recount_likes.NewRecountLikesService(memoryTweets, memoryLikes, memoryUserRepository, timelineInvalidators).Execute)
+	resetPasswordService := instrumentCommand(m, "reset_password", reset_password.NewResetPasswordService(credentialRepo, memoryUserRepository, passwordHasher).Execute)
+	purgeTweetsService := instrument(m, "purge_user_tweets", purge_user_tweets.NewPurgeUserTweetsService(memoryTweets, memoryLikes, memoryUserRepository, timelineInvalidators).Execute)
+	datasetBackend := dataset.NewMemoryBackend(memoryUsers, memoryTweets, memoryLikes, psxUserRepository)
+	exportDatasetService := instrument(m, "export_dataset", export_dataset.NewExportDatasetService(datasetBackend).Execute)
+	importDatasetBuilder := import_dataset.NewImportDatasetService(datasetBackend, timelineInvalidators)
+	importDatasetBuilder.MaxContentLength = cfg.MaxContentLength
+	importDatasetService := instrument(m, "import_dataset", importDatasetBuilder.Execute)
 
 	// === Handlers ===
 	postTweetHandler := tweet.NewPostTweetHandler(postTweetService)
 	followUserHandler := user.NewFollowUserHandler(followUserService)
-	getTimelineHandler := tweet.NewGetTimelineHandler(getTimelineService)
+	getTimelineHandler := tweet.NewGetTimelineHandler(getTimeline)
 	createUserHandler := user.NewCreateUserHandler(createUserService)
 	likeTweetHandler := tweet.NewLikeTweetHandler(likeTweetService)
+	issueTokenHandler := authhandler.NewIssueTokenHandler(issueTokenService)
+	changePasswordHandler := authhandler.NewChangePasswordHandler(changePasswordService)
+	createAPIKeyHandler := authhandler.NewCreateAPIKeyHandler(createAPIKeyService)
+	listAPIKeysHandler := authhandler.NewListAPIKeysHandler(listAPIKeysService)
+	revokeAPIKeyHandler := authhandler.NewRevokeAPIKeyHandler(revokeAPIKeyService)
+	graphQLHandler := graph.NewGraphQLHandler(graph.Dependencies{
+		Timeline: getTimeline,
+		Users:    memoryUserRepository,
+		Tweets:   tweetRepo,
+		Likes:    likeRepo,
+	}, cfg.GraphQLLimits)
 
 	healthHandler := health.NewHealthHandler(cfg.Env, cfg.AppName, cfg.Version)
-
-	logger.Init()
+	readiness := initializeReadiness(pool, timelineCacheStore)
 
 	// === Route Bindings ===
 	handlers := routes.Handlers{
-		PostTweet:   postTweetHandler.ServeHTTP,
-		FollowUser:  followUserHandler.ServeHTTP,
-		GetTimeline: getTimelineHandler.ServeHTTP,
-		CreateUser:  createUserHandler.ServeHTTP,
-		LikeTweet:   likeTweetHandler.ServeHTTP,
-		Health:      healthHandler.ServeHTTP,
+		V1: routes.V1Handlers{
+			PostTweet:      postTweetHandler.ServeHTTP,
+			FollowUser:     followUserHandler.ServeHTTP,
+			GetTimeline:    getTimelineHandler.ServeHTTP,
+			CreateUser:     createUserHandler.ServeHTTP,
+			LikeTweet:      likeTweetHandler.ServeHTTP,
+			IssueToken:     issueTokenHandler.ServeHTTP,
+			ChangePassword: changePasswordHandler.ServeHTTP,
+			CreateAPIKey:   createAPIKeyHandler.ServeHTTP,
+			ListAPIKeys:    listAPIKeysHandler.ServeHTTP,
+			RevokeAPIKey:   revokeAPIKeyHandler.ServeHTTP,
+			GraphQL:        graphQLHandler.ServeHTTP,
+		},
+		Health:  healthHandler.ServeHTTP,
+		Live:    health.NewLiveHandler().ServeHTTP,
+		Ready:   health.NewReadyHandler(readiness).ServeHTTP,
+		Metrics: m.Handler().ServeHTTP,
+		Admin: routes.AdminHandlers{
+			GetLogLevel:      admin.NewGetLogLevelHandler(logger.Level).ServeHTTP,
+			SetLogLevel:      admin.NewSetLogLevelHandler(logger.Level).ServeHTTP,
+			ListFeatureFlags: admin.NewListFeatureFlagsHandler(featureFlags).ServeHTTP,
+			Stats:            admin.NewStatsHandler(repositorySizes, getTimelineService).ServeHTTP,
+			FollowGraph:      admin.NewFollowGraphHandler(followGraphService).ServeHTTP,
+			PurgeTweets:      admin.NewPurgeTweetsHandler(purgeTweetsService).ServeHTTP,
+			ResetPassword:    admin.NewResetPasswordHandler(resetPasswordService).ServeHTTP,
+			RecountLikes:     admin.NewRecountLikesHandler(recountLikesService).ServeHTTP,
+			Export:           admin.NewExportHandler(exportDatasetService, cfg.HTTPDatasetTimeout).ServeHTTP,
+			Import:           admin.NewImportHandler(importDatasetService, cfg.HTTPDatasetTimeout).ServeHTTP,
+		},
+		AdminAuth: auth.RequireAdminToken(cfg.AdminToken),
+
+		Authenticate: auth.APIKeyMiddleware(
+			apiKeyPrincipals(authenticateAPIKeyService),
+			auth.Middleware(tokenManager, sessionVersions(credentialRepo)),
+		),
+		RateLimit:  rateLimiter.For,
+		Idempotent: idempotency.Middleware(idempotency.NewMemoryStore(cfg.IdempotencyMaxEntries), cfg.IdempotencyTTL),
+		Trace:      tracing.HTTPMiddleware,
+		AccessLog:  logger.AccessLog,
+		Legacy: routes.LegacyPolicy{
+			DeprecatedAt: cfg.LegacyDeprecatedAt,
+			SunsetAt:     cfg.LegacySunsetAt,
+		},
 	}
 
 	r := mux.NewRouter()
 	routes.RegisterRoutes(r, handlers)
-	log.Printf("Server started on :%s", cfg.ServerPort)
-	log.Fatal(http.ListenAndServe(":"+cfg.ServerPort, r))
+	// Metrics wrap the router, so requests no route matches are counted.
+	httpServer := newHTTPServer(cfg, httphelper.RequestID(m.HTTPHandler(r)))
+
+	// A server that stops on its own shuts the rest down like a signal.
+	serveErrs := make(chan error, 2)
+	go serveHTTP(httpServer, serveErrs)
+
+	var grpcServer *grpc.Server
+	if cfg.GRPCPort != "" && cfg.GRPCPort != "off" {
+		authenticator := auth.NewAuthenticator(tokenManager, sessionVersions(credentialRepo), apiKeyPrincipals(authenticateAPIKeyService))
+		grpcServer = serveGRPC(cfg.GRPCPort, authenticator, rateLimiter, grpcapi.Services{
+			CreateUser:  createUserService,
+			FollowUser:  followUserService,
+			PostTweet:   postTweetService,
+			LikeTweet:   likeTweetService,
+			GetTimeline: getTimeline,
+			Watcher:     timelineHub,
+		}, serveErrs)
+	}
+
+	signals, stopSignals := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+
+	exitCode := 0
+	select {
+	case <-signals.Done():
+		logger.Log.Info("shutting down")
+	case err := <-serveErrs:
+		logger.Log.Error("server failed, shutting down", zap.Error(err))
+		exitCode = 1
+	}
+	// A second signal kills the process instead of waiting for the drain.
+	stopSignals()
+
+	shutdown(cfg, resources{
+		readiness:      readiness,
+		stopWorkers:    stopWorkers,
+		watchers:       timelineHub,
+		httpServer:     httpServer,
+		grpcServer:     grpcServer,
+		timelineCache:  timelineCacheStore,
+		rateLimitStore: rateLimitStore,
+		pool:           pool,
+		tracing:        shutdownTracing,
+	})
+	os.Exit(exitCode)
+}
+
+// instrument traces and measures a use case under one name.
+func instrument[I, O any](m *metrics.Metrics, name string, execute func(context.Context, I) (O, error)) metrics.UseCaseFunc[I, O] {
+	return metrics.ObserveUseCase(m, name, tracing.UseCase(name, execute))
+}
+
+// instrumentCommand is instrument for use cases that only return an error.
+func instrumentCommand[I any](m *metrics.Metrics, name string, execute func(context.Context, I) error) metrics.CommandFunc[I] {
+	return metrics.ObserveCommand(m, name, tracing.Command(name, execute))
+}
+
+// printConfig writes the effective configuration, secrets redacted, as a
+// file that can be edited and passed back with --config.
+func printConfig(args []string) {
+	if err := config.Print(os.Stdout, loadConfig(args)); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// loadConfig exits listing every invalid setting, or after -h printed the
+// flags.
+func loadConfig(args []string) *config.Config {
+	cfg, err := config.Load(args)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	return cfg
+}
+
+func initializePsx(ctx context.Context, cfg *config.Config) *pgxpool.Pool {
+	poolConfig, err := pgxpool.ParseConfig(cfg.PostgresDSN)
+	if err != nil {
+		logger.Log.Fatal("invalid Postgres DSN", zap.Error(err))
+	}
+	poolConfig.MaxConns = int32(cfg.PostgresMaxConns)
+	poolConfig.MinConns = int32(cfg.PostgresMinConns)
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		logger.Log.Fatal("failed to connect to Postgres", zap.Error(err))
+	}
+	if err := pool.Ping(ctx); err != nil {
+		logger.Log.Fatal("failed to connect to Postgres", zap.Error(err))
+	}
+	return pool
+}
+
+// initializeReadiness registers the dependencies /health/ready reports on.
+// In-process caches have nothing to check; remote ones are pinged.
+func initializeReadiness(pool *pgxpool.Pool, timelineCache cache.Store) *health.Checker {
+	checker := health.NewChecker()
+	checker.Register("postgres", pool.Ping)
+	checker.Register("postgres_schema", func(ctx context.Context) error {
+		return postgres.CheckSchema(ctx, pool)
+	})
+	if pinger, ok := timelineCache.(interface{ Ping(context.Context) error }); ok {
+		checker.Register("timeline_cache", pinger.Ping)
+	}
+	return checker
+}
+
+func initializeTimelineCache(cfg *config.Config) cache.Store {
+	switch cfg.TimelineCacheBackend {
+	case config.CacheBackendRedis:
+		return cache.NewRedisStore(redisConfig(cfg))
+	default:
+		return cache.NewLRUStore(cfg.TimelineCacheSize)
+	}
+}
+
+// initializeRateLimitStore keeps buckets in process unless the limits must
+// hold across replicas.
+func initializeRateLimitStore(cfg *config.Config) ratelimit.Store {
+	switch cfg.RateLimitBackend {
+	case config.RateLimitBackendRedis:
+		return ratelimit.NewRedisStore(cache.NewRedisClient(redisConfig(cfg)))
+	default:
+		return ratelimit.NewMemoryStore()
+	}
+}
+
+func redisConfig(cfg *config.Config) cache.RedisConfig {
+	return cache.RedisConfig{
+		Addr:     cfg.RedisAddr,
+		Username: cfg.RedisUsername,
+		Password: cfg.RedisPassword,
+		TLS:      cfg.RedisTLS,
+		PoolSize: cfg.RedisPoolSize,
+	}
 }
 
-func initializePsx(ctx context.Context, dsn string) *pgx.Conn {
-	conn, err := pgx.Connect(ctx, dsn)
+func initializeRateLimiter(cfg *config.Config, store ratelimit.Store) *ratelimit.Limiter {
+	limits := make(map[string]ratelimit.Limit, len(cfg.RateLimits))
+	for name, spec := range cfg.RateLimits {
+		if spec == "" || spec == "off" {
+			continue
+		}
+		limit, err := ratelimit.ParseLimit(spec)
+		if err != nil {
+			logger.Log.Fatal("invalid rate limit", zap.String("limit", name), zap.Error(err))
+		}
+		limits[name] = limit
+	}
+	return ratelimit.NewLimiter(store, limits)
+}
+
+// initializeTokenManager builds the JWT signer from JWT_KEYS. Config
+// validation requires keys outside local; there, without keys, a random
+// HS256 secret is generated, so tokens do not survive a restart.
+func initializeTokenManager(cfg *config.Config) *auth.TokenManager {
+	keys, err := auth.ParseKeys(cfg.JWTKeys)
+	if err != nil {
+		logger.Log.Fatal("invalid JWT_KEYS", zap.Error(err))
+	}
+
+	activeKID := cfg.JWTActiveKID
+	if len(keys) == 0 {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			logger.Log.Fatal("failed to generate JWT secret", zap.Error(err))
+		}
+		keys = []auth.Key{{ID: "ephemeral", Algorithm: auth.AlgHS256, Secret: secret}}
+		activeKID = "ephemeral"
+		logger.Log.Warn("JWT_KEYS not set, using an ephemeral signing key (local only)")
+	}
+	if activeKID == "" {
+		activeKID = keys[0].ID
+	}
+
+	manager, err := auth.NewTokenManager(keys, activeKID, cfg.JWTIssuer, cfg.JWTTTL)
 	if err != nil {
-		log.Fatalf("Failed to connect to Postgres: %v", err)
+		logger.Log.Fatal("failed to configure JWT signing", zap.Error(err))
+	}
+	return manager
+}
+
+// sessionVersions lets the auth middleware reject tokens issued before the
+// user's last password change.
+func sessionVersions(credentials credential.Repository) auth.SessionVersionFunc {
+	return func(ctx context.Context, userID string) (int64, error) {
+		c, err := credentials.GetByUserID(ctx, userID)
+		if errors.Is(err, credential.ErrNotFound) {
+			return 0, auth.ErrRevokedToken
+		}
+		if err != nil {
+			return 0, err
+		}
+		return c.SessionVersion, nil
+	}
+}
+
+type apiKeyAuthenticator interface {
+	Execute(ctx context.Context, input authenticate_api_key.Input) (authenticate_api_key.Output, error)
+}
+
+// apiKeyPrincipals resolves X-API-Key headers to the key owner and scopes.
+func apiKeyPrincipals(service apiKeyAuthenticator) auth.APIKeyFunc {
+	return func(ctx context.Context, key string) (string, []string, error) {
+		out, err := service.Execute(ctx, authenticate_api_key.Input{Key: key})
+		if err != nil {
+			return "", nil, err
+		}
+		return out.UserID, out.Scopes, nil
 	}
-	return conn
 }
@@ -2,13 +2,77 @@ package routes
 
 import (
 	"net/http"
+	"time"
 )
 
+// Handlers wires the endpoints of every API version and the middleware
+// they share. Each version has its own handler set, so a /v2 with
+// different DTOs can be added next to V1 without touching it.
 type Handlers struct {
-	PostTweet   http.HandlerFunc
-	FollowUser  http.HandlerFunc
-	CreateUser  http.HandlerFunc
-	GetTimeline http.HandlerFunc
-	LikeTweet   http.HandlerFunc
-	Health      http.HandlerFunc
+	V1 V1Handlers
+
+	// Health and the Live and Ready probes are unversioned; probes should
+	// not follow API versions.
+	Health http.HandlerFunc
+	Live   http.HandlerFunc
+	Ready  http.HandlerFunc
+	// Metrics serves the Prometheus scrape endpoint, also unversioned.
+	Metrics http.HandlerFunc
+	// Admin are operator endpoints guarded by AdminAuth instead of user
+	// credentials; without AdminAuth they are disabled.
+	Admin     AdminHandlers
+	AdminAuth func(http.Handler) http.Handler
+
+	Authenticate func(http.Handler) http.Handler
+	// RateLimit returns the limiter for a route budget (see the Limit*
+	// names); nil disables rate limiting.
+	RateLimit func(name string) func(http.Handler) http.Handler
+	// Idempotent returns the middleware replaying stored responses for
+	// retried writes to an endpoint carrying an Idempotency-Key header; nil
+	// disables it.
+	Idempotent func(endpoint string) func(http.Handler) http.Handler
+	// Trace and AccessLog wrap every matched route, so they can name spans
+	// and log by the route template; nil disables them.
+	Trace     func(http.Handler) http.Handler
+	AccessLog func(http.Handler) http.Handler
+
+	// Legacy dates the unprefixed aliases of the v1 routes.
+	Legacy LegacyPolicy
+}
+
+// V1Handlers are the endpoints served under /v1.
+type V1Handlers struct {
+	PostTweet      http.HandlerFunc
+	FollowUser     http.HandlerFunc
+	CreateUser     http.HandlerFunc
+	GetTimeline    http.HandlerFunc
+	LikeTweet      http.HandlerFunc
+	IssueToken     http.HandlerFunc
+	ChangePassword http.HandlerFunc
+	CreateAPIKey   http.HandlerFunc
+	ListAPIKeys    http.HandlerFunc
+	RevokeAPIKey   http.HandlerFunc
+	GraphQL        http.HandlerFunc
+}
+
+// AdminHandlers are the unversioned operator endpoints.
+type AdminHandlers struct {
+	GetLogLevel      http.HandlerFunc
+	SetLogLevel      http.HandlerFunc
+	ListFeatureFlags http.HandlerFunc
+	Stats            http.HandlerFunc
+	FollowGraph      http.HandlerFunc
+	PurgeTweets      http.HandlerFunc
+	ResetPassword    http.HandlerFunc
+	RecountLikes     http.HandlerFunc
+	Export           http.HandlerFunc
+	Import           http.HandlerFunc
+}
+
+// LegacyPolicy is announced on every unprefixed route through the
+// Deprecation (RFC 9745) and Sunset (RFC 8594) headers. Zero times omit
+// the header.
+type LegacyPolicy struct {
+	DeprecatedAt time.Time
+	SunsetAt     time.Time
 }
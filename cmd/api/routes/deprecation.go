@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// deprecation announces that a route is an alias scheduled for removal and
+// links the same path under successorPrefix.
+func deprecation(policy LegacyPolicy, successorPrefix string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !policy.DeprecatedAt.IsZero() {
+				w.Header().Set("Deprecation", "@"+strconv.FormatInt(policy.DeprecatedAt.Unix(), 10))
+			}
+			if !policy.SunsetAt.IsZero() {
+				w.Header().Set("Sunset", policy.SunsetAt.UTC().Format(http.TimeFormat))
+			}
+			w.Header().Add("Link", "<"+successorPrefix+r.URL.Path+`>; rel="successor-version"`)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
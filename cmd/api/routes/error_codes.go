@@ -0,0 +1,95 @@
+package routes
+
+import (
+	adminhandler "ualaTwitter/cmd/api/routes/handlers/admin"
+	authhandler "ualaTwitter/cmd/api/routes/handlers/auth"
+	graphhandler "ualaTwitter/cmd/api/routes/handlers/graph"
+	tweethandler "ualaTwitter/cmd/api/routes/handlers/tweet"
+	userhandler "ualaTwitter/cmd/api/routes/handlers/user"
+	"ualaTwitter/internal/domain/apikey"
+	"ualaTwitter/internal/domain/credential"
+	"ualaTwitter/internal/domain/like"
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/platform/idempotency"
+	"ualaTwitter/internal/usecase/authenticate_api_key"
+	"ualaTwitter/internal/usecase/change_password"
+	"ualaTwitter/internal/usecase/create_api_key"
+	"ualaTwitter/internal/usecase/issue_token"
+)
+
+// errorCodes is the public contract for the "code" member of error
+// responses. Codes must never change once released; add new ones instead.
+// Errors that are not listed fall back to the use case error type or the
+// HTTP status.
+var errorCodes = []httphelper.ErrorCode{
+	// Domain
+	{Err: tweet.ErrTooLong, Code: "tweet_too_long", Field: "content"},
+	{Err: tweet.ErrEmptyTweet, Code: "tweet_empty", Field: "content"},
+	{Err: tweet.ErrNotFound, Code: "tweet_not_found"},
+	{Err: user.ErrUserNotFound, Code: "user_not_found"},
+	{Err: user.ErrAlreadyFollowing, Code: "already_following"},
+	{Err: user.ErrInvalidInput, Code: "invalid_follow_request"},
+	{Err: user.ErrInvalidName, Code: "invalid_name", Field: "name"},
+	{Err: user.ErrInvalidDocument, Code: "invalid_document", Field: "document"},
+	{Err: user.ErrSelfFollow, Code: "self_follow", Field: "followee_id"},
+	{Err: user.ErrUserAlreadyExists, Code: "user_already_exists"},
+	{Err: user.ErrWeakPassword, Code: "weak_password"},
+	{Err: like.ErrAlreadyLiked, Code: "already_liked"},
+	{Err: like.ErrInvalidInput, Code: "invalid_like_request"},
+	{Err: credential.ErrNotFound, Code: "credentials_not_found"},
+	{Err: apikey.ErrNotFound, Code: "api_key_not_found"},
+	{Err: apikey.ErrInvalidName, Code: "invalid_api_key_name", Field: "name"},
+	{Err: apikey.ErrInvalidScope, Code: "invalid_scope", Field: "scopes"},
+	{Err: apikey.ErrNoScopes, Code: "scopes_required", Field: "scopes"},
+	{Err: apikey.ErrRevoked, Code: "api_key_revoked"},
+
+	// Use cases
+	{Err: issue_token.ErrInvalidCredentials, Code: "invalid_credentials"},
+	{Err: issue_token.ErrAccountLocked, Code: "account_locked"},
+	{Err: change_password.ErrWrongPassword, Code: "wrong_password", Field: "current_password"},
+	{Err: create_api_key.ErrTooManyKeys, Code: "too_many_api_keys"},
+	{Err: authenticate_api_key.ErrInvalidKey, Code: "invalid_api_key"},
+
+	// Authentication and request handling
+	{Err: auth.ErrMissingToken, Code: "missing_token"},
+	{Err: auth.ErrInvalidToken, Code: "invalid_token"},
+	{Err: auth.ErrUnknownKey, Code: "invalid_token"},
+	{Err: auth.ErrExpiredToken, Code: "token_expired"},
+	{Err: auth.ErrRevokedToken, Code: "token_revoked"},
+	{Err: auth.ErrMissingScope, Code: "missing_scope"},
+	{Err: auth.ErrSessionRequired, Code: "session_required"},
+	{Err: auth.ErrAdminDisabled, Code: "admin_disabled"},
+	{Err: auth.ErrInvalidAdminToken, Code: "invalid_admin_token"},
+	{Err: idempotency.ErrInProgress, Code: "idempotency_in_progress"},
+	{Err: idempotency.ErrKeyReused, Code: "idempotency_key_reused"},
+	{Err: idempotency.ErrKeyTooLong, Code: "idempotency_key_too_long"},
+	{Err: idempotency.ErrStoreFull, Code: "idempotency_store_full"},
+
+	// Handlers
+	{Err: userhandler.ErrMissingUserID, Code: "unauthenticated"},
+	{Err: userhandler.ErrInvalidBody, Code: "invalid_body"},
+	{Err: userhandler.ErrInvalidCreateUserBody, Code: "invalid_body"},
+	{Err: userhandler.ErrEmptyUserName, Code: "name_required", Field: "name"},
+	{Err: userhandler.ErrInvalidDoc, Code: "invalid_document", Field: "document"},
+	{Err: userhandler.ErrEmptyPassword, Code: "password_required", Field: "password"},
+	{Err: userhandler.ErrEmptyFolloweeID, Code: "followee_id_required", Field: "followee_id"},
+	{Err: tweethandler.ErrMissingUserID, Code: "unauthenticated"},
+	{Err: tweethandler.ErrInvalidBody, Code: "invalid_body"},
+	{Err: tweethandler.ErrEmptyTweet, Code: "tweet_empty", Field: "content"},
+	{Err: tweethandler.ErrMissingTweetID, Code: "tweet_id_required"},
+	{Err: tweethandler.ErrInvalidExpand, Code: "invalid_expand", Field: "expand"},
+	{Err: authhandler.ErrMissingUserID, Code: "unauthenticated"},
+	{Err: authhandler.ErrInvalidBody, Code: "invalid_body"},
+	{Err: authhandler.ErrMissingCredentials, Code: "credentials_required"},
+	{Err: authhandler.ErrMissingPasswordFields, Code: "password_fields_required"},
+	{Err: authhandler.ErrMissingKeyFields, Code: "api_key_fields_required"},
+	{Err: authhandler.ErrMissingKeyID, Code: "api_key_id_required"},
+	{Err: graphhandler.ErrMissingUserID, Code: "unauthenticated"},
+	{Err: graphhandler.ErrInvalidBody, Code: "invalid_body"},
+	{Err: graphhandler.ErrEmptyQuery, Code: "query_required", Field: "query"},
+	{Err: adminhandler.ErrInvalidBody, Code: "invalid_body"},
+	{Err: adminhandler.ErrInvalidLevel, Code: "invalid_log_level", Field: "level"},
+}
@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/platform/httphelper"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCodes_WellFormed(t *testing.T) {
+	codePattern := regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	seen := make(map[error]bool, len(errorCodes))
+
+	for _, entry := range errorCodes {
+		assert.Regexp(t, codePattern, entry.Code, entry.Err.Error())
+		assert.False(t, seen[entry.Err], "duplicate entry for %q", entry.Err)
+		seen[entry.Err] = true
+	}
+}
+
+func TestRegisterRoutes_ProblemCodes(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	r := mux.NewRouter()
+	RegisterRoutes(r, Handlers{
+		V1: V1Handlers{
+			PostTweet: func(w http.ResponseWriter, r *http.Request) {
+				httphelper.WriteError(w, r, usecase.InvalidParam("invalid tweet content", tweet.ErrTooLong))
+			},
+			FollowUser:  ok,
+			CreateUser:  ok,
+			GetTimeline: ok,
+			LikeTweet:   ok,
+			IssueToken:  ok,
+		},
+		Health:       ok,
+		Authenticate: fakeAuthenticate,
+	})
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/tweets", nil))
+
+	var problem httphelper.Problem
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &problem))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "tweet_too_long", problem.Code)
+	assert.Equal(t, "invalid tweet content", problem.Detail)
+	assert.Equal(t, []httphelper.FieldError{{Field: "content", Code: "tweet_too_long", Detail: tweet.ErrTooLong.Error()}}, problem.Errors)
+}
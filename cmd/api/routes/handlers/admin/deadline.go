@@ -0,0 +1,26 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"ualaTwitter/internal/platform/logger"
+)
+
+// extendDeadlines gives a dataset stream timeout to be read and written in,
+// past the server timeouts sized for ordinary requests. A zero timeout
+// keeps the server ones.
+func extendDeadlines(w http.ResponseWriter, r *http.Request, timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	deadline := time.Now().Add(timeout)
+	rc := http.NewResponseController(w)
+	if err := rc.SetReadDeadline(deadline); err != nil {
+		logger.FromContext(r.Context()).Warn("failed to extend the read deadline", zap.Error(err))
+	}
+	if err := rc.SetWriteDeadline(deadline); err != nil {
+		logger.FromContext(r.Context()).Warn("failed to extend the write deadline", zap.Error(err))
+	}
+}
@@ -0,0 +1,76 @@
+package admin
+
+type logLevelRequest struct {
+	Level string `json:"level"`
+}
+
+type logLevelResponse struct {
+	Level string `json:"level"`
+}
+
+type featureFlagsResponse struct {
+	Flags []featureFlagResponse `json:"flags"`
+}
+
+type featureFlagResponse struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Enabled     bool     `json:"enabled"`
+	Percentage  int      `json:"percentage"`
+	Allow       []string `json:"allow"`
+	Deny        []string `json:"deny"`
+	// EnabledForUser answers for the user_id query parameter, when given.
+	EnabledForUser *bool `json:"enabled_for_user,omitempty"`
+}
+
+type followGraphResponse struct {
+	UserID    string   `json:"user_id"`
+	Following []string `json:"following"`
+	Followers []string `json:"followers"`
+}
+
+type recountLikesResponse struct {
+	Checked   int                    `json:"checked"`
+	Corrected []likeCorrectionResult `json:"corrected"`
+}
+
+type likeCorrectionResult struct {
+	TweetID string `json:"tweet_id"`
+	Was     int    `json:"was"`
+	Now     int    `json:"now"`
+}
+
+type resetPasswordRequest struct {
+	Password string `json:"password"`
+}
+
+type purgeTweetsResponse struct {
+	Tweets int `json:"tweets"`
+	Likes  int `json:"likes"`
+}
+
+type statsResponse struct {
+	// Repositories is the number of stored entities by repository.
+	Repositories  map[string]int     `json:"repositories"`
+	TimelineCache timelineCacheStats `json:"timeline_cache"`
+}
+
+type timelineCacheStats struct {
+	Hits          uint64 `json:"hits"`
+	Misses        uint64 `json:"misses"`
+	Invalidations uint64 `json:"invalidations"`
+}
+
+type importReportResponse struct {
+	// Imported and Skipped count lines by record type. Skipped lines hold
+	// entities the backend does not store.
+	Imported map[string]int          `json:"imported"`
+	Skipped  map[string]int          `json:"skipped"`
+	Rejected []importRejectionResult `json:"rejected"`
+}
+
+type importRejectionResult struct {
+	Line   int    `json:"line"`
+	Type   string `json:"type,omitempty"`
+	Reason string `json:"reason"`
+}
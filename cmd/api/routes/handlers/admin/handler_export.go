@@ -0,0 +1,61 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"ualaTwitter/internal/platform/dataset"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/usecase/export_dataset"
+)
+
+// JSONLinesContentType is the media type of datasets.
+const JSONLinesContentType = "application/jsonl"
+
+type exportService interface {
+	Execute(ctx context.Context, input export_dataset.Input) (dataset.Counts, error)
+}
+
+// ExportHandler streams every user, follow, tweet and like as JSON Lines.
+type ExportHandler struct {
+	service exportService
+	// timeout replaces the server read and write timeouts for the request.
+	timeout time.Duration
+}
+
+func NewExportHandler(service exportService, timeout time.Duration) *ExportHandler {
+	return &ExportHandler{
+		service: service,
+		timeout: timeout,
+	}
+}
+
+func (h *ExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	extendDeadlines(w, r, h.timeout)
+	out := &writeTracker{ResponseWriter: w}
+	w.Header().Set("Content-Type", JSONLinesContentType)
+	w.Header().Set("Cache-Control", "no-store")
+
+	if _, err := h.service.Execute(r.Context(), export_dataset.Input{Writer: out}); err != nil {
+		if !out.written {
+			httphelper.WriteError(w, r, err)
+			return
+		}
+		// The status is already sent; the client sees a truncated stream.
+		logger.FromContext(r.Context()).Error("dataset export failed mid-stream", zap.Error(err))
+	}
+}
+
+// writeTracker records whether the response has started.
+type writeTracker struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (w *writeTracker) Write(p []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(p)
+}
@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ualaTwitter/internal/platform/dataset"
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/usecase/export_dataset"
+)
+
+type fakeExportService struct {
+	lines string
+	err   error
+}
+
+func (f *fakeExportService) Execute(_ context.Context, input export_dataset.Input) (dataset.Counts, error) {
+	if f.lines != "" {
+		_, _ = input.Writer.Write([]byte(f.lines))
+	}
+	return dataset.Counts{}, f.err
+}
+
+func TestExportHandler(t *testing.T) {
+	serve := func(service *fakeExportService) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		NewExportHandler(service, 0).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/export", nil))
+		return rr
+	}
+
+	t.Run("streams the dataset", func(t *testing.T) {
+		rr := serve(&fakeExportService{lines: `{"type":"user","id":"usr_1111111"}` + "\n"})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, JSONLinesContentType, rr.Header().Get("Content-Type"))
+		assert.Equal(t, `{"type":"user","id":"usr_1111111"}`+"\n", rr.Body.String())
+	})
+
+	t.Run("failure before the first line is a problem", func(t *testing.T) {
+		rr := serve(&fakeExportService{err: usecase.InternalServerError("failed to export dataset")})
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("failure after the first line truncates the stream", func(t *testing.T) {
+		rr := serve(&fakeExportService{lines: "{}\n", err: usecase.InternalServerError("failed to export dataset")})
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "{}\n", rr.Body.String())
+	})
+}
@@ -0,0 +1,57 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"ualaTwitter/internal/platform/featureflag"
+)
+
+// flagSource is the loaded feature flags, featureflag.Store in production.
+type flagSource interface {
+	Flags() []featureflag.Flag
+	Enabled(ctx context.Context, name, userID string) bool
+}
+
+// ListFeatureFlagsHandler lists the feature flags as currently loaded and,
+// given a user_id, whether each is on for that user.
+type ListFeatureFlagsHandler struct {
+	flags flagSource
+}
+
+func NewListFeatureFlagsHandler(flags flagSource) *ListFeatureFlagsHandler {
+	return &ListFeatureFlagsHandler{
+		flags: flags,
+	}
+}
+
+func (h *ListFeatureFlagsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+
+	flags := h.flags.Flags()
+	response := featureFlagsResponse{Flags: make([]featureFlagResponse, len(flags))}
+	for i, f := range flags {
+		response.Flags[i] = featureFlagResponse{
+			Name:        f.Name,
+			Description: f.Description,
+			Enabled:     f.Enabled,
+			Percentage:  f.Percentage,
+			Allow:       nonNil(f.Allow),
+			Deny:        nonNil(f.Deny),
+		}
+		if userID != "" {
+			enabled := h.flags.Enabled(r.Context(), f.Name, userID)
+			response.Flags[i].EnabledForUser = &enabled
+		}
+	}
+
+	renderJSON(w, r, response)
+}
+
+// nonNil renders empty lists as [] rather than null.
+func nonNil(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
@@ -0,0 +1,52 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ualaTwitter/internal/platform/featureflag"
+)
+
+func TestListFeatureFlagsHandler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+ranked_timeline:
+  description: Ranked timeline by default
+  enabled: true
+  percentage: 0
+  allow: [usr_1]
+edit_tweet:
+  enabled: false
+`), 0o600))
+	store := featureflag.NewStore(path)
+	require.NoError(t, store.Load())
+	handler := NewListFeatureFlagsHandler(store)
+
+	t.Run("lists flags", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/feature-flags", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"flags":[
+			{"name":"edit_tweet","enabled":false,"percentage":0,"allow":[],"deny":[]},
+			{"name":"ranked_timeline","description":"Ranked timeline by default","enabled":true,"percentage":0,"allow":["usr_1"],"deny":[]}
+		]}`, rr.Body.String())
+	})
+
+	t.Run("evaluates flags for a user", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/feature-flags?user_id=usr_1", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"flags":[
+			{"name":"edit_tweet","enabled":false,"percentage":0,"allow":[],"deny":[],"enabled_for_user":false},
+			{"name":"ranked_timeline","description":"Ranked timeline by default","enabled":true,"percentage":0,"allow":["usr_1"],"deny":[],"enabled_for_user":true}
+		]}`, rr.Body.String())
+	})
+}
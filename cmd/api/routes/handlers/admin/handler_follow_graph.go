@@ -0,0 +1,48 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/usecase/get_follow_graph"
+)
+
+var ErrMissingUserID = errors.New("missing user ID in path")
+
+type followGraphService interface {
+	Execute(ctx context.Context, input get_follow_graph.Input) (get_follow_graph.Output, error)
+}
+
+// FollowGraphHandler lists who a user follows and who follows them.
+type FollowGraphHandler struct {
+	service followGraphService
+}
+
+func NewFollowGraphHandler(service followGraphService) *FollowGraphHandler {
+	return &FollowGraphHandler{
+		service: service,
+	}
+}
+
+func (h *FollowGraphHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+	if userID == "" {
+		httphelper.RenderProblem(w, r, http.StatusBadRequest, ErrMissingUserID)
+		return
+	}
+
+	graph, err := h.service.Execute(r.Context(), get_follow_graph.Input{UserID: userID})
+	if err != nil {
+		httphelper.WriteError(w, r, err)
+		return
+	}
+
+	renderJSON(w, r, followGraphResponse{
+		UserID:    graph.UserID,
+		Following: graph.Following,
+		Followers: graph.Followers,
+	})
+}
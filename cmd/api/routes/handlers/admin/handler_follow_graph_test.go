@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/usecase/get_follow_graph"
+)
+
+type fakeFollowGraphService struct {
+	Out get_follow_graph.Output
+	Err error
+}
+
+func (f *fakeFollowGraphService) Execute(_ context.Context, _ get_follow_graph.Input) (get_follow_graph.Output, error) {
+	return f.Out, f.Err
+}
+
+func TestFollowGraphHandler(t *testing.T) {
+	serve := func(service *fakeFollowGraphService, userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/admin/users/"+userID+"/follow-graph", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": userID})
+		rr := httptest.NewRecorder()
+		NewFollowGraphHandler(service).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("renders the graph", func(t *testing.T) {
+		rr := serve(&fakeFollowGraphService{Out: get_follow_graph.Output{
+			UserID:    "usr_1",
+			Following: []string{"usr_2"},
+			Followers: []string{},
+		}}, "usr_1")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"user_id":"usr_1","following":["usr_2"],"followers":[]}`, rr.Body.String())
+	})
+
+	t.Run("missing user ID", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(&fakeFollowGraphService{}, "").Code)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		rr := serve(&fakeFollowGraphService{Err: usecase.NotFound("user not found")}, "ghost")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
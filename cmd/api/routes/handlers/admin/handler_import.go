@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"ualaTwitter/internal/platform/dataset"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/usecase/import_dataset"
+)
+
+type importService interface {
+	Execute(ctx context.Context, input import_dataset.Input) (dataset.Report, error)
+}
+
+// ImportHandler loads a JSON Lines dataset from the request body and
+// reports the lines it rejected.
+type ImportHandler struct {
+	service importService
+	// timeout replaces the server read and write timeouts for the request.
+	timeout time.Duration
+}
+
+func NewImportHandler(service importService, timeout time.Duration) *ImportHandler {
+	return &ImportHandler{
+		service: service,
+		timeout: timeout,
+	}
+}
+
+func (h *ImportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	extendDeadlines(w, r, h.timeout)
+	report, err := h.service.Execute(r.Context(), import_dataset.Input{Data: r.Body})
+	if err != nil {
+		httphelper.WriteError(w, r, err)
+		return
+	}
+
+	response := importReportResponse{
+		Imported: report.Imported,
+		Skipped:  report.Skipped,
+		Rejected: make([]importRejectionResult, len(report.Rejected)),
+	}
+	for i, rej := range report.Rejected {
+		response.Rejected[i] = importRejectionResult{Line: rej.Line, Type: rej.Type, Reason: rej.Reason}
+	}
+	renderJSON(w, r, response)
+}
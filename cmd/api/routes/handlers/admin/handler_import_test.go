@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ualaTwitter/internal/platform/dataset"
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/usecase/import_dataset"
+)
+
+type fakeImportService struct {
+	report dataset.Report
+	err    error
+	body   string
+	delay  time.Duration
+}
+
+func (f *fakeImportService) Execute(_ context.Context, input import_dataset.Input) (dataset.Report, error) {
+	time.Sleep(f.delay)
+	raw, _ := io.ReadAll(input.Data)
+	f.body = string(raw)
+	return f.report, f.err
+}
+
+func TestImportHandler(t *testing.T) {
+	serve := func(service *fakeImportService, body string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		NewImportHandler(service, 0).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/import", strings.NewReader(body)))
+		return rr
+	}
+
+	t.Run("reports imported, skipped and rejected lines", func(t *testing.T) {
+		service := &fakeImportService{report: dataset.Report{
+			Imported: dataset.Counts{dataset.TypeUser: 2},
+			Skipped:  dataset.Counts{},
+			Rejected: []dataset.Rejection{{Line: 3, Type: dataset.TypeTweet, Reason: "tweet content empty"}},
+		}}
+		rr := serve(service, "lines\n")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "lines\n", service.body)
+		assert.JSONEq(t, `{
+			"imported": {"user": 2},
+			"skipped": {},
+			"rejected": [{"line": 3, "type": "tweet", "reason": "tweet content empty"}]
+		}`, rr.Body.String())
+	})
+
+	t.Run("outlasts the server timeouts", func(t *testing.T) {
+		service := &fakeImportService{report: dataset.Report{Imported: dataset.Counts{dataset.TypeUser: 1}}, delay: 300 * time.Millisecond}
+		server := httptest.NewUnstartedServer(NewImportHandler(service, time.Minute))
+		server.Config.ReadTimeout = 100 * time.Millisecond
+		server.Config.WriteTimeout = 100 * time.Millisecond
+		server.Start()
+		t.Cleanup(server.Close)
+
+		resp, err := http.Post(server.URL, JSONLinesContentType, strings.NewReader("lines\n"))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "lines\n", service.body)
+		assert.JSONEq(t, `{"imported":{"user":1},"skipped":null,"rejected":[]}`, string(body))
+	})
+
+	t.Run("unreadable dataset", func(t *testing.T) {
+		rr := serve(&fakeImportService{err: usecase.InvalidParam("dataset could not be read to the end")}, "")
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
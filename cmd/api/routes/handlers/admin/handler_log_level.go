@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/platform/logger"
+)
+
+const maxLogLevelBodySize = 1024
+
+var (
+	ErrInvalidBody  = errors.New("invalid request body")
+	ErrInvalidLevel = errors.New("level must be one of debug, info, warn, error, dpanic, panic or fatal")
+)
+
+// levelController is the runtime level of the logger, zap.AtomicLevel in
+// production.
+type levelController interface {
+	Level() zapcore.Level
+	SetLevel(zapcore.Level)
+}
+
+// GetLogLevelHandler reports the current minimum log level.
+type GetLogLevelHandler struct {
+	level levelController
+}
+
+func NewGetLogLevelHandler(level levelController) *GetLogLevelHandler {
+	return &GetLogLevelHandler{
+		level: level,
+	}
+}
+
+func (h *GetLogLevelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	renderLevel(w, r, h.level.Level())
+}
+
+// SetLogLevelHandler changes the minimum log level until the next restart.
+type SetLogLevelHandler struct {
+	level levelController
+}
+
+func NewSetLogLevelHandler(level levelController) *SetLogLevelHandler {
+	return &SetLogLevelHandler{
+		level: level,
+	}
+}
+
+func (h *SetLogLevelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxLogLevelBodySize)
+
+	level, err := h.parseRequest(r)
+	if err != nil {
+		httphelper.RenderProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	previous := h.level.Level()
+	h.level.SetLevel(level)
+	logger.FromContext(r.Context()).Warn("log level changed",
+		zap.Stringer("from", previous),
+		zap.Stringer("to", level),
+	)
+
+	renderLevel(w, r, level)
+}
+
+func (h *SetLogLevelHandler) parseRequest(r *http.Request) (zapcore.Level, error) {
+	var req logLevelRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		return 0, ErrInvalidBody
+	}
+
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(req.Level)); err != nil || req.Level == "" {
+		return 0, ErrInvalidLevel
+	}
+	return level, nil
+}
+
+func renderLevel(w http.ResponseWriter, r *http.Request, level zapcore.Level) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(logLevelResponse{Level: level.String()}); err != nil {
+		logger.FromContext(r.Context()).Error("failed to encode log level response", zap.Error(err))
+	}
+}
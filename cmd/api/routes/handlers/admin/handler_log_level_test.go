@@ -0,0 +1,57 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestGetLogLevelHandler(t *testing.T) {
+	level := zap.NewAtomicLevelAt(zapcore.WarnLevel)
+	rr := httptest.NewRecorder()
+
+	NewGetLogLevelHandler(level).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/log-level", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"level":"warn"}`, rr.Body.String())
+}
+
+func TestSetLogLevelHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedLevel  zapcore.Level
+	}{
+		{name: "changes the level", body: `{"level":"debug"}`, expectedStatus: http.StatusOK, expectedLevel: zapcore.DebugLevel},
+		{name: "accepts upper case", body: `{"level":"ERROR"}`, expectedStatus: http.StatusOK, expectedLevel: zapcore.ErrorLevel},
+		{name: "unknown level", body: `{"level":"loud"}`, expectedStatus: http.StatusBadRequest, expectedLevel: zapcore.InfoLevel},
+		{name: "missing level", body: `{}`, expectedStatus: http.StatusBadRequest, expectedLevel: zapcore.InfoLevel},
+		{name: "unknown field", body: `{"level":"debug","for":"1h"}`, expectedStatus: http.StatusBadRequest, expectedLevel: zapcore.InfoLevel},
+		{name: "invalid JSON", body: `{`, expectedStatus: http.StatusBadRequest, expectedLevel: zapcore.InfoLevel},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+			rr := httptest.NewRecorder()
+
+			NewSetLogLevelHandler(level).ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(tc.body)))
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			assert.Equal(t, tc.expectedLevel, level.Level())
+			if tc.expectedStatus == http.StatusOK {
+				var resp logLevelResponse
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				assert.Equal(t, tc.expectedLevel.String(), resp.Level)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/usecase/purge_user_tweets"
+)
+
+type purgeTweetsService interface {
+	Execute(ctx context.Context, input purge_user_tweets.Input) (purge_user_tweets.Output, error)
+}
+
+// PurgeTweetsHandler deletes every tweet of a user and reports how much
+// was removed.
+type PurgeTweetsHandler struct {
+	service purgeTweetsService
+}
+
+func NewPurgeTweetsHandler(service purgeTweetsService) *PurgeTweetsHandler {
+	return &PurgeTweetsHandler{
+		service: service,
+	}
+}
+
+func (h *PurgeTweetsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+	if userID == "" {
+		httphelper.RenderProblem(w, r, http.StatusBadRequest, ErrMissingUserID)
+		return
+	}
+
+	out, err := h.service.Execute(r.Context(), purge_user_tweets.Input{UserID: userID})
+	if err != nil {
+		httphelper.WriteError(w, r, err)
+		return
+	}
+
+	renderJSON(w, r, purgeTweetsResponse{Tweets: out.Tweets, Likes: out.Likes})
+}
@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/usecase/purge_user_tweets"
+)
+
+type fakePurgeTweetsService struct {
+	Out       purge_user_tweets.Output
+	Err       error
+	LastInput purge_user_tweets.Input
+}
+
+func (f *fakePurgeTweetsService) Execute(_ context.Context, input purge_user_tweets.Input) (purge_user_tweets.Output, error) {
+	f.LastInput = input
+	return f.Out, f.Err
+}
+
+func TestPurgeTweetsHandler(t *testing.T) {
+	serve := func(service *fakePurgeTweetsService, userID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, "/admin/users/"+userID+"/tweets", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": userID})
+		rr := httptest.NewRecorder()
+		NewPurgeTweetsHandler(service).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("reports what was deleted", func(t *testing.T) {
+		service := &fakePurgeTweetsService{Out: purge_user_tweets.Output{Tweets: 3, Likes: 5}}
+		rr := serve(service, "usr_1")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "usr_1", service.LastInput.UserID)
+		assert.JSONEq(t, `{"tweets":3,"likes":5}`, rr.Body.String())
+	})
+
+	t.Run("missing user ID", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(&fakePurgeTweetsService{}, "").Code)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		rr := serve(&fakePurgeTweetsService{Err: usecase.NotFound("user not found")}, "ghost")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
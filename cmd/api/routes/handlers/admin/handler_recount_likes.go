@@ -0,0 +1,42 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/usecase/recount_likes"
+)
+
+type recountLikesService interface {
+	Execute(ctx context.Context, input recount_likes.Input) (recount_likes.Output, error)
+}
+
+// RecountLikesHandler rebuilds the tweet like counters from the like set
+// and lists the ones that were wrong.
+type RecountLikesHandler struct {
+	service recountLikesService
+}
+
+func NewRecountLikesHandler(service recountLikesService) *RecountLikesHandler {
+	return &RecountLikesHandler{
+		service: service,
+	}
+}
+
+func (h *RecountLikesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	out, err := h.service.Execute(r.Context(), recount_likes.Input{})
+	if err != nil {
+		httphelper.WriteError(w, r, err)
+		return
+	}
+
+	response := recountLikesResponse{
+		Checked:   out.Checked,
+		Corrected: make([]likeCorrectionResult, len(out.Corrected)),
+	}
+	for i, c := range out.Corrected {
+		response.Corrected[i] = likeCorrectionResult{TweetID: c.TweetID, Was: c.Was, Now: c.Now}
+	}
+	renderJSON(w, r, response)
+}
@@ -0,0 +1,50 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/usecase/recount_likes"
+)
+
+type fakeRecountLikesService struct {
+	Out recount_likes.Output
+	Err error
+}
+
+func (f *fakeRecountLikesService) Execute(_ context.Context, _ recount_likes.Input) (recount_likes.Output, error) {
+	return f.Out, f.Err
+}
+
+func TestRecountLikesHandler(t *testing.T) {
+	serve := func(service *fakeRecountLikesService) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		NewRecountLikesHandler(service).ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/admin/likes/recount", nil))
+		return rr
+	}
+
+	t.Run("lists corrected tweets", func(t *testing.T) {
+		rr := serve(&fakeRecountLikesService{Out: recount_likes.Output{
+			Checked:   4,
+			Corrected: []recount_likes.Correction{{TweetID: "t1", Was: 5, Now: 2}},
+		}})
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"checked":4,"corrected":[{"tweet_id":"t1","was":5,"now":2}]}`, rr.Body.String())
+	})
+
+	t.Run("nothing to correct", func(t *testing.T) {
+		rr := serve(&fakeRecountLikesService{Out: recount_likes.Output{Checked: 4}})
+		assert.JSONEq(t, `{"checked":4,"corrected":[]}`, rr.Body.String())
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		rr := serve(&fakeRecountLikesService{Err: usecase.InternalServerError("failed to count likes")})
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
@@ -0,0 +1,61 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/usecase/reset_password"
+)
+
+const maxResetPasswordBodySize = 2 * 1024 // 2kb
+
+var ErrMissingPassword = errors.New("password is required")
+
+type resetPasswordService interface {
+	Execute(ctx context.Context, input reset_password.Input) error
+}
+
+// ResetPasswordHandler sets a user's password, creating the credentials of
+// users that have none, such as imported ones.
+type ResetPasswordHandler struct {
+	service resetPasswordService
+}
+
+func NewResetPasswordHandler(service resetPasswordService) *ResetPasswordHandler {
+	return &ResetPasswordHandler{
+		service: service,
+	}
+}
+
+func (h *ResetPasswordHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+	if userID == "" {
+		httphelper.RenderProblem(w, r, http.StatusBadRequest, ErrMissingUserID)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxResetPasswordBodySize)
+
+	var req resetPasswordRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		httphelper.RenderProblem(w, r, http.StatusBadRequest, ErrInvalidBody)
+		return
+	}
+	if req.Password == "" {
+		httphelper.RenderProblem(w, r, http.StatusBadRequest, ErrMissingPassword)
+		return
+	}
+
+	if err := h.service.Execute(r.Context(), reset_password.Input{UserID: userID, Password: req.Password}); err != nil {
+		httphelper.WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,54 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/usecase/reset_password"
+)
+
+type fakeResetPasswordService struct {
+	Err       error
+	LastInput reset_password.Input
+}
+
+func (f *fakeResetPasswordService) Execute(_ context.Context, input reset_password.Input) error {
+	f.LastInput = input
+	return f.Err
+}
+
+func TestResetPasswordHandler(t *testing.T) {
+	serve := func(service *fakeResetPasswordService, userID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/admin/users/"+userID+"/password", strings.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"id": userID})
+		rr := httptest.NewRecorder()
+		NewResetPasswordHandler(service).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("sets the password", func(t *testing.T) {
+		service := &fakeResetPasswordService{}
+		rr := serve(service, "usr_1", `{"password":"batterystaple77"}`)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, reset_password.Input{UserID: "usr_1", Password: "batterystaple77"}, service.LastInput)
+	})
+
+	t.Run("bad requests", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(&fakeResetPasswordService{}, "", `{"password":"batterystaple77"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, serve(&fakeResetPasswordService{}, "usr_1", `{"pass":"x"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, serve(&fakeResetPasswordService{}, "usr_1", `{}`).Code)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		rr := serve(&fakeResetPasswordService{Err: usecase.NotFound("user not found")}, "ghost", `{"password":"batterystaple77"}`)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
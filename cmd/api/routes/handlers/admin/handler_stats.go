@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"net/http"
+
+	"ualaTwitter/internal/usecase/get_timeline"
+)
+
+// cacheStatsSource is the cached timeline service.
+type cacheStatsSource interface {
+	Stats() get_timeline.CacheStats
+}
+
+// StatsHandler reports repository sizes and timeline cache counters since
+// start.
+type StatsHandler struct {
+	sizes    map[string]func() int
+	timeline cacheStatsSource
+}
+
+// NewStatsHandler reports each of sizes under its name, the same ones
+// exported as metrics.
+func NewStatsHandler(sizes map[string]func() int, timeline cacheStatsSource) *StatsHandler {
+	return &StatsHandler{
+		sizes:    sizes,
+		timeline: timeline,
+	}
+}
+
+func (h *StatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	response := statsResponse{Repositories: make(map[string]int, len(h.sizes))}
+	for name, size := range h.sizes {
+		response.Repositories[name] = size()
+	}
+
+	stats := h.timeline.Stats()
+	response.TimelineCache = timelineCacheStats{
+		Hits:          stats.Hits,
+		Misses:        stats.Misses,
+		Invalidations: stats.Invalidations,
+	}
+	renderJSON(w, r, response)
+}
@@ -0,0 +1,37 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ualaTwitter/internal/usecase/get_timeline"
+)
+
+type fakeCacheStats get_timeline.CacheStats
+
+func (f fakeCacheStats) Stats() get_timeline.CacheStats {
+	return get_timeline.CacheStats(f)
+}
+
+func TestStatsHandler(t *testing.T) {
+	handler := NewStatsHandler(
+		map[string]func() int{
+			"users":  func() int { return 2 },
+			"tweets": func() int { return 7 },
+		},
+		fakeCacheStats{Hits: 10, Misses: 3, Invalidations: 1},
+	)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/stats", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{
+		"repositories": {"users": 2, "tweets": 7},
+		"timeline_cache": {"hits": 10, "misses": 3, "invalidations": 1}
+	}`, rr.Body.String())
+}
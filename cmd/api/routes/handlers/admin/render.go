@@ -0,0 +1,18 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+	"ualaTwitter/internal/platform/logger"
+)
+
+// renderJSON writes an operator response, which is never cached.
+func renderJSON(w http.ResponseWriter, r *http.Request, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.FromContext(r.Context()).Error("failed to encode admin response", zap.Error(err))
+	}
+}
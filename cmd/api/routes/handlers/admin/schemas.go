@@ -0,0 +1,23 @@
+package admin
+
+import "ualaTwitter/internal/platform/openapi"
+
+// Schemas describes the package DTOs for the OpenAPI document, keyed by
+// the exported form of the DTO type name.
+func Schemas() map[string]*openapi.Schema {
+	return map[string]*openapi.Schema{
+		"LogLevelRequest":       openapi.SchemaOf(logLevelRequest{}),
+		"LogLevelResponse":      openapi.SchemaOf(logLevelResponse{}),
+		"FeatureFlagsResponse":  openapi.SchemaOf(featureFlagsResponse{}),
+		"FeatureFlagResponse":   openapi.SchemaOf(featureFlagResponse{}),
+		"FollowGraphResponse":   openapi.SchemaOf(followGraphResponse{}),
+		"RecountLikesResponse":  openapi.SchemaOf(recountLikesResponse{}),
+		"LikeCorrectionResult":  openapi.SchemaOf(likeCorrectionResult{}),
+		"PurgeTweetsResponse":   openapi.SchemaOf(purgeTweetsResponse{}),
+		"ResetPasswordRequest":  openapi.SchemaOf(resetPasswordRequest{}),
+		"StatsResponse":         openapi.SchemaOf(statsResponse{}),
+		"TimelineCacheStats":    openapi.SchemaOf(timelineCacheStats{}),
+		"ImportReportResponse":  openapi.SchemaOf(importReportResponse{}),
+		"ImportRejectionResult": openapi.SchemaOf(importRejectionResult{}),
+	}
+}
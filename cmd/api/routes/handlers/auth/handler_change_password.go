@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/usecase/change_password"
+)
+
+const maxChangePasswordBodySize = 2 * 1024 // 2kb
+
+var (
+	ErrMissingUserID         = errors.New("missing authenticated user")
+	ErrMissingPasswordFields = errors.New("current_password and new_password are required")
+)
+
+type changePasswordService interface {
+	Execute(ctx context.Context, input change_password.Input) error
+}
+
+type ChangePasswordHandler struct {
+	service changePasswordService
+}
+
+func NewChangePasswordHandler(service changePasswordService) *ChangePasswordHandler {
+	return &ChangePasswordHandler{
+		service: service,
+	}
+}
+
+func (h *ChangePasswordHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxChangePasswordBodySize)
+
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		httphelper.RenderProblem(w, r, http.StatusUnauthorized, ErrMissingUserID)
+		return
+	}
+
+	input, err := h.parseRequest(r, userID)
+	if err != nil {
+		httphelper.RenderProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.service.Execute(ctx, *input); err != nil {
+		httphelper.WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ChangePasswordHandler) parseRequest(r *http.Request, userID string) (*change_password.Input, error) {
+	var req changePasswordRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		return nil, ErrInvalidBody
+	}
+
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		return nil, ErrMissingPasswordFields
+	}
+
+	return &change_password.Input{
+		UserID:          userID,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	}, nil
+}
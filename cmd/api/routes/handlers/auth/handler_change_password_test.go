@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/usecase/change_password"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeChangePasswordService struct {
+	Err       error
+	LastInput change_password.Input
+}
+
+func (f *fakeChangePasswordService) Execute(_ context.Context, input change_password.Input) error {
+	f.LastInput = input
+	return f.Err
+}
+
+func TestChangePasswordHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		authUserID     string
+		body           any
+		mockService    *fakeChangePasswordService
+		expectedStatus int
+	}{
+		{
+			name:           "changes password",
+			authUserID:     "usr_123",
+			body:           map[string]string{"current_password": "correcthorse42", "new_password": "batterystaple77"},
+			mockService:    &fakeChangePasswordService{},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "missing authenticated user",
+			body:           map[string]string{"current_password": "correcthorse42", "new_password": "batterystaple77"},
+			mockService:    &fakeChangePasswordService{},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "missing new password",
+			authUserID:     "usr_123",
+			body:           map[string]string{"current_password": "correcthorse42"},
+			mockService:    &fakeChangePasswordService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "wrong current password",
+			authUserID:     "usr_123",
+			body:           map[string]string{"current_password": "guess", "new_password": "batterystaple77"},
+			mockService:    &fakeChangePasswordService{Err: usecase.Unauthorized("current password is incorrect")},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "weak new password",
+			authUserID:     "usr_123",
+			body:           map[string]string{"current_password": "correcthorse42", "new_password": "short"},
+			mockService:    &fakeChangePasswordService{Err: usecase.InvalidParam("password must be 10 to 128 characters")},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPut, "/me/password", bytes.NewReader(bodyBytes))
+			if tc.authUserID != "" {
+				req = req.WithContext(auth.WithUserID(req.Context(), tc.authUserID))
+			}
+			rr := httptest.NewRecorder()
+
+			NewChangePasswordHandler(tc.mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusNoContent {
+				assert.Equal(t, tc.authUserID, tc.mockService.LastInput.UserID)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/usecase/create_api_key"
+)
+
+const maxCreateAPIKeyBodySize = 2 * 1024 // 2kb
+
+var ErrMissingKeyFields = errors.New("name and scopes are required")
+
+type createAPIKeyService interface {
+	Execute(ctx context.Context, input create_api_key.Input) (create_api_key.Output, error)
+}
+
+type CreateAPIKeyHandler struct {
+	service createAPIKeyService
+}
+
+func NewCreateAPIKeyHandler(service createAPIKeyService) *CreateAPIKeyHandler {
+	return &CreateAPIKeyHandler{
+		service: service,
+	}
+}
+
+func (h *CreateAPIKeyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxCreateAPIKeyBodySize)
+
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		httphelper.RenderProblem(w, r, http.StatusUnauthorized, ErrMissingUserID)
+		return
+	}
+
+	input, err := h.parseRequest(r, userID)
+	if err != nil {
+		httphelper.RenderProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	output, err := h.service.Execute(ctx, *input)
+	if err != nil {
+		httphelper.WriteError(w, r, err)
+		return
+	}
+
+	h.renderResponse(r.Context(), w, output)
+}
+
+func (h *CreateAPIKeyHandler) parseRequest(r *http.Request, userID string) (*create_api_key.Input, error) {
+	var req createAPIKeyRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		return nil, ErrInvalidBody
+	}
+
+	if req.Name == "" || len(req.Scopes) == 0 {
+		return nil, ErrMissingKeyFields
+	}
+
+	return &create_api_key.Input{
+		UserID: userID,
+		Name:   req.Name,
+		Scopes: req.Scopes,
+	}, nil
+}
+
+func (h *CreateAPIKeyHandler) renderResponse(ctx context.Context, w http.ResponseWriter, output create_api_key.Output) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+
+	response := createAPIKeyResponse{
+		ID:        output.ID,
+		Key:       output.Key,
+		Prefix:    output.Prefix,
+		Name:      output.Name,
+		Scopes:    output.Scopes,
+		CreatedAt: output.CreatedAt.Format(time.RFC3339),
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.FromContext(ctx).Error("failed to encode create api key response", zap.Error(err))
+		return
+	}
+}
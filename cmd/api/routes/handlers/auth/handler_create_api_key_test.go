@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/usecase/create_api_key"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCreateAPIKeyService struct {
+	Output    create_api_key.Output
+	Err       error
+	LastInput create_api_key.Input
+}
+
+func (f *fakeCreateAPIKeyService) Execute(_ context.Context, input create_api_key.Input) (create_api_key.Output, error) {
+	f.LastInput = input
+	return f.Output, f.Err
+}
+
+func TestCreateAPIKeyHandler(t *testing.T) {
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		authUserID     string
+		body           any
+		mockService    *fakeCreateAPIKeyService
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:       "creates a key",
+			authUserID: "usr_123",
+			body:       map[string]any{"name": "bot", "scopes": []string{"tweets:write"}},
+			mockService: &fakeCreateAPIKeyService{Output: create_api_key.Output{
+				ID: "k1", Key: "uak_secret", Prefix: "uak_secret", Name: "bot", Scopes: []string{"tweets:write"}, CreatedAt: createdAt,
+			}},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"k1","key":"uak_secret","prefix":"uak_secret","name":"bot","scopes":["tweets:write"],"created_at":"2025-06-01T12:00:00Z"}`,
+		},
+		{
+			name:           "missing authenticated user",
+			body:           map[string]any{"name": "bot", "scopes": []string{"tweets:write"}},
+			mockService:    &fakeCreateAPIKeyService{},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "missing scopes",
+			authUserID:     "usr_123",
+			body:           map[string]any{"name": "bot"},
+			mockService:    &fakeCreateAPIKeyService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown scope",
+			authUserID:     "usr_123",
+			body:           map[string]any{"name": "bot", "scopes": []string{"admin"}},
+			mockService:    &fakeCreateAPIKeyService{Err: usecase.InvalidParam("unknown api key scope")},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			bodyBytes, _ := json.Marshal(tc.body)
+			req := httptest.NewRequest(http.MethodPost, "/me/api-keys", bytes.NewReader(bodyBytes))
+			if tc.authUserID != "" {
+				req = req.WithContext(auth.WithUserID(req.Context(), tc.authUserID))
+			}
+			rr := httptest.NewRecorder()
+
+			NewCreateAPIKeyHandler(tc.mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusCreated {
+				require.JSONEq(t, tc.expectedBody, rr.Body.String())
+				assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+				assert.Equal(t, tc.authUserID, tc.mockService.LastInput.UserID)
+			}
+		})
+	}
+}
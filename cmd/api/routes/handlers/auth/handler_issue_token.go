@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/usecase/issue_token"
+)
+
+const maxIssueTokenBodySize = 2 * 1024 // 2kb
+
+var (
+	ErrInvalidBody        = errors.New("invalid request body")
+	ErrMissingCredentials = errors.New("user_id and password are required")
+)
+
+type issueTokenService interface {
+	Execute(ctx context.Context, input issue_token.Input) (issue_token.Output, error)
+}
+
+type IssueTokenHandler struct {
+	service issueTokenService
+	now     func() time.Time
+}
+
+func NewIssueTokenHandler(service issueTokenService) *IssueTokenHandler {
+	return &IssueTokenHandler{
+		service: service,
+		now:     time.Now,
+	}
+}
+
+func (h *IssueTokenHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxIssueTokenBodySize)
+
+	input, err := h.parseRequest(r)
+	if err != nil {
+		httphelper.RenderProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	output, err := h.service.Execute(ctx, *input)
+	if err != nil {
+		httphelper.WriteError(w, r, err)
+		return
+	}
+
+	h.renderResponse(r.Context(), w, output)
+}
+
+func (h *IssueTokenHandler) parseRequest(r *http.Request) (*issue_token.Input, error) {
+	var req issueTokenRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		return nil, ErrInvalidBody
+	}
+
+	if req.UserID == "" || req.Password == "" {
+		return nil, ErrMissingCredentials
+	}
+
+	return &issue_token.Input{
+		UserID:   req.UserID,
+		Password: req.Password,
+	}, nil
+}
+
+func (h *IssueTokenHandler) renderResponse(ctx context.Context, w http.ResponseWriter, output issue_token.Output) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	response := issueTokenResponse{
+		AccessToken: output.Token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(output.ExpiresAt.Sub(h.now()).Seconds()),
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.FromContext(ctx).Error("failed to encode issue token response", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
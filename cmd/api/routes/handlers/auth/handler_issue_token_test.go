@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/usecase/issue_token"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeIssueTokenService struct {
+	Output issue_token.Output
+	Err    error
+}
+
+func (f *fakeIssueTokenService) Execute(_ context.Context, _ issue_token.Input) (issue_token.Output, error) {
+	return f.Output, f.Err
+}
+
+func TestIssueTokenHandler(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		body           any
+		mockService    *fakeIssueTokenService
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "issues a token",
+			body: map[string]string{"user_id": "usr_12345678", "password": "correcthorse42"},
+			mockService: &fakeIssueTokenService{
+				Output: issue_token.Output{Token: "jwt", ExpiresAt: now.Add(time.Hour)},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"access_token":"jwt","token_type":"Bearer","expires_in":3600}`,
+		},
+		{
+			name:           "empty body",
+			body:           nil,
+			mockService:    &fakeIssueTokenService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing password",
+			body:           map[string]string{"user_id": "usr_12345678"},
+			mockService:    &fakeIssueTokenService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown field",
+			body:           map[string]string{"user_id": "usr_12345678", "password": "correcthorse42", "role": "admin"},
+			mockService:    &fakeIssueTokenService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid credentials",
+			body:           map[string]string{"user_id": "usr_12345678", "password": "guess"},
+			mockService:    &fakeIssueTokenService{Err: usecase.Unauthorized("invalid credentials")},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "locked account",
+			body:           map[string]string{"user_id": "usr_12345678", "password": "correcthorse42"},
+			mockService:    &fakeIssueTokenService{Err: usecase.Forbidden("account temporarily locked")},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var bodyBytes []byte
+			if tc.body != nil {
+				bodyBytes, _ = json.Marshal(tc.body)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/token", bytes.NewReader(bodyBytes))
+			rr := httptest.NewRecorder()
+
+			handler := NewIssueTokenHandler(tc.mockService)
+			handler.now = func() time.Time { return now }
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				require.JSONEq(t, tc.expectedBody, rr.Body.String())
+				assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/usecase/list_api_keys"
+)
+
+type listAPIKeysService interface {
+	Execute(ctx context.Context, input list_api_keys.Input) ([]list_api_keys.APIKey, error)
+}
+
+type ListAPIKeysHandler struct {
+	service listAPIKeysService
+}
+
+func NewListAPIKeysHandler(service listAPIKeysService) *ListAPIKeysHandler {
+	return &ListAPIKeysHandler{
+		service: service,
+	}
+}
+
+func (h *ListAPIKeysHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		httphelper.RenderProblem(w, r, http.StatusUnauthorized, ErrMissingUserID)
+		return
+	}
+
+	keys, err := h.service.Execute(ctx, list_api_keys.Input{UserID: userID})
+	if err != nil {
+		httphelper.WriteError(w, r, err)
+		return
+	}
+
+	h.renderResponse(r.Context(), w, keys)
+}
+
+func (h *ListAPIKeysHandler) renderResponse(ctx context.Context, w http.ResponseWriter, keys []list_api_keys.APIKey) {
+	w.Header().Set("Content-Type", "application/json")
+
+	response := make([]apiKeyResponse, len(keys))
+	for i, k := range keys {
+		response[i] = apiKeyResponse{
+			ID:         k.ID,
+			Name:       k.Name,
+			Prefix:     k.Prefix,
+			Scopes:     k.Scopes,
+			CreatedAt:  k.CreatedAt.Format(time.RFC3339),
+			LastUsedAt: formatOptionalTime(k.LastUsedAt),
+			RevokedAt:  formatOptionalTime(k.RevokedAt),
+		}
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.FromContext(ctx).Error("failed to encode api keys response", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
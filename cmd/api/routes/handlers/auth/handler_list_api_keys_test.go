@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/usecase/list_api_keys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeListAPIKeysService struct {
+	Output []list_api_keys.APIKey
+	Err    error
+}
+
+func (f *fakeListAPIKeysService) Execute(_ context.Context, _ list_api_keys.Input) ([]list_api_keys.APIKey, error) {
+	return f.Output, f.Err
+}
+
+func TestListAPIKeysHandler(t *testing.T) {
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	usedAt := createdAt.Add(time.Hour)
+
+	tests := []struct {
+		name           string
+		authUserID     string
+		mockService    *fakeListAPIKeysService
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:       "lists keys",
+			authUserID: "usr_123",
+			mockService: &fakeListAPIKeysService{Output: []list_api_keys.APIKey{
+				{ID: "k1", Name: "bot", Prefix: "uak_abcdef", Scopes: []string{"tweets:write"}, CreatedAt: createdAt, LastUsedAt: &usedAt},
+				{ID: "k2", Name: "new", Prefix: "uak_ghijkl", Scopes: []string{"timeline:read"}, CreatedAt: createdAt},
+			}},
+			expectedStatus: http.StatusOK,
+			expectedBody: `[
+				{"id":"k1","name":"bot","prefix":"uak_abcdef","scopes":["tweets:write"],"created_at":"2025-06-01T12:00:00Z","last_used_at":"2025-06-01T13:00:00Z"},
+				{"id":"k2","name":"new","prefix":"uak_ghijkl","scopes":["timeline:read"],"created_at":"2025-06-01T12:00:00Z","last_used_at":null}
+			]`,
+		},
+		{
+			name:           "no keys",
+			authUserID:     "usr_123",
+			mockService:    &fakeListAPIKeysService{Output: []list_api_keys.APIKey{}},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[]`,
+		},
+		{
+			name:           "missing authenticated user",
+			mockService:    &fakeListAPIKeysService{},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "use case error",
+			authUserID:     "usr_123",
+			mockService:    &fakeListAPIKeysService{Err: errors.New("boom")},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/me/api-keys", nil)
+			if tc.authUserID != "" {
+				req = req.WithContext(auth.WithUserID(req.Context(), tc.authUserID))
+			}
+			rr := httptest.NewRecorder()
+
+			NewListAPIKeysHandler(tc.mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedBody != "" {
+				require.JSONEq(t, tc.expectedBody, rr.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/usecase/revoke_api_key"
+)
+
+var ErrMissingKeyID = errors.New("missing api key ID in path")
+
+type revokeAPIKeyService interface {
+	Execute(ctx context.Context, input revoke_api_key.Input) error
+}
+
+type RevokeAPIKeyHandler struct {
+	service revokeAPIKeyService
+}
+
+func NewRevokeAPIKeyHandler(service revokeAPIKeyService) *RevokeAPIKeyHandler {
+	return &RevokeAPIKeyHandler{
+		service: service,
+	}
+}
+
+func (h *RevokeAPIKeyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		httphelper.RenderProblem(w, r, http.StatusUnauthorized, ErrMissingUserID)
+		return
+	}
+
+	keyID := mux.Vars(r)["id"]
+	if keyID == "" {
+		httphelper.RenderProblem(w, r, http.StatusBadRequest, ErrMissingKeyID)
+		return
+	}
+
+	if err := h.service.Execute(ctx, revoke_api_key.Input{UserID: userID, KeyID: keyID}); err != nil {
+		httphelper.WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
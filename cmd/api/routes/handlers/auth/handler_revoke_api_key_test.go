@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/usecase/revoke_api_key"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRevokeAPIKeyService struct {
+	Err       error
+	LastInput revoke_api_key.Input
+}
+
+func (f *fakeRevokeAPIKeyService) Execute(_ context.Context, input revoke_api_key.Input) error {
+	f.LastInput = input
+	return f.Err
+}
+
+func TestRevokeAPIKeyHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		authUserID     string
+		keyID          string
+		mockService    *fakeRevokeAPIKeyService
+		expectedStatus int
+	}{
+		{
+			name:           "revokes the key",
+			authUserID:     "usr_123",
+			keyID:          "k1",
+			mockService:    &fakeRevokeAPIKeyService{},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "missing authenticated user",
+			keyID:          "k1",
+			mockService:    &fakeRevokeAPIKeyService{},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "missing key ID",
+			authUserID:     "usr_123",
+			mockService:    &fakeRevokeAPIKeyService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown key",
+			authUserID:     "usr_123",
+			keyID:          "nope",
+			mockService:    &fakeRevokeAPIKeyService{Err: usecase.NotFound("api key not found")},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/me/api-keys/"+tc.keyID, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tc.keyID})
+			if tc.authUserID != "" {
+				req = req.WithContext(auth.WithUserID(req.Context(), tc.authUserID))
+			}
+			rr := httptest.NewRecorder()
+
+			NewRevokeAPIKeyHandler(tc.mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusNoContent {
+				assert.Equal(t, revoke_api_key.Input{UserID: tc.authUserID, KeyID: tc.keyID}, tc.mockService.LastInput)
+			}
+		})
+	}
+}
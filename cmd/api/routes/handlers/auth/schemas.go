@@ -0,0 +1,16 @@
+package auth
+
+import "ualaTwitter/internal/platform/openapi"
+
+// Schemas describes the package DTOs for the OpenAPI document, keyed by
+// the exported form of the DTO type name.
+func Schemas() map[string]*openapi.Schema {
+	return map[string]*openapi.Schema{
+		"IssueTokenRequest":     openapi.SchemaOf(issueTokenRequest{}),
+		"IssueTokenResponse":    openapi.SchemaOf(issueTokenResponse{}),
+		"ChangePasswordRequest": openapi.SchemaOf(changePasswordRequest{}),
+		"CreateAPIKeyRequest":   openapi.SchemaOf(createAPIKeyRequest{}),
+		"CreateAPIKeyResponse":  openapi.SchemaOf(createAPIKeyResponse{}),
+		"APIKeyResponse":        openapi.SchemaOf(apiKeyResponse{}),
+	}
+}
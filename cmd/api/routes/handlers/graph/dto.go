@@ -0,0 +1,25 @@
+package graph
+
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// graphQLResponse omits data when the query was rejected before running.
+type graphQLResponse struct {
+	Data   any            `json:"data,omitempty"`
+	Errors []graphQLError `json:"errors,omitempty"`
+}
+
+type graphQLError struct {
+	Message    string            `json:"message"`
+	Locations  []graphQLLocation `json:"locations,omitempty"`
+	Path       []any             `json:"path,omitempty"`
+	Extensions map[string]any    `json:"extensions,omitempty"`
+}
+
+type graphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
@@ -0,0 +1,143 @@
+// Package graph serves the read side of the API as GraphQL, so clients
+// can fetch a timeline with authors and like state in one round trip.
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"go.uber.org/zap"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/platform/querylimit"
+)
+
+const maxGraphQLBodySize = 64 * 1024 // 64 KB
+
+var (
+	ErrMissingUserID = errors.New("missing authenticated user")
+	ErrInvalidBody   = errors.New("invalid request body")
+	ErrEmptyQuery    = errors.New("query cannot be empty")
+)
+
+type GraphQLHandler struct {
+	deps   Dependencies
+	limits querylimit.Limits
+}
+
+func NewGraphQLHandler(deps Dependencies, limits querylimit.Limits) *GraphQLHandler {
+	return &GraphQLHandler{
+		deps:   deps,
+		limits: limits,
+	}
+}
+
+// ServeHTTP answers with 200 and the GraphQL response, errors included, as
+// GraphQL clients expect. Only requests that are not GraphQL at all get a
+// problem document.
+func (h *GraphQLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	viewerID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		httphelper.RenderProblem(w, r, http.StatusUnauthorized, ErrMissingUserID)
+		return
+	}
+
+	req, err := h.parseRequest(w, r)
+	if err != nil {
+		httphelper.RenderProblem(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	h.renderResponse(ctx, w, h.execute(ctx, viewerID, req))
+}
+
+func (h *GraphQLHandler) parseRequest(w http.ResponseWriter, r *http.Request) (*graphQLRequest, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxGraphQLBodySize)
+
+	var req graphQLRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		return nil, ErrInvalidBody
+	}
+
+	if req.Query == "" {
+		return nil, ErrEmptyQuery
+	}
+
+	return &req, nil
+}
+
+// execute parses and validates the query and checks its limits before
+// running any resolver.
+func (h *GraphQLHandler) execute(ctx context.Context, viewerID string, req *graphQLRequest) graphQLResponse {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return graphQLResponse{Errors: toErrors(gqlerrors.FormatErrors(err))}
+	}
+
+	if result := graphql.ValidateDocument(&schema, doc, graphql.SpecifiedRules); !result.IsValid {
+		return graphQLResponse{Errors: toErrors(result.Errors)}
+	}
+
+	if errs := checkLimits(h.limits, doc, req.OperationName, req.Variables); len(errs) > 0 {
+		return graphQLResponse{Errors: toErrors(errs)}
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withState(ctx, newRequestState(viewerID, h.deps)),
+	})
+	// Once execution started data must be present even if null; a typed
+	// nil survives omitempty.
+	data := result.Data
+	if data == nil {
+		data = map[string]any(nil)
+	}
+	return graphQLResponse{Data: data, Errors: toErrors(result.Errors)}
+}
+
+func (h *GraphQLHandler) renderResponse(ctx context.Context, w http.ResponseWriter, resp graphQLResponse) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logger.FromContext(ctx).Error("failed to encode graphql response", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+func toErrors(errs []gqlerrors.FormattedError) []graphQLError {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	out := make([]graphQLError, len(errs))
+	for i, err := range errs {
+		out[i] = graphQLError{
+			Message:    err.Message,
+			Path:       err.Path,
+			Extensions: err.Extensions,
+		}
+		for _, loc := range err.Locations {
+			out[i].Locations = append(out[i].Locations, graphQLLocation{Line: loc.Line, Column: loc.Column})
+		}
+	}
+	return out
+}
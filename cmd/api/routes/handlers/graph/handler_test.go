@@ -0,0 +1,321 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/platform/querylimit"
+	"ualaTwitter/internal/usecase/get_timeline"
+)
+
+var (
+	baseTime = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testUsers = map[string]user.User{
+		"usr_1": {ID: "usr_1", Name: "Ana", Document: "11111111"},
+		"usr_2": {ID: "usr_2", Name: "Bob", Document: "22222222"},
+		"usr_3": {ID: "usr_3", Name: "Cy", Document: "33333333"},
+	}
+	testTweets = []tweet.Tweet{
+		{ID: "tw_1", UserID: "usr_2", Content: "first", CreatedAt: baseTime, Likes: 1},
+		{ID: "tw_2", UserID: "usr_3", Content: "second", CreatedAt: baseTime.Add(time.Minute)},
+		{ID: "tw_3", UserID: "usr_2", Content: "third", CreatedAt: baseTime.Add(2 * time.Minute), Likes: 4},
+	}
+)
+
+// fakeStore records repository calls so tests can assert on batching.
+type fakeStore struct {
+	mu           sync.Mutex
+	getByIDs     [][]string
+	hasLikedMany [][]string
+	timelineErr  error
+	timelineIn   get_timeline.Input
+}
+
+func (f *fakeStore) Execute(_ context.Context, input get_timeline.Input) ([]get_timeline.TweetTimeline, error) {
+	f.timelineIn = input
+	if f.timelineErr != nil {
+		return nil, f.timelineErr
+	}
+	var page []get_timeline.TweetTimeline
+	for i := len(testTweets) - 1; i >= 0; i-- {
+		t := testTweets[i]
+		page = append(page, get_timeline.TweetTimeline{
+			ID: t.ID, UserID: t.UserID, Content: t.Content, Likes: t.Likes, CreatedAt: t.CreatedAt,
+			LikedByMe: t.ID == "tw_3",
+		})
+	}
+	return page, nil
+}
+
+func (f *fakeStore) GetByIDs(_ context.Context, ids []string) ([]user.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.getByIDs = append(f.getByIDs, ids)
+	var users []user.User
+	for _, id := range ids {
+		if u, ok := testUsers[id]; ok {
+			users = append(users, u)
+		}
+	}
+	return users, nil
+}
+
+func (f *fakeStore) GetUsersFollowedBy(_ context.Context, userID string) ([]string, error) {
+	if userID == "usr_1" {
+		return []string{"usr_3", "usr_2"}, nil
+	}
+	return []string{}, nil
+}
+
+func (f *fakeStore) GetFollowersOf(_ context.Context, userID string) ([]string, error) {
+	if userID == "usr_1" {
+		return []string{}, nil
+	}
+	return []string{"usr_1"}, nil
+}
+
+func (f *fakeStore) GetByID(_ context.Context, id string) (tweet.Tweet, error) {
+	for _, t := range testTweets {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return tweet.Tweet{}, tweet.ErrNotFound
+}
+
+func (f *fakeStore) FindTweetsAuthoredBy(_ context.Context, userID string) ([]tweet.Tweet, error) {
+	var out []tweet.Tweet
+	for _, t := range testTweets {
+		if t.UserID == userID {
+			out = append(out, t)
+		}
+	}
+	if out == nil {
+		return []tweet.Tweet{}, user.ErrUserNotFound
+	}
+	return out, nil
+}
+
+func (f *fakeStore) HasLikedMany(_ context.Context, _ string, tweetIDs []string) (map[string]bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.hasLikedMany = append(f.hasLikedMany, tweetIDs)
+	return map[string]bool{"tw_1": true}, nil
+}
+
+func newTestHandler(store *fakeStore, limits querylimit.Limits) *GraphQLHandler {
+	return NewGraphQLHandler(Dependencies{Timeline: store, Users: store, Tweets: store, Likes: store}, limits)
+}
+
+func doQuery(t *testing.T, h http.Handler, userID string, body any) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+	raw, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(raw))
+	if userID != "" {
+		req = req.WithContext(auth.WithUserID(req.Context(), userID))
+	}
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	var resp map[string]any
+	if rr.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	}
+	return rr, resp
+}
+
+func firstError(t *testing.T, resp map[string]any) map[string]any {
+	t.Helper()
+	errs, ok := resp["errors"].([]any)
+	require.True(t, ok, "expected errors in %v", resp)
+	require.NotEmpty(t, errs)
+	return errs[0].(map[string]any)
+}
+
+func TestGraphQLHandler_TimelineBatchesLookups(t *testing.T) {
+	store := &fakeStore{}
+	h := newTestHandler(store, querylimit.Default)
+
+	rr, resp := doQuery(t, h, "usr_1", graphQLRequest{
+		Query:     `query($n: Int) { timeline(limit: $n, mode: RANKED) { id likedByMe likes createdAt author { name } } }`,
+		Variables: map[string]any{"n": 3},
+	})
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, resp["errors"])
+	assert.Equal(t, get_timeline.Input{UserID: "usr_1", Limit: 3, Mode: get_timeline.ModeRanked}, store.timelineIn)
+
+	timeline := resp["data"].(map[string]any)["timeline"].([]any)
+	require.Len(t, timeline, 3)
+	newest := timeline[0].(map[string]any)
+	assert.Equal(t, "tw_3", newest["id"])
+	assert.Equal(t, true, newest["likedByMe"])
+	assert.Equal(t, float64(4), newest["likes"])
+	assert.Equal(t, "2026-01-02T03:06:05Z", newest["createdAt"])
+	assert.Equal(t, "Bob", newest["author"].(map[string]any)["name"])
+
+	// Three tweets by two authors: one user lookup, and the like state
+	// comes from the use case.
+	require.Len(t, store.getByIDs, 1)
+	assert.ElementsMatch(t, []string{"usr_2", "usr_3"}, store.getByIDs[0])
+	assert.Empty(t, store.hasLikedMany)
+}
+
+func TestGraphQLHandler_NestedListsBatchPerLevel(t *testing.T) {
+	store := &fakeStore{}
+	h := newTestHandler(store, querylimit.Default)
+
+	_, resp := doQuery(t, h, "usr_1", graphQLRequest{
+		Query: `{ me { name following { id tweets { id likedByMe author { id } } } } }`,
+	})
+
+	assert.Nil(t, resp["errors"])
+	me := resp["data"].(map[string]any)["me"].(map[string]any)
+	assert.Equal(t, "Ana", me["name"])
+	following := me["following"].([]any)
+	require.Len(t, following, 2)
+	assert.Equal(t, "usr_2", following[0].(map[string]any)["id"])
+
+	bobTweets := following[0].(map[string]any)["tweets"].([]any)
+	require.Len(t, bobTweets, 2)
+	assert.Equal(t, "tw_3", bobTweets[0].(map[string]any)["id"], "newest first")
+	assert.Equal(t, true, bobTweets[1].(map[string]any)["likedByMe"])
+
+	// me and following are one lookup each; the authors were loaded with
+	// following. The like state of every tweet is a single lookup.
+	assert.Equal(t, [][]string{{"usr_1"}, {"usr_2", "usr_3"}}, store.getByIDs)
+	require.Len(t, store.hasLikedMany, 1)
+	assert.ElementsMatch(t, []string{"tw_1", "tw_2", "tw_3"}, store.hasLikedMany[0])
+}
+
+func TestGraphQLHandler_NullForUnknownResources(t *testing.T) {
+	h := newTestHandler(&fakeStore{}, querylimit.Default)
+
+	_, resp := doQuery(t, h, "usr_1", graphQLRequest{
+		Query: `{ user(id: "usr_9") { id } tweet(id: "tw_9") { id } }`,
+	})
+
+	assert.Nil(t, resp["errors"])
+	assert.Equal(t, map[string]any{"user": nil, "tweet": nil}, resp["data"])
+}
+
+func TestGraphQLHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		store       *fakeStore
+		limits      querylimit.Limits
+		query       string
+		wantMessage string
+		wantCode    any
+		wantData    bool
+	}{
+		{
+			name:        "syntax error",
+			query:       `{ me {`,
+			wantMessage: "Syntax Error",
+		},
+		{
+			name:        "unknown field",
+			query:       `{ me { document } }`,
+			wantMessage: `Cannot query field "document" on type "User".`,
+		},
+		{
+			name:        "too deep",
+			limits:      querylimit.Limits{MaxDepth: 3},
+			query:       `{ me { following { following { id } } } }`,
+			wantMessage: "query depth 4 exceeds the limit of 3",
+			wantCode:    codeQueryTooDeep,
+		},
+		{
+			name:        "too complex",
+			limits:      querylimit.Limits{MaxComplexity: 500},
+			query:       `{ timeline(limit: 100) { id author { id tweets(limit: 100) { id } } } }`,
+			wantMessage: "exceeds the limit of 500",
+			wantCode:    codeQueryTooComplex,
+		},
+		{
+			name:        "complexity counts fragments and variable defaults",
+			limits:      querylimit.Limits{MaxComplexity: 50},
+			query:       `query($n: Int = 60) { timeline(limit: $n) { ...t } } fragment t on Tweet { id }`,
+			wantMessage: "query complexity 61 exceeds the limit of 50",
+			wantCode:    codeQueryTooComplex,
+		},
+		{
+			name:        "use case error",
+			store:       &fakeStore{timelineErr: usecase.InvalidParam("ranked timeline is not available")},
+			query:       `{ timeline(mode: RANKED) { id } }`,
+			wantMessage: "ranked timeline is not available",
+			wantCode:    usecase.TypeInvalidParam,
+			wantData:    true,
+		},
+		{
+			name:        "internal errors are hidden",
+			store:       &fakeStore{timelineErr: errors.New("connection refused")},
+			query:       `{ timeline { id } }`,
+			wantMessage: internalMessage,
+			wantCode:    usecase.TypeInternalServerError,
+			wantData:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := tc.store
+			if store == nil {
+				store = &fakeStore{}
+			}
+			h := newTestHandler(store, tc.limits)
+
+			rr, resp := doQuery(t, h, "usr_1", graphQLRequest{Query: tc.query})
+
+			require.Equal(t, http.StatusOK, rr.Code)
+			err := firstError(t, resp)
+			assert.Contains(t, err["message"], tc.wantMessage)
+			if tc.wantCode != nil {
+				assert.Equal(t, tc.wantCode, err["extensions"].(map[string]any)["code"])
+			}
+			_, hasData := resp["data"]
+			assert.Equal(t, tc.wantData, hasData)
+		})
+	}
+}
+
+func TestGraphQLHandler_RejectsBadRequests(t *testing.T) {
+	h := newTestHandler(&fakeStore{}, querylimit.Default)
+
+	rr, _ := doQuery(t, h, "", graphQLRequest{Query: `{ me { id } }`})
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	rr, _ = doQuery(t, h, "usr_1", graphQLRequest{})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr, _ = doQuery(t, h, "usr_1", map[string]any{"query": "{ me { id } }", "unknown": true})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestDefaultLimits_AdmitTimelinePage(t *testing.T) {
+	h := newTestHandler(&fakeStore{}, querylimit.Default)
+
+	_, resp := doQuery(t, h, "usr_1", graphQLRequest{
+		Query: `{ timeline(limit: 50) { id content likes likedByMe createdAt author { id name tweets(limit: 3) { id content } } } }`,
+	})
+
+	assert.Nil(t, resp["errors"])
+}
@@ -0,0 +1,193 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"ualaTwitter/internal/platform/querylimit"
+)
+
+const (
+	codeQueryTooDeep    = "query_too_deep"
+	codeQueryTooComplex = "query_too_complex"
+)
+
+// cost is the depth and complexity of a selection set.
+type cost struct {
+	depth      int
+	complexity int
+}
+
+// check measures the operation that will be executed. It runs after
+// validation, so fields, fragments and types are known to exist and
+// fragments cannot cycle. Zero limits are not enforced.
+func checkLimits(l querylimit.Limits, doc *ast.Document, operationName string, variables map[string]any) []gqlerrors.FormattedError {
+	a := analyzer{
+		fragments: map[string]*ast.FragmentDefinition{},
+		variables: variables,
+	}
+	var op *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				op = def
+			}
+		case *ast.FragmentDefinition:
+			a.fragments[def.Name.Value] = def
+		}
+	}
+	if op == nil || op.Operation != ast.OperationTypeQuery {
+		return nil
+	}
+	a.defaults = variableDefaults(op)
+
+	c := a.selectionSet(op.SelectionSet, schema.QueryType())
+
+	var errs []gqlerrors.FormattedError
+	if l.MaxDepth > 0 && c.depth > l.MaxDepth {
+		errs = append(errs, limitError(codeQueryTooDeep, fmt.Sprintf("query depth %d exceeds the limit of %d", c.depth, l.MaxDepth)))
+	}
+	if l.MaxComplexity > 0 && c.complexity > l.MaxComplexity {
+		errs = append(errs, limitError(codeQueryTooComplex, fmt.Sprintf("query complexity %d exceeds the limit of %d", c.complexity, l.MaxComplexity)))
+	}
+	return errs
+}
+
+type analyzer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+	defaults  map[string]ast.Value
+}
+
+func (a analyzer) selectionSet(set *ast.SelectionSet, parent *graphql.Object) cost {
+	var total cost
+	if set == nil || parent == nil {
+		return total
+	}
+
+	for _, selection := range set.Selections {
+		var c cost
+		switch selection := selection.(type) {
+		case *ast.Field:
+			c = a.field(selection, parent)
+		case *ast.InlineFragment:
+			c = a.selectionSet(selection.SelectionSet, a.typeCondition(selection.TypeCondition, parent))
+		case *ast.FragmentSpread:
+			if fragment, ok := a.fragments[selection.Name.Value]; ok {
+				c = a.selectionSet(fragment.SelectionSet, a.typeCondition(fragment.TypeCondition, parent))
+			}
+		}
+		total.depth = max(total.depth, c.depth)
+		total.complexity += c.complexity
+	}
+	return total
+}
+
+func (a analyzer) field(field *ast.Field, parent *graphql.Object) cost {
+	// Introspection is bounded by the schema, and clients such as GraphiQL
+	// send deep introspection queries.
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return cost{}
+	}
+	def, ok := parent.Fields()[field.Name.Value]
+	if !ok {
+		return cost{}
+	}
+
+	object, list := unwrap(def.Type)
+	child := a.selectionSet(field.SelectionSet, object)
+
+	multiplier := 1
+	if list {
+		multiplier = a.limit(field, def)
+	}
+	return cost{
+		depth:      1 + child.depth,
+		complexity: 1 + multiplier*child.complexity,
+	}
+}
+
+// limit is the page size a list field resolves to, see listLimit.
+func (a analyzer) limit(field *ast.Field, def *graphql.FieldDefinition) int {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value == "limit" {
+			return clampLimit(a.intValue(arg.Value))
+		}
+	}
+	for _, arg := range def.Args {
+		if arg.Name() == "limit" {
+			limit, _ := arg.DefaultValue.(int)
+			return clampLimit(limit)
+		}
+	}
+	return maxListLimit
+}
+
+func (a analyzer) intValue(value ast.Value) int {
+	switch value := value.(type) {
+	case *ast.IntValue:
+		n, _ := strconv.Atoi(value.Value)
+		return n
+	case *ast.Variable:
+		name := value.Name.Value
+		switch v := a.variables[name].(type) {
+		case int:
+			return v
+		case float64:
+			return int(v)
+		case nil:
+			if def, ok := a.defaults[name]; ok {
+				return a.intValue(def)
+			}
+		}
+	}
+	return 0
+}
+
+func (a analyzer) typeCondition(named *ast.Named, parent *graphql.Object) *graphql.Object {
+	if named == nil {
+		return parent
+	}
+	object, _ := schema.Type(named.Name.Value).(*graphql.Object)
+	return object
+}
+
+func variableDefaults(op *ast.OperationDefinition) map[string]ast.Value {
+	defaults := map[string]ast.Value{}
+	for _, def := range op.VariableDefinitions {
+		if def.DefaultValue != nil {
+			defaults[def.Variable.Name.Value] = def.DefaultValue
+		}
+	}
+	return defaults
+}
+
+// unwrap returns the object type behind non-null and list wrappers and
+// whether a list was among them.
+func unwrap(t graphql.Output) (*graphql.Object, bool) {
+	list := false
+	for {
+		switch wrapped := t.(type) {
+		case *graphql.NonNull:
+			t = wrapped.OfType
+		case *graphql.List:
+			list = true
+			t = wrapped.OfType
+		case *graphql.Object:
+			return wrapped, list
+		default:
+			return nil, list
+		}
+	}
+}
+
+func limitError(code, message string) gqlerrors.FormattedError {
+	err := gqlerrors.NewFormattedError(message)
+	err.Extensions = map[string]any{"code": code}
+	return err
+}
@@ -0,0 +1,312 @@
+package graph
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/graphql-go/graphql"
+
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/usecase/get_timeline"
+)
+
+const (
+	defaultListLimit = 10
+	// maxListLimit caps every list argument; limits.go assumes it when
+	// estimating the cost of a query.
+	maxListLimit = 100
+)
+
+// schema is static; resolvers find the per-request dependencies and
+// loaders in the context (see requestState).
+var schema = mustBuildSchema()
+
+// tweetNode is the source value of Tweet. likedByMe is set when the use
+// case already computed it, otherwise it is loaded in batch.
+type tweetNode struct {
+	tweet.Tweet
+	likedByMe *bool
+}
+
+var timelineModeEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "TimelineMode",
+	Description: "Ordering of the timeline.",
+	Values: graphql.EnumValueConfigMap{
+		"CHRONOLOGICAL": {Value: get_timeline.ModeChronological, Description: "Newest first."},
+		"RANKED":        {Value: get_timeline.ModeRanked, Description: "By affinity with the viewer's likes."},
+	},
+})
+
+func limitArg(description string) *graphql.ArgumentConfig {
+	return &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultListLimit, Description: description}
+}
+
+func mustBuildSchema() graphql.Schema {
+	// User refers to itself and to Tweet; its fields are a thunk so both
+	// are set by the time they are built.
+	var userType, tweetType *graphql.Object
+
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: userField(func(u user.User) any { return u.ID })},
+				"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: userField(func(u user.User) any { return u.Name })},
+				"tweets": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(tweetType))),
+					Description: "Tweets authored by the user, newest first.",
+					Args:        graphql.FieldConfigArgument{"limit": limitArg("Page size, at most 100.")},
+					Resolve:     resolveUserTweets,
+				},
+				"following": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userType))),
+					Description: "Users this user follows.",
+					Args:        graphql.FieldConfigArgument{"limit": limitArg("Page size, at most 100.")},
+					Resolve:     resolveFollowing,
+				},
+				"followers": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(userType))),
+					Description: "Users following this user.",
+					Args:        graphql.FieldConfigArgument{"limit": limitArg("Page size, at most 100.")},
+					Resolve:     resolveFollowers,
+				},
+			}
+		}),
+	})
+	tweetType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Tweet",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: tweetField(func(t tweetNode) any { return t.ID })},
+			"content":   &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: tweetField(func(t tweetNode) any { return t.Content })},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime), Resolve: tweetField(func(t tweetNode) any { return t.CreatedAt })},
+			"likes":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: tweetField(func(t tweetNode) any { return t.Likes })},
+			"likedByMe": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: resolveLikedByMe},
+			"author": &graphql.Field{
+				Type:        userType,
+				Description: "Null when the author no longer exists.",
+				Resolve:     resolveAuthor,
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"me": &graphql.Field{
+				Type:    graphql.NewNonNull(userType),
+				Resolve: resolveMe,
+			},
+			"user": &graphql.Field{
+				Type:    userType,
+				Args:    graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: resolveUser,
+			},
+			"tweet": &graphql.Field{
+				Type:    tweetType,
+				Args:    graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: resolveTweet,
+			},
+			"timeline": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(tweetType))),
+				Description: "Tweets of the users the viewer follows.",
+				Args: graphql.FieldConfigArgument{
+					"limit":  limitArg("Page size, at most 100."),
+					"offset": {Type: graphql.Int, DefaultValue: 0, Description: "Items to skip."},
+					"mode":   {Type: timelineModeEnum, DefaultValue: get_timeline.ModeChronological},
+				},
+				Resolve: resolveTimeline,
+			},
+		},
+	})
+
+	s, err := graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+	if err != nil {
+		panic("graph: invalid schema: " + err.Error())
+	}
+	return s
+}
+
+func userField(get func(user.User) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		return get(p.Source.(user.User)), nil
+	}
+}
+
+func tweetField(get func(tweetNode) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		return get(p.Source.(tweetNode)), nil
+	}
+}
+
+func resolveMe(p graphql.ResolveParams) (any, error) {
+	state := stateFrom(p.Context)
+	load := state.users.Load(p.Context, state.viewerID)
+	return func() (any, error) {
+		u, found, err := load()
+		if err != nil {
+			return nil, resolverError(err)
+		}
+		if !found {
+			return nil, errNotFound(user.ErrUserNotFound)
+		}
+		return u, nil
+	}, nil
+}
+
+func resolveUser(p graphql.ResolveParams) (any, error) {
+	return loadUser(p, p.Args["id"].(string)), nil
+}
+
+func resolveAuthor(p graphql.ResolveParams) (any, error) {
+	return loadUser(p, p.Source.(tweetNode).UserID), nil
+}
+
+// loadUser resolves to null for unknown users.
+func loadUser(p graphql.ResolveParams, id string) func() (any, error) {
+	load := stateFrom(p.Context).users.Load(p.Context, id)
+	return func() (any, error) {
+		u, found, err := load()
+		if err != nil {
+			return nil, resolverError(err)
+		}
+		if !found {
+			return nil, nil
+		}
+		return u, nil
+	}
+}
+
+func resolveTweet(p graphql.ResolveParams) (any, error) {
+	t, err := stateFrom(p.Context).deps.Tweets.GetByID(p.Context, p.Args["id"].(string))
+	if errors.Is(err, tweet.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, resolverError(err)
+	}
+	return tweetNode{Tweet: t}, nil
+}
+
+func resolveTimeline(p graphql.ResolveParams) (any, error) {
+	state := stateFrom(p.Context)
+	page, err := state.deps.Timeline.Execute(p.Context, get_timeline.Input{
+		UserID: state.viewerID,
+		Limit:  listLimit(p),
+		Offset: p.Args["offset"].(int),
+		Mode:   p.Args["mode"].(string),
+	})
+	if err != nil {
+		return nil, resolverError(err)
+	}
+
+	nodes := make([]tweetNode, len(page))
+	for i, t := range page {
+		liked := t.LikedByMe
+		nodes[i] = tweetNode{
+			Tweet: tweet.Tweet{
+				ID:        t.ID,
+				UserID:    t.UserID,
+				Content:   t.Content,
+				CreatedAt: t.CreatedAt,
+				Likes:     t.Likes,
+			},
+			likedByMe: &liked,
+		}
+	}
+	return nodes, nil
+}
+
+func resolveUserTweets(p graphql.ResolveParams) (any, error) {
+	tweets, err := stateFrom(p.Context).deps.Tweets.FindTweetsAuthoredBy(p.Context, p.Source.(user.User).ID)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return []tweetNode{}, nil
+	}
+	if err != nil {
+		return nil, resolverError(err)
+	}
+
+	sorted := append([]tweet.Tweet(nil), tweets...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+	})
+	if limit := listLimit(p); len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+
+	nodes := make([]tweetNode, len(sorted))
+	for i, t := range sorted {
+		nodes[i] = tweetNode{Tweet: t}
+	}
+	return nodes, nil
+}
+
+func resolveFollowing(p graphql.ResolveParams) (any, error) {
+	state := stateFrom(p.Context)
+	ids, err := state.deps.Users.GetUsersFollowedBy(p.Context, p.Source.(user.User).ID)
+	if err != nil {
+		return nil, resolverError(err)
+	}
+	return loadUsers(p, ids), nil
+}
+
+func resolveFollowers(p graphql.ResolveParams) (any, error) {
+	state := stateFrom(p.Context)
+	ids, err := state.deps.Users.GetFollowersOf(p.Context, p.Source.(user.User).ID)
+	if err != nil {
+		return nil, resolverError(err)
+	}
+	return loadUsers(p, ids), nil
+}
+
+// loadUsers resolves the first page of ids, in ID order since follows are
+// unordered, through the batched user loader.
+func loadUsers(p graphql.ResolveParams, ids []string) func() (any, error) {
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	if limit := listLimit(p); len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+
+	load := stateFrom(p.Context).users.LoadMany(p.Context, sorted)
+	return func() (any, error) {
+		users, err := load()
+		if err != nil {
+			return nil, resolverError(err)
+		}
+		return users, nil
+	}
+}
+
+func resolveLikedByMe(p graphql.ResolveParams) (any, error) {
+	node := p.Source.(tweetNode)
+	if node.likedByMe != nil {
+		return *node.likedByMe, nil
+	}
+
+	load := stateFrom(p.Context).liked.Load(p.Context, node.ID)
+	return func() (any, error) {
+		liked, _, err := load()
+		if err != nil {
+			return nil, resolverError(err)
+		}
+		return liked, nil
+	}, nil
+}
+
+// listLimit reads the limit argument the way limits.go estimates it.
+func listLimit(p graphql.ResolveParams) int {
+	limit, _ := p.Args["limit"].(int)
+	return clampLimit(limit)
+}
+
+func clampLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		return defaultListLimit
+	case limit > maxListLimit:
+		return maxListLimit
+	default:
+		return limit
+	}
+}
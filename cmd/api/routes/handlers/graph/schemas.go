@@ -0,0 +1,14 @@
+package graph
+
+import "ualaTwitter/internal/platform/openapi"
+
+// Schemas describes the package DTOs for the OpenAPI document, keyed by
+// the exported form of the DTO type name.
+func Schemas() map[string]*openapi.Schema {
+	return map[string]*openapi.Schema{
+		"GraphQLRequest":  openapi.SchemaOf(graphQLRequest{}),
+		"GraphQLResponse": openapi.SchemaOf(graphQLResponse{}),
+		"GraphQLError":    openapi.SchemaOf(graphQLError{}),
+		"GraphQLLocation": openapi.SchemaOf(graphQLLocation{}),
+	}
+}
@@ -0,0 +1,115 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/dataloader"
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/usecase/get_timeline"
+)
+
+type timelineService interface {
+	Execute(ctx context.Context, input get_timeline.Input) ([]get_timeline.TweetTimeline, error)
+}
+
+type userReader interface {
+	GetByIDs(ctx context.Context, ids []string) ([]user.User, error)
+	GetUsersFollowedBy(ctx context.Context, userID string) ([]string, error)
+	GetFollowersOf(ctx context.Context, userID string) ([]string, error)
+}
+
+type tweetReader interface {
+	GetByID(ctx context.Context, id string) (tweet.Tweet, error)
+	FindTweetsAuthoredBy(ctx context.Context, userID string) ([]tweet.Tweet, error)
+}
+
+type likeReader interface {
+	HasLikedMany(ctx context.Context, userID string, tweetIDs []string) (map[string]bool, error)
+}
+
+// Dependencies back the resolvers: the timeline goes through its use case,
+// which ranks and caches it; the rest reads the repositories directly.
+type Dependencies struct {
+	Timeline timelineService
+	Users    userReader
+	Tweets   tweetReader
+	Likes    likeReader
+}
+
+// requestState is what resolvers of one request share. The loaders batch
+// the user and like lookups of a query level into one repository call.
+type requestState struct {
+	viewerID string
+	deps     Dependencies
+	users    *dataloader.Loader[string, user.User]
+	liked    *dataloader.Loader[string, bool]
+}
+
+type stateKey struct{}
+
+func newRequestState(viewerID string, deps Dependencies) *requestState {
+	return &requestState{
+		viewerID: viewerID,
+		deps:     deps,
+		users: dataloader.New(func(ctx context.Context, ids []string) (map[string]user.User, error) {
+			users, err := deps.Users.GetByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[string]user.User, len(users))
+			for _, u := range users {
+				byID[u.ID] = u
+			}
+			return byID, nil
+		}),
+		liked: dataloader.New(func(ctx context.Context, tweetIDs []string) (map[string]bool, error) {
+			return deps.Likes.HasLikedMany(ctx, viewerID, tweetIDs)
+		}),
+	}
+}
+
+func withState(ctx context.Context, state *requestState) context.Context {
+	return context.WithValue(ctx, stateKey{}, state)
+}
+
+func stateFrom(ctx context.Context) *requestState {
+	return ctx.Value(stateKey{}).(*requestState)
+}
+
+// internalMessage hides the cause of unexpected failures, as 5xx problem
+// documents do.
+const internalMessage = "an unexpected error occurred"
+
+// gqlError is a resolver error with a stable code in its extensions.
+type gqlError struct {
+	message string
+	code    string
+}
+
+func (e *gqlError) Error() string {
+	return e.message
+}
+
+func (e *gqlError) Extensions() map[string]any {
+	return map[string]any{"code": e.code}
+}
+
+// resolverError exposes the message of client errors and hides the rest.
+func resolverError(err error) error {
+	var ue *usecase.UseCaseError
+	if !errors.As(err, &ue) || ue.Type == usecase.TypeInternalServerError || ue.Type == usecase.TypeUnknown {
+		return &gqlError{message: internalMessage, code: usecase.TypeInternalServerError}
+	}
+	message := ue.Message
+	if message == "" && len(ue.Causes) > 0 && ue.Causes[0] != nil {
+		message = ue.Causes[0].Error()
+	}
+	return &gqlError{message: message, code: ue.Type}
+}
+
+func errNotFound(err error) error {
+	return &gqlError{message: err.Error(), code: usecase.TypeNotFound}
+}
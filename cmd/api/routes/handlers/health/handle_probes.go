@@ -0,0 +1,52 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// LiveHandler answers the liveness probe: the process is up and serving.
+// It checks no dependency, so a database outage does not get the instance
+// restarted.
+type LiveHandler struct{}
+
+func NewLiveHandler() *LiveHandler {
+	return &LiveHandler{}
+}
+
+func (h *LiveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(LiveStatus{Status: StatusUp})
+}
+
+// LiveStatus is the body of /health/live.
+type LiveStatus struct {
+	Status string `json:"status"`
+}
+
+// ReadyHandler answers the readiness probe with the report of checker:
+// 200 when every dependency is up, 503 otherwise or while draining.
+type ReadyHandler struct {
+	checker *Checker
+}
+
+func NewReadyHandler(checker *Checker) *ReadyHandler {
+	return &ReadyHandler{
+		checker: checker,
+	}
+}
+
+func (h *ReadyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := h.checker.Check(r.Context())
+
+	status := http.StatusOK
+	if report.Status != StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(report)
+}
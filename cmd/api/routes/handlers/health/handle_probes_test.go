@@ -0,0 +1,107 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func up(context.Context) error { return nil }
+
+func TestLiveHandler(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	NewLiveHandler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"status":"up"}`, rr.Body.String())
+}
+
+func TestReadyHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		checks         map[string]Check
+		drain          bool
+		expectedStatus int
+		expectedReport string
+		expectedDeps   map[string]string
+	}{
+		{
+			name:           "all dependencies up",
+			checks:         map[string]Check{"postgres": up, "timeline_cache": up},
+			expectedStatus: http.StatusOK,
+			expectedReport: StatusUp,
+			expectedDeps:   map[string]string{"postgres": StatusUp, "timeline_cache": StatusUp},
+		},
+		{
+			name: "one dependency down",
+			checks: map[string]Check{
+				"postgres":       func(context.Context) error { return errors.New("connection refused") },
+				"timeline_cache": up,
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedReport: StatusDown,
+			expectedDeps:   map[string]string{"postgres": StatusDown, "timeline_cache": StatusUp},
+		},
+		{
+			name:           "draining",
+			checks:         map[string]Check{"postgres": up},
+			drain:          true,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedReport: StatusDraining,
+			expectedDeps:   map[string]string{"postgres": StatusUp},
+		},
+		{
+			name:           "no dependencies",
+			expectedStatus: http.StatusOK,
+			expectedReport: StatusUp,
+			expectedDeps:   map[string]string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := NewChecker()
+			for name, check := range tc.checks {
+				checker.Register(name, check)
+			}
+			if tc.drain {
+				checker.Drain()
+			}
+			rr := httptest.NewRecorder()
+
+			NewReadyHandler(checker).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			var report ReadinessReport
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+			assert.Equal(t, tc.expectedReport, report.Status)
+			deps := map[string]string{}
+			for name, dep := range report.Dependencies {
+				deps[name] = dep.Status
+			}
+			assert.Equal(t, tc.expectedDeps, deps)
+		})
+	}
+}
+
+func TestChecker_TimesOutHungChecks(t *testing.T) {
+	checker := NewChecker()
+	checker.timeout = 10 * time.Millisecond
+	checker.Register("redis", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report := checker.Check(context.Background())
+
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Dependencies["redis"].Error)
+}
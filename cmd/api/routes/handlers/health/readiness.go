@@ -0,0 +1,111 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Statuses of a readiness report and of each dependency in it.
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusDraining = "draining"
+)
+
+// defaultCheckTimeout bounds each dependency check, so a hung dependency
+// fails readiness instead of hanging the probe.
+const defaultCheckTimeout = 2 * time.Second
+
+// Check reports whether one dependency is usable.
+type Check func(ctx context.Context) error
+
+// DependencyStatus is the outcome of one Check.
+type DependencyStatus struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// ReadinessReport is the body of /health/ready.
+type ReadinessReport struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker runs the readiness checks of the service. Once Drain is called
+// it reports draining, so load balancers stop routing to an instance that
+// is shutting down.
+type Checker struct {
+	checks   []namedCheck
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+func NewChecker() *Checker {
+	return &Checker{timeout: defaultCheckTimeout}
+}
+
+// Register adds a dependency check; call it before serving.
+func (c *Checker) Register(name string, check Check) {
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// Drain makes every later report draining.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Check runs every dependency check concurrently. The report is up only
+// when all of them are and the service is not draining.
+func (c *Checker) Check(ctx context.Context) ReadinessReport {
+	report := ReadinessReport{Status: StatusUp, Dependencies: make(map[string]DependencyStatus, len(c.checks))}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, nc := range c.checks {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+			status := c.run(ctx, nc.check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Dependencies[nc.name] = status
+			if status.Status != StatusUp {
+				report.Status = StatusDown
+			}
+		}(nc)
+	}
+	wg.Wait()
+
+	if c.draining.Load() {
+		report.Status = StatusDraining
+	}
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, check Check) DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	status := DependencyStatus{
+		Status:    StatusUp,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		status.Status = StatusDown
+		status.Error = err.Error()
+	}
+	return status
+}
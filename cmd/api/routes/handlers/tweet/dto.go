@@ -9,9 +9,16 @@ type postTweetResponse struct {
 }
 
 type tweetTimelineResponse struct {
-	ID        string `json:"id"`
-	UserID    string `json:"user_id"`
-	Content   string `json:"content"`
-	CreatedAt string `json:"created_at"`
-	Likes     int    `json:"likes"`
+	ID        string          `json:"id"`
+	UserID    string          `json:"user_id"`
+	Author    *authorResponse `json:"author,omitempty"`
+	Content   string          `json:"content"`
+	CreatedAt string          `json:"created_at"`
+	Likes     int             `json:"likes"`
+	LikedByMe bool            `json:"liked_by_me"`
+}
+
+type authorResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
@@ -3,18 +3,28 @@ package tweet
 import (
 	"context"
 	"encoding/json"
-	"log"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"go.uber.org/zap"
+	"ualaTwitter/internal/platform/auth"
 	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/platform/logger"
 	"ualaTwitter/internal/usecase/get_timeline"
 )
 
 const (
 	defaultLimitValue  = 50
 	defaultOffsetValue = 0
+
+	expandAuthor = "author"
+)
+
+var (
+	ErrInvalidExpand = errors.New("invalid expand: supported values are [author]")
 )
 
 type getTimelineService interface {
@@ -34,38 +44,54 @@ func NewGetTimelineHandler(service getTimelineService) *GetTimelineHandler {
 func (h *GetTimelineHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	input, err := h.parseRequest(r)
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		httphelper.RenderProblem(w, r, http.StatusUnauthorized, ErrMissingUserID)
+		return
+	}
+
+	input, err := h.parseRequest(r, userID)
 	if err != nil {
-		httphelper.RenderError(w, http.StatusBadRequest, err.Error())
+		httphelper.RenderProblem(w, r, http.StatusBadRequest, err)
 		return
 	}
 
 	tweets, err := h.service.Execute(ctx, *input)
 	if err != nil {
-		httphelper.RenderError(w, httphelper.StatusFromError(err), err.Error())
+		httphelper.WriteError(w, r, err)
 		return
 	}
 
-	h.renderResponse(w, tweets)
-}
-
-func (h *GetTimelineHandler) parseRequest(r *http.Request) (*get_timeline.Input, error) {
-	userID := r.Header.Get("X-User-ID")
-	if userID == "" {
-		return nil, ErrMissingUserID
+	// The date misses likes and unfollows; it is only weighed when the
+	// client sends no ETag.
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if httphelper.WriteNotModified(w, r, timelineETag(tweets), newestCreatedAt(tweets)) {
+		return
 	}
 
+	h.renderResponse(r.Context(), w, tweets)
+}
+
+func (h *GetTimelineHandler) parseRequest(r *http.Request, userID string) (*get_timeline.Input, error) {
 	limit := parseQueryInt(r, "limit", defaultLimitValue)
 	offset := parseQueryInt(r, "offset", defaultOffsetValue)
+	mode := r.URL.Query().Get("mode")
+
+	expandAuthor, err := parseExpand(r)
+	if err != nil {
+		return nil, err
+	}
 
 	return &get_timeline.Input{
-		UserID: userID,
-		Limit:  limit,
-		Offset: offset,
+		UserID:       userID,
+		Limit:        limit,
+		Offset:       offset,
+		Mode:         mode,
+		ExpandAuthor: expandAuthor,
 	}, nil
 }
 
-func (h *GetTimelineHandler) renderResponse(w http.ResponseWriter, tweets []get_timeline.TweetTimeline) {
+func (h *GetTimelineHandler) renderResponse(ctx context.Context, w http.ResponseWriter, tweets []get_timeline.TweetTimeline) {
 	w.Header().Set("Content-Type", "application/json")
 
 	response := make([]tweetTimelineResponse, len(tweets))
@@ -75,17 +101,60 @@ func (h *GetTimelineHandler) renderResponse(w http.ResponseWriter, tweets []get_
 			UserID:    t.UserID,
 			Content:   t.Content,
 			Likes:     t.Likes,
+			LikedByMe: t.LikedByMe,
 			CreatedAt: t.CreatedAt.Format(time.RFC3339),
 		}
+		if t.Author != nil {
+			response[i].Author = &authorResponse{ID: t.Author.ID, Name: t.Author.Name}
+		}
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
-		log.Printf("failed to encode timeline response: %v", err)
+		logger.FromContext(ctx).Error("failed to encode timeline response", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 }
 
+// parseExpand reads the comma separated expand query option
+// (e.g. ?expand=author).
+func parseExpand(r *http.Request) (bool, error) {
+	author := false
+	for _, value := range r.URL.Query()["expand"] {
+		for _, field := range strings.Split(value, ",") {
+			switch strings.TrimSpace(field) {
+			case "":
+			case expandAuthor:
+				author = true
+			default:
+				return false, ErrInvalidExpand
+			}
+		}
+	}
+	return author, nil
+}
+
+// timelineETag identifies a page by its tweets, their like counts and the
+// viewer's like state, the only parts of an item that change after it is
+// posted.
+func timelineETag(tweets []get_timeline.TweetTimeline) string {
+	parts := make([]string, len(tweets))
+	for i, t := range tweets {
+		parts[i] = t.ID + ":" + strconv.Itoa(t.Likes) + ":" + strconv.FormatBool(t.LikedByMe)
+	}
+	return httphelper.StrongETag(parts...)
+}
+
+func newestCreatedAt(tweets []get_timeline.TweetTimeline) time.Time {
+	var newest time.Time
+	for _, t := range tweets {
+		if t.CreatedAt.After(newest) {
+			newest = t.CreatedAt
+		}
+	}
+	return newest
+}
+
 func parseQueryInt(r *http.Request, key string, defaultVal int) int {
 	valStr := r.URL.Query().Get(key)
 	if valStr == "" {
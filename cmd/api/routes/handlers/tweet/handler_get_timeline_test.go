@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 	"time"
+	"ualaTwitter/internal/platform/auth"
 	"ualaTwitter/internal/usecase/get_timeline"
 
 	"github.com/stretchr/testify/assert"
@@ -15,11 +16,13 @@ import (
 )
 
 type fakeGetTimelineService struct {
-	Output []get_timeline.TweetTimeline
-	Err    error
+	Output    []get_timeline.TweetTimeline
+	Err       error
+	LastInput get_timeline.Input
 }
 
-func (f *fakeGetTimelineService) Execute(_ context.Context, _ get_timeline.Input) ([]get_timeline.TweetTimeline, error) {
+func (f *fakeGetTimelineService) Execute(_ context.Context, input get_timeline.Input) ([]get_timeline.TweetTimeline, error) {
+	f.LastInput = input
 	return f.Output, f.Err
 }
 
@@ -28,6 +31,7 @@ type TimelineResp struct {
 	UserID    string `json:"user_id"`
 	Content   string `json:"content"`
 	Likes     int    `json:"likes"`
+	LikedByMe bool   `json:"liked_by_me"`
 	CreatedAt string `json:"created_at"`
 }
 
@@ -46,13 +50,14 @@ func TestGetTimelineHandler(t *testing.T) {
 			UserID:    "usr_789",
 			Content:   "Second tweet",
 			Likes:     5,
+			LikedByMe: true,
 			CreatedAt: tweetTime,
 		},
 	}
 
 	tests := []struct {
 		name           string
-		headerUserID   string
+		authUserID     string
 		queryParams    string
 		mockService    *fakeGetTimelineService
 		expectedStatus int
@@ -60,9 +65,9 @@ func TestGetTimelineHandler(t *testing.T) {
 		expectedBody   []TimelineResp
 	}{
 		{
-			name:         "returns timeline successfully",
-			headerUserID: "usr_123",
-			queryParams:  "?limit=2&offset=0",
+			name:        "returns timeline successfully",
+			authUserID:  "usr_123",
+			queryParams: "?limit=2&offset=0",
 			mockService: &fakeGetTimelineService{
 				Output: mockTweets,
 			},
@@ -81,30 +86,31 @@ func TestGetTimelineHandler(t *testing.T) {
 					UserID:    "usr_789",
 					Content:   "Second tweet",
 					Likes:     5,
+					LikedByMe: true,
 					CreatedAt: tweetTime.Format(time.RFC3339),
 				},
 			},
 		},
 		{
-			name:           "missing X-User-ID header",
-			headerUserID:   "",
+			name:           "missing authenticated user",
+			authUserID:     "",
 			queryParams:    "",
 			mockService:    &fakeGetTimelineService{},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnauthorized,
 		},
 		{
-			name:         "use case returns error",
-			headerUserID: "usr_123",
-			queryParams:  "",
+			name:        "use case returns error",
+			authUserID:  "usr_123",
+			queryParams: "",
 			mockService: &fakeGetTimelineService{
 				Err: errors.New("something failed"),
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
 		{
-			name:         "uses default limit and offset when query params are missing",
-			headerUserID: "usr_123",
-			queryParams:  "",
+			name:        "uses default limit and offset when query params are missing",
+			authUserID:  "usr_123",
+			queryParams: "",
 			mockService: &fakeGetTimelineService{
 				Output: mockTweets,
 			},
@@ -123,6 +129,7 @@ func TestGetTimelineHandler(t *testing.T) {
 					UserID:    "usr_789",
 					Content:   "Second tweet",
 					Likes:     5,
+					LikedByMe: true,
 					CreatedAt: tweetTime.Format(time.RFC3339),
 				},
 			},
@@ -132,8 +139,8 @@ func TestGetTimelineHandler(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			req := httptest.NewRequest(http.MethodGet, "/timeline"+tc.queryParams, nil)
-			if tc.headerUserID != "" {
-				req.Header.Set("X-User-ID", tc.headerUserID)
+			if tc.authUserID != "" {
+				req = req.WithContext(auth.WithUserID(req.Context(), tc.authUserID))
 			}
 
 			rr := httptest.NewRecorder()
@@ -151,4 +158,125 @@ func TestGetTimelineHandler(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("passes the requested mode to the service", func(t *testing.T) {
+		service := &fakeGetTimelineService{Output: mockTweets}
+		req := httptest.NewRequest(http.MethodGet, "/timeline?mode=ranked&limit=5", nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), "usr_123"))
+		rr := httptest.NewRecorder()
+
+		NewGetTimelineHandler(service).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, get_timeline.ModeRanked, service.LastInput.Mode)
+		assert.Equal(t, 5, service.LastInput.Limit)
+	})
+
+	t.Run("answers 304 when the page ETag still matches", func(t *testing.T) {
+		service := &fakeGetTimelineService{Output: mockTweets}
+		handler := NewGetTimelineHandler(service)
+
+		first := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/timeline", nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), "usr_123"))
+		handler.ServeHTTP(first, req)
+		require.Equal(t, http.StatusOK, first.Code)
+		etag := first.Header().Get("ETag")
+		require.NotEmpty(t, etag)
+		assert.Equal(t, tweetTime.UTC().Format(http.TimeFormat), first.Header().Get("Last-Modified"))
+
+		second := httptest.NewRecorder()
+		req = httptest.NewRequest(http.MethodGet, "/timeline", nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), "usr_123"))
+		req.Header.Set("If-None-Match", etag)
+		handler.ServeHTTP(second, req)
+
+		assert.Equal(t, http.StatusNotModified, second.Code)
+		assert.Empty(t, second.Body.String())
+	})
+
+	t.Run("ETag changes when the viewer likes a tweet", func(t *testing.T) {
+		liked := make([]get_timeline.TweetTimeline, len(mockTweets))
+		copy(liked, mockTweets)
+		liked[0].Likes++
+		liked[0].LikedByMe = true
+
+		before := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/timeline", nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), "usr_123"))
+		NewGetTimelineHandler(&fakeGetTimelineService{Output: mockTweets}).ServeHTTP(before, req)
+
+		after := httptest.NewRecorder()
+		req = httptest.NewRequest(http.MethodGet, "/timeline", nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), "usr_123"))
+		req.Header.Set("If-None-Match", before.Header().Get("ETag"))
+		NewGetTimelineHandler(&fakeGetTimelineService{Output: liked}).ServeHTTP(after, req)
+
+		assert.Equal(t, http.StatusOK, after.Code)
+		assert.NotEqual(t, before.Header().Get("ETag"), after.Header().Get("ETag"))
+	})
+
+	t.Run("answers 304 when nothing newer than If-Modified-Since", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/timeline", nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), "usr_123"))
+		req.Header.Set("If-Modified-Since", tweetTime.UTC().Format(http.TimeFormat))
+
+		NewGetTimelineHandler(&fakeGetTimelineService{Output: mockTweets}).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotModified, rr.Code)
+	})
+
+	t.Run("a stale ETag wins over a current If-Modified-Since", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/timeline", nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), "usr_123"))
+		req.Header.Set("If-None-Match", `"before-a-like"`)
+		req.Header.Set("If-Modified-Since", tweetTime.UTC().Format(http.TimeFormat))
+
+		NewGetTimelineHandler(&fakeGetTimelineService{Output: mockTweets}).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("expand=author embeds the author of each item", func(t *testing.T) {
+		withAuthors := []get_timeline.TweetTimeline{
+			{ID: "tweet_1", UserID: "usr_456", Content: "Ualá tweeting", CreatedAt: tweetTime, Author: &get_timeline.Author{ID: "usr_456", Name: "Mauricio"}},
+			{ID: "tweet_2", UserID: "usr_789", Content: "Second tweet", CreatedAt: tweetTime},
+		}
+		service := &fakeGetTimelineService{Output: withAuthors}
+		req := httptest.NewRequest(http.MethodGet, "/timeline?expand=author", nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), "usr_123"))
+		rr := httptest.NewRecorder()
+
+		NewGetTimelineHandler(service).ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.True(t, service.LastInput.ExpandAuthor)
+
+		var parsed []map[string]any
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &parsed))
+		assert.Equal(t, map[string]any{"id": "usr_456", "name": "Mauricio"}, parsed[0]["author"])
+		assert.NotContains(t, parsed[1], "author")
+	})
+
+	t.Run("author is not expanded by default", func(t *testing.T) {
+		service := &fakeGetTimelineService{Output: mockTweets}
+		req := httptest.NewRequest(http.MethodGet, "/timeline", nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), "usr_123"))
+
+		NewGetTimelineHandler(service).ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.False(t, service.LastInput.ExpandAuthor)
+	})
+
+	t.Run("unknown expand value is rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/timeline?expand=author,likes", nil)
+		req = req.WithContext(auth.WithUserID(req.Context(), "usr_123"))
+		rr := httptest.NewRecorder()
+
+		NewGetTimelineHandler(&fakeGetTimelineService{}).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/gorilla/mux"
 	"net/http"
+	"ualaTwitter/internal/platform/auth"
 	"ualaTwitter/internal/platform/httphelper"
 	"ualaTwitter/internal/usecase/like_tweet"
 )
@@ -30,26 +31,27 @@ func NewLikeTweetHandler(service likeTweetService) *LikeTweetHandler {
 func (h *LikeTweetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	input, err := h.parseRequest(r)
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		httphelper.RenderProblem(w, r, http.StatusUnauthorized, ErrMissingUserID)
+		return
+	}
+
+	input, err := h.parseRequest(r, userID)
 	if err != nil {
-		httphelper.RenderError(w, http.StatusBadRequest, err.Error())
+		httphelper.RenderProblem(w, r, http.StatusBadRequest, err)
 		return
 	}
 
 	if err := h.service.Execute(ctx, *input); err != nil {
-		httphelper.RenderError(w, httphelper.StatusFromError(err), err.Error())
+		httphelper.WriteError(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func (h *LikeTweetHandler) parseRequest(r *http.Request) (*like_tweet.Input, error) {
-	userID := r.Header.Get("X-User-ID")
-	if userID == "" {
-		return nil, ErrMissingUserID
-	}
-
+func (h *LikeTweetHandler) parseRequest(r *http.Request, userID string) (*like_tweet.Input, error) {
 	vars := mux.Vars(r)
 	tweetID := vars["id"]
 	if tweetID == "" {
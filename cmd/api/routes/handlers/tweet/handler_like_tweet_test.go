@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"ualaTwitter/internal/platform/auth"
 	"ualaTwitter/internal/usecase/like_tweet"
 
 	"github.com/gorilla/mux"
@@ -23,35 +24,35 @@ func (f *fakeLikeTweetService) Execute(_ context.Context, _ like_tweet.Input) er
 func TestLikeTweetHandler(t *testing.T) {
 	tests := []struct {
 		name           string
-		headerUserID   string
+		authUserID     string
 		tweetIDPathVar string
 		mockService    *fakeLikeTweetService
 		expectedStatus int
 	}{
 		{
 			name:           "successfully likes a tweet",
-			headerUserID:   "usr_123",
+			authUserID:     "usr_123",
 			tweetIDPathVar: "tweet_abc",
 			mockService:    &fakeLikeTweetService{},
 			expectedStatus: http.StatusNoContent,
 		},
 		{
-			name:           "missing X-User-ID header",
-			headerUserID:   "",
+			name:           "missing authenticated user",
+			authUserID:     "",
 			tweetIDPathVar: "tweet_abc",
 			mockService:    &fakeLikeTweetService{},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnauthorized,
 		},
 		{
 			name:           "missing tweet ID path param",
-			headerUserID:   "usr_123",
+			authUserID:     "usr_123",
 			tweetIDPathVar: "",
 			mockService:    &fakeLikeTweetService{},
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
 			name:           "use case error",
-			headerUserID:   "usr_123",
+			authUserID:     "usr_123",
 			tweetIDPathVar: "tweet_abc",
 			mockService:    &fakeLikeTweetService{Err: errors.New("already liked")},
 			expectedStatus: http.StatusInternalServerError,
@@ -61,8 +62,8 @@ func TestLikeTweetHandler(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			req := httptest.NewRequest(http.MethodPost, "/tweets/{id}/like", nil)
-			if tc.headerUserID != "" {
-				req.Header.Set("X-User-ID", tc.headerUserID)
+			if tc.authUserID != "" {
+				req = req.WithContext(auth.WithUserID(req.Context(), tc.authUserID))
 			}
 
 			req = mux.SetURLVars(req, map[string]string{"id": tc.tweetIDPathVar})
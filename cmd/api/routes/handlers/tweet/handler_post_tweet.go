@@ -4,17 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log"
 	"net/http"
 
+	"go.uber.org/zap"
+	"ualaTwitter/internal/platform/auth"
 	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/platform/logger"
 	"ualaTwitter/internal/usecase/post_tweet"
 )
 
 const maxPostTweetBodySize = 4 * 1024
 
 var (
-	ErrMissingUserID = errors.New("missing X-User-ID header")
+	ErrMissingUserID = errors.New("missing authenticated user")
 	ErrInvalidBody   = errors.New("invalid request body")
 	ErrEmptyTweet    = errors.New("tweet content cannot be empty")
 )
@@ -36,27 +38,28 @@ func NewPostTweetHandler(service postTweetService) *PostTweetHandler {
 func (h *PostTweetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	input, err := h.parseRequest(r)
+	userID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		httphelper.RenderProblem(w, r, http.StatusUnauthorized, ErrMissingUserID)
+		return
+	}
+
+	input, err := h.parseRequest(r, userID)
 	if err != nil {
-		httphelper.RenderError(w, http.StatusBadRequest, err.Error())
+		httphelper.RenderProblem(w, r, http.StatusBadRequest, err)
 		return
 	}
 
 	tweetID, err := h.service.Execute(ctx, *input)
 	if err != nil {
-		httphelper.RenderError(w, httphelper.StatusFromError(err), err.Error())
+		httphelper.WriteError(w, r, err)
 		return
 	}
 
-	h.renderResponse(w, tweetID)
+	h.renderResponse(r.Context(), w, tweetID)
 }
 
-func (h *PostTweetHandler) parseRequest(r *http.Request) (*post_tweet.Input, error) {
-	userID := r.Header.Get("X-User-ID")
-	if userID == "" {
-		return nil, ErrMissingUserID
-	}
-
+func (h *PostTweetHandler) parseRequest(r *http.Request, userID string) (*post_tweet.Input, error) {
 	r.Body = http.MaxBytesReader(nil, r.Body, maxPostTweetBodySize)
 
 	var req postTweetRequest
@@ -77,11 +80,11 @@ func (h *PostTweetHandler) parseRequest(r *http.Request) (*post_tweet.Input, err
 	}, nil
 }
 
-func (h *PostTweetHandler) renderResponse(w http.ResponseWriter, tweetID string) {
+func (h *PostTweetHandler) renderResponse(ctx context.Context, w http.ResponseWriter, tweetID string) {
 	w.Header().Set("Content-Type", "application/json")
 
 	if err := json.NewEncoder(w).Encode(postTweetResponse{ID: tweetID}); err != nil {
-		log.Printf("failed to encode post tweet response: %v", err)
+		logger.FromContext(ctx).Error("failed to encode post tweet response", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
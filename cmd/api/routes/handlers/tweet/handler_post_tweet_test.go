@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"ualaTwitter/internal/platform/auth"
 	"ualaTwitter/internal/usecase/post_tweet"
 
 	"github.com/stretchr/testify/assert"
@@ -26,15 +27,15 @@ func (f *fakePostTweetService) Execute(_ context.Context, _ post_tweet.Input) (s
 func TestPostTweetHandler(t *testing.T) {
 	tests := []struct {
 		name           string
-		header         string
+		authUserID     string
 		body           map[string]string
 		mockService    *fakePostTweetService
 		expectedStatus int
 		expectedBody   string
 	}{
 		{
-			name:   "successfully posts tweet",
-			header: "usr_123",
+			name:       "successfully posts tweet",
+			authUserID: "usr_123",
 			body: map[string]string{
 				"content": "Hello Twitter!",
 			},
@@ -45,22 +46,22 @@ func TestPostTweetHandler(t *testing.T) {
 			expectedBody:   `{"id":"tweet_001"}`,
 		},
 		{
-			name:           "missing X-User-ID",
-			header:         "",
+			name:           "missing authenticated user",
+			authUserID:     "",
 			body:           map[string]string{"content": "Hello"},
 			mockService:    &fakePostTweetService{},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnauthorized,
 		},
 		{
 			name:           "empty request body",
-			header:         "usr_123",
+			authUserID:     "usr_123",
 			body:           nil,
 			mockService:    &fakePostTweetService{},
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name:   "empty tweet content",
-			header: "usr_123",
+			name:       "empty tweet content",
+			authUserID: "usr_123",
 			body: map[string]string{
 				"content": "",
 			},
@@ -68,8 +69,8 @@ func TestPostTweetHandler(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name:   "use case returns error",
-			header: "usr_123",
+			name:       "use case returns error",
+			authUserID: "usr_123",
 			body: map[string]string{
 				"content": "Something real",
 			},
@@ -88,8 +89,8 @@ func TestPostTweetHandler(t *testing.T) {
 			}
 
 			req := httptest.NewRequest(http.MethodPost, "/tweets", bytes.NewReader(bodyBytes))
-			if tc.header != "" {
-				req.Header.Set("X-User-ID", tc.header)
+			if tc.authUserID != "" {
+				req = req.WithContext(auth.WithUserID(req.Context(), tc.authUserID))
 			}
 
 			rr := httptest.NewRecorder()
@@ -0,0 +1,14 @@
+package tweet
+
+import "ualaTwitter/internal/platform/openapi"
+
+// Schemas describes the package DTOs for the OpenAPI document, keyed by
+// the exported form of the DTO type name.
+func Schemas() map[string]*openapi.Schema {
+	return map[string]*openapi.Schema{
+		"PostTweetRequest":      openapi.SchemaOf(postTweetRequest{}),
+		"PostTweetResponse":     openapi.SchemaOf(postTweetResponse{}),
+		"TweetTimelineResponse": openapi.SchemaOf(tweetTimelineResponse{}),
+		"AuthorResponse":        openapi.SchemaOf(authorResponse{}),
+	}
+}
@@ -7,6 +7,7 @@ type followUserRequest struct {
 type createUserRequest struct {
 	Name     string `json:"name"`
 	Document string `json:"document"`
+	Password string `json:"password"`
 }
 
 type createUserResponse struct {
@@ -4,12 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log"
 	"net/http"
 	"regexp"
 	"strings"
 
+	"go.uber.org/zap"
 	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/platform/logger"
 	"ualaTwitter/internal/usecase/create_user"
 )
 
@@ -19,6 +20,7 @@ var (
 	ErrInvalidCreateUserBody = errors.New("invalid request body")
 	ErrEmptyUserName         = errors.New("user name cannot be empty")
 	ErrInvalidDoc            = errors.New("invalid document: must be a of 7 or 8 digits")
+	ErrEmptyPassword         = errors.New("password cannot be empty")
 	docRegex                 = regexp.MustCompile(`^\d{7,8}$`)
 )
 
@@ -43,17 +45,17 @@ func (h *CreateUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	input, err := h.parseRequest(r)
 	if err != nil {
-		httphelper.RenderError(w, http.StatusBadRequest, err.Error())
+		httphelper.RenderProblem(w, r, http.StatusBadRequest, err)
 		return
 	}
 
 	output, err := h.service.Execute(ctx, *input)
 	if err != nil {
-		httphelper.RenderError(w, httphelper.StatusFromError(err), err.Error())
+		httphelper.WriteError(w, r, err)
 		return
 	}
 
-	h.renderResponse(w, output.ID)
+	h.renderResponse(r.Context(), w, output.ID)
 
 }
 
@@ -75,17 +77,22 @@ func (h *CreateUserHandler) parseRequest(r *http.Request) (*create_user.Input, e
 		return nil, err
 	}
 
+	if req.Password == "" {
+		return nil, ErrEmptyPassword
+	}
+
 	return &create_user.Input{
 		Name:     req.Name,
 		Document: doc,
+		Password: req.Password,
 	}, nil
 }
 
-func (h *CreateUserHandler) renderResponse(w http.ResponseWriter, userID string) {
+func (h *CreateUserHandler) renderResponse(ctx context.Context, w http.ResponseWriter, userID string) {
 	w.Header().Set("Content-Type", "application/json")
 
 	if err := json.NewEncoder(w).Encode(createUserResponse{ID: userID}); err != nil {
-		log.Printf("failed to encode post tweet response: %v", err)
+		logger.FromContext(ctx).Error("failed to encode create user response", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
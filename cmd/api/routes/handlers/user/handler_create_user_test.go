@@ -39,6 +39,7 @@ func TestCreateUserHandler(t *testing.T) {
 			body: map[string]string{
 				"name":     "Mauricio",
 				"document": "12345678",
+				"password": "correcthorse42",
 			},
 			mockService: &fakeCreateUserService{
 				Output: create_user.Output{ID: "usr_12345678"},
@@ -57,6 +58,7 @@ func TestCreateUserHandler(t *testing.T) {
 			body: map[string]string{
 				"name":     "Mauricio",
 				"document": "abc123",
+				"password": "correcthorse42",
 			},
 			mockService:    &fakeCreateUserService{},
 			expectedStatus: http.StatusBadRequest,
@@ -66,6 +68,16 @@ func TestCreateUserHandler(t *testing.T) {
 			body: map[string]string{
 				"name":     "",
 				"document": "12345678",
+				"password": "correcthorse42",
+			},
+			mockService:    &fakeCreateUserService{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "missing password",
+			body: map[string]string{
+				"name":     "Mauricio",
+				"document": "12345678",
 			},
 			mockService:    &fakeCreateUserService{},
 			expectedStatus: http.StatusBadRequest,
@@ -75,6 +87,7 @@ func TestCreateUserHandler(t *testing.T) {
 			body: map[string]string{
 				"name":     "Mauricio",
 				"document": "12345678",
+				"password": "correcthorse42",
 			},
 			mockService: &fakeCreateUserService{
 				Err: errors.New("something went wrong"),
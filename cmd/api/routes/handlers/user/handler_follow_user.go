@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 
+	"ualaTwitter/internal/platform/auth"
 	"ualaTwitter/internal/platform/httphelper"
 	"ualaTwitter/internal/usecase/follow_user"
 )
@@ -13,7 +14,7 @@ import (
 const maxFollowBodySize = 4 * 1024 // 4 KB
 
 var (
-	ErrMissingUserID   = errors.New("missing X-User-ID header")
+	ErrMissingUserID   = errors.New("missing authenticated user")
 	ErrInvalidBody     = errors.New("invalid request body")
 	ErrEmptyFolloweeID = errors.New("followee_id cannot be empty")
 )
@@ -37,27 +38,27 @@ func (h *FollowUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	r.Body = http.MaxBytesReader(w, r.Body, maxFollowBodySize)
 
-	input, err := h.parseRequest(r)
+	followerID, ok := auth.UserIDFromContext(ctx)
+	if !ok {
+		httphelper.RenderProblem(w, r, http.StatusUnauthorized, ErrMissingUserID)
+		return
+	}
+
+	input, err := h.parseRequest(r, followerID)
 	if err != nil {
-		httphelper.RenderError(w, http.StatusBadRequest, err.Error())
+		httphelper.RenderProblem(w, r, http.StatusBadRequest, err)
 		return
 	}
 
 	if err := h.service.Execute(ctx, *input); err != nil {
-		httphelper.RenderError(w, httphelper.StatusFromError(err), err.Error())
+		httphelper.WriteError(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
-func (h *FollowUserHandler) parseRequest(r *http.Request) (*follow_user.Input, error) {
-	followerID := r.Header.Get("X-User-ID")
-
-	if followerID == "" {
-		return nil, ErrMissingUserID
-	}
-
+func (h *FollowUserHandler) parseRequest(r *http.Request, followerID string) (*follow_user.Input, error) {
 	var req followUserRequest
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
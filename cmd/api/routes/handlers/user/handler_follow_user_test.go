@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"ualaTwitter/internal/platform/auth"
 	"ualaTwitter/internal/usecase/follow_user"
 )
 
@@ -23,14 +24,14 @@ func (f *fakeFollowUserService) Execute(_ context.Context, _ follow_user.Input)
 func TestFollowUserHandler(t *testing.T) {
 	tests := []struct {
 		name           string
-		header         string
+		authUserID     string
 		body           map[string]string
 		mockService    *fakeFollowUserService
 		expectedStatus int
 	}{
 		{
-			name:   "successfully follows a user",
-			header: "usr_123",
+			name:       "successfully follows a user",
+			authUserID: "usr_123",
 			body: map[string]string{
 				"followee_id": "usr_456",
 			},
@@ -38,32 +39,32 @@ func TestFollowUserHandler(t *testing.T) {
 			expectedStatus: http.StatusNoContent,
 		},
 		{
-			name:           "missing X-User-ID header",
-			header:         "",
+			name:           "missing authenticated user",
+			authUserID:     "",
 			body:           map[string]string{"followee_id": "usr_456"},
 			mockService:    &fakeFollowUserService{},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnauthorized,
 		},
 		{
 			name:           "empty body",
-			header:         "usr_123",
+			authUserID:     "usr_123",
 			body:           nil,
 			mockService:    &fakeFollowUserService{},
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name:   "missing followee_id",
-			header: "usr_123",
-			body:   map[string]string{"followee_id": ""},
+			name:       "missing followee_id",
+			authUserID: "usr_123",
+			body:       map[string]string{"followee_id": ""},
 			mockService: &fakeFollowUserService{
 				Err: nil,
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name:   "use case returns error",
-			header: "usr_123",
-			body:   map[string]string{"followee_id": "usr_456"},
+			name:       "use case returns error",
+			authUserID: "usr_123",
+			body:       map[string]string{"followee_id": "usr_456"},
 			mockService: &fakeFollowUserService{
 				Err: errors.New("already following user"),
 			},
@@ -79,8 +80,8 @@ func TestFollowUserHandler(t *testing.T) {
 			}
 
 			req := httptest.NewRequest(http.MethodPost, "/follow", bytes.NewReader(bodyBytes))
-			if tc.header != "" {
-				req.Header.Set("X-User-ID", tc.header)
+			if tc.authUserID != "" {
+				req = req.WithContext(auth.WithUserID(req.Context(), tc.authUserID))
 			}
 
 			rr := httptest.NewRecorder()
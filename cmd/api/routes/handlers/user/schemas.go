@@ -0,0 +1,13 @@
+package user
+
+import "ualaTwitter/internal/platform/openapi"
+
+// Schemas describes the package DTOs for the OpenAPI document, keyed by
+// the exported form of the DTO type name.
+func Schemas() map[string]*openapi.Schema {
+	return map[string]*openapi.Schema{
+		"FollowUserRequest":  openapi.SchemaOf(followUserRequest{}),
+		"CreateUserRequest":  openapi.SchemaOf(createUserRequest{}),
+		"CreateUserResponse": openapi.SchemaOf(createUserResponse{}),
+	}
+}
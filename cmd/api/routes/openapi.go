@@ -0,0 +1,281 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	adminhandler "ualaTwitter/cmd/api/routes/handlers/admin"
+	authhandler "ualaTwitter/cmd/api/routes/handlers/auth"
+	graphhandler "ualaTwitter/cmd/api/routes/handlers/graph"
+	"ualaTwitter/cmd/api/routes/handlers/health"
+	tweethandler "ualaTwitter/cmd/api/routes/handlers/tweet"
+	userhandler "ualaTwitter/cmd/api/routes/handlers/user"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/platform/idempotency"
+	"ualaTwitter/internal/platform/openapi"
+	"ualaTwitter/internal/usecase/get_timeline"
+)
+
+// APIVersion is the version of the HTTP contract described by the
+// OpenAPI document, not of the binary.
+const APIVersion = "1.0.0"
+
+const (
+	bearerScheme = "bearerAuth"
+	apiKeyScheme = "apiKeyAuth"
+	adminScheme  = "adminToken"
+)
+
+var (
+	stringSchema  = &openapi.Schema{Type: "string"}
+	integerSchema = &openapi.Schema{Type: "integer"}
+
+	noStoreHeader = map[string]*openapi.Header{
+		"Cache-Control": {Description: "Always no-store, the body holds a secret.", Schema: stringSchema},
+	}
+	conditionalHeaders = map[string]*openapi.Header{
+		"ETag":          {Description: "Strong validator for If-None-Match.", Schema: stringSchema},
+		"Last-Modified": {Description: "Creation time of the newest tweet on the page.", Schema: stringSchema},
+	}
+	deprecationHeaders = map[string]*openapi.Header{
+		"Deprecation": {Description: "When the route was deprecated, as @<unix seconds>.", Schema: stringSchema},
+		"Sunset":      {Description: "When the route will be removed.", Schema: stringSchema},
+		"Link":        {Description: "The successor-version of the route.", Schema: stringSchema},
+	}
+	rateLimitHeaders = map[string]*openapi.Header{
+		"X-RateLimit-Limit":     {Description: "Bucket size.", Schema: integerSchema},
+		"X-RateLimit-Remaining": {Description: "Requests left in the bucket.", Schema: integerSchema},
+		"X-RateLimit-Reset":     {Description: "Seconds until the bucket is full again.", Schema: integerSchema},
+	}
+
+	timelineParams = []openapi.Parameter{
+		{Name: "limit", In: "query", Description: "Page size, 50 by default.", Schema: &openapi.Schema{Type: "integer", Minimum: intPtr(0)}},
+		{Name: "offset", In: "query", Description: "Items to skip.", Schema: &openapi.Schema{Type: "integer", Minimum: intPtr(0)}},
+		{Name: "mode", In: "query", Description: "Ordering of the page; chronological by default, or ranked for users in the ranked_timeline feature flag rollout.", Schema: &openapi.Schema{Type: "string", Enum: []string{get_timeline.ModeChronological, get_timeline.ModeRanked}}},
+		{Name: "expand", In: "query", Description: "Comma separated related resources to embed.", Schema: &openapi.Schema{Type: "string", Enum: []string{"author"}}},
+		{Name: "If-None-Match", In: "header", Schema: stringSchema},
+		{Name: "If-Modified-Since", In: "header", Schema: stringSchema},
+	}
+	featureFlagUserParam = openapi.Parameter{
+		Name: "user_id", In: "query",
+		Description: "Also reports whether each flag is on for this user.",
+		Schema:      stringSchema,
+	}
+	idempotencyKeyParam = openapi.Parameter{
+		Name: idempotency.HeaderKey, In: "header",
+		Description: "Makes retries safe: the first response for a key is replayed (24h by default).",
+		Schema:      stringSchema,
+	}
+	requestIDParam = openapi.Parameter{
+		Name: httphelper.RequestIDHeader, In: "header",
+		Description: "Propagated to the response and error bodies; generated when absent.",
+		Schema:      stringSchema,
+	}
+)
+
+var (
+	specOnce sync.Once
+	specJSON []byte
+)
+
+// Spec returns the OpenAPI document for the route table.
+func Spec() *openapi.Document {
+	return buildSpec(routeTable(Handlers{}))
+}
+
+func serveOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	specOnce.Do(func() {
+		specJSON, _ = json.Marshal(Spec())
+	})
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(specJSON)
+}
+
+func buildSpec(table []route) *openapi.Document {
+	doc := &openapi.Document{
+		OpenAPI: openapi.Version,
+		Info: openapi.Info{
+			Title:       "Ualá Twitter API",
+			Version:     APIVersion,
+			Description: "Errors are application/problem+json documents whose code member is stable.",
+		},
+		Paths: map[string]*openapi.PathItem{},
+		Components: openapi.Components{
+			Schemas: componentSchemas(),
+			SecuritySchemes: map[string]*openapi.SecurityScheme{
+				bearerScheme: {Type: "http", Scheme: "bearer", BearerFormat: "JWT", Description: "Access token from POST /auth/token."},
+				apiKeyScheme: {Type: "apiKey", In: "header", Name: auth.APIKeyHeader, Description: "API key; only valid on routes its scopes cover."},
+				adminScheme:  {Type: "apiKey", In: "header", Name: auth.AdminTokenHeader, Description: "Operator token (ADMIN_TOKEN) for /admin routes."},
+			},
+		},
+	}
+
+	for _, rt := range table {
+		item, ok := doc.Paths[rt.path]
+		if !ok {
+			item = &openapi.PathItem{}
+			doc.Paths[rt.path] = item
+		}
+		(*item)[strings.ToLower(rt.method)] = operationFor(rt)
+	}
+	return doc
+}
+
+func operationFor(rt route) *openapi.Operation {
+	op := &openapi.Operation{
+		OperationID: rt.doc.id,
+		Summary:     rt.doc.summary,
+		Tags:        []string{rt.doc.tag},
+		Deprecated:  rt.deprecated,
+		Parameters:  append([]openapi.Parameter{requestIDParam}, rt.doc.params...),
+		Responses:   map[string]*openapi.Response{},
+	}
+
+	errs := append([]int{}, rt.doc.errors...)
+	switch rt.access {
+	case accessAuthenticated:
+		op.Security = []openapi.SecurityRequirement{{bearerScheme: {}}, {apiKeyScheme: {rt.scope}}}
+		errs = append(errs, http.StatusUnauthorized, http.StatusForbidden)
+	case accessSession:
+		op.Security = []openapi.SecurityRequirement{{bearerScheme: {}}}
+		errs = append(errs, http.StatusUnauthorized, http.StatusForbidden)
+	case accessAdmin:
+		op.Security = []openapi.SecurityRequirement{{adminScheme: {}}}
+		errs = append(errs, http.StatusUnauthorized, http.StatusForbidden)
+	}
+	switch {
+	case rt.doc.request != "":
+		op.RequestBody = &openapi.RequestBody{Required: true, Content: openapi.JSON(openapi.Ref(rt.doc.request))}
+		errs = append(errs, http.StatusBadRequest)
+	case rt.doc.requestMedia != "":
+		op.RequestBody = &openapi.RequestBody{Required: true, Content: openapi.Content(rt.doc.requestMedia, stringSchema)}
+		errs = append(errs, http.StatusBadRequest)
+	}
+	if rt.idempotent {
+		op.Parameters = append(op.Parameters, idempotencyKeyParam)
+		errs = append(errs, http.StatusBadRequest, http.StatusConflict, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity, http.StatusServiceUnavailable)
+	}
+	if rt.limit != "" {
+		errs = append(errs, http.StatusTooManyRequests)
+	}
+	errs = append(errs, http.StatusInternalServerError)
+
+	success := &openapi.Response{Description: http.StatusText(rt.doc.status), Headers: rt.doc.headers}
+	switch {
+	case rt.doc.array:
+		success.Content = openapi.JSON(&openapi.Schema{Type: "array", Items: openapi.Ref(rt.doc.response)})
+	case rt.doc.response != "":
+		success.Content = openapi.JSON(openapi.Ref(rt.doc.response))
+	case rt.doc.responseMedia != "":
+		success.Content = openapi.Content(rt.doc.responseMedia, stringSchema)
+	}
+	if rt.limit != "" {
+		success.Headers = mergeHeaders(success.Headers, rateLimitHeaders)
+	}
+	if rt.deprecated {
+		success.Headers = mergeHeaders(success.Headers, deprecationHeaders)
+	}
+	op.Responses[strconv.Itoa(rt.doc.status)] = success
+	if rt.doc.failure != 0 {
+		op.Responses[strconv.Itoa(rt.doc.failure)] = &openapi.Response{
+			Description: http.StatusText(rt.doc.failure),
+			Content:     success.Content,
+		}
+	}
+
+	for _, status := range errs {
+		key := strconv.Itoa(status)
+		if _, ok := op.Responses[key]; ok {
+			continue
+		}
+		resp := &openapi.Response{Description: http.StatusText(status)}
+		if status != http.StatusNotModified {
+			resp.Content = openapi.Content(httphelper.ProblemContentType, openapi.Ref("Problem"))
+		}
+		if status == http.StatusTooManyRequests {
+			resp.Headers = mergeHeaders(rateLimitHeaders, map[string]*openapi.Header{
+				"Retry-After": {Description: "Seconds until a request will be accepted.", Schema: integerSchema},
+			})
+		}
+		if status == http.StatusServiceUnavailable {
+			resp.Headers = map[string]*openapi.Header{
+				"Retry-After": {Description: "Seconds until the request may be retried.", Schema: integerSchema},
+			}
+		}
+		op.Responses[key] = resp
+	}
+	return op
+}
+
+func componentSchemas() map[string]*openapi.Schema {
+	schemas := map[string]*openapi.Schema{
+		"ServiceInfo":     openapi.SchemaOf(health.ServiceInfo{}),
+		"LiveStatus":      openapi.SchemaOf(health.LiveStatus{}),
+		"ReadinessReport": readinessSchema(),
+		"Problem":         problemSchema(),
+		"OpenAPIDocument": {Type: "object", Description: "An OpenAPI 3.1 document."},
+	}
+	for _, set := range []map[string]*openapi.Schema{
+		tweethandler.Schemas(),
+		userhandler.Schemas(),
+		authhandler.Schemas(),
+		graphhandler.Schemas(),
+		adminhandler.Schemas(),
+	} {
+		for name, schema := range set {
+			schemas[name] = schema
+		}
+	}
+	return schemas
+}
+
+// problemSchema lists the registered error codes as examples of the code
+// member; unregistered errors fall back to a snake_case status text.
+func readinessSchema() *openapi.Schema {
+	schema := openapi.SchemaOf(health.ReadinessReport{})
+	schema.Properties["status"].Enum = []string{health.StatusUp, health.StatusDown, health.StatusDraining}
+	schema.Properties["dependencies"].Description = "Status, latency and error of each dependency, by name (" +
+		"postgres, postgres_schema, timeline_cache)."
+	return schema
+}
+
+func problemSchema() *openapi.Schema {
+	schema := openapi.SchemaOf(httphelper.Problem{})
+
+	seen := map[string]bool{}
+	var codes []string
+	for _, entry := range errorCodes {
+		if !seen[entry.Code] {
+			seen[entry.Code] = true
+			codes = append(codes, entry.Code)
+		}
+	}
+	sort.Strings(codes)
+
+	schema.Properties["code"].Description = "Stable machine readable error code."
+	schema.Properties["code"].Examples = codes
+	return schema
+}
+
+func mergeHeaders(sets ...map[string]*openapi.Header) map[string]*openapi.Header {
+	merged := map[string]*openapi.Header{}
+	for _, set := range sets {
+		for name, header := range set {
+			merged[name] = header
+		}
+	}
+	return merged
+}
+
+func pathID(description string) openapi.Parameter {
+	return openapi.Parameter{Name: "id", In: "path", Required: true, Description: description, Schema: stringSchema}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
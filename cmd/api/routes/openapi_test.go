@@ -0,0 +1,196 @@
+package routes
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"ualaTwitter/internal/platform/openapi"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOpenAPI_CoversRegisteredRoutes walks the real router, so a route
+// mounted outside the route table fails here.
+func TestOpenAPI_CoversRegisteredRoutes(t *testing.T) {
+	spec := Spec()
+	registered := 0
+
+	err := newTestRouter().Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil // subrouters have no template of their own
+		}
+		methods, err := route.GetMethods()
+		require.NoError(t, err, path)
+
+		for _, method := range methods {
+			registered++
+			item, ok := spec.Paths[path]
+			if assert.True(t, ok, "path %s is not documented", path) {
+				assert.Contains(t, *item, strings.ToLower(method), "%s %s is not documented", method, path)
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	documented := 0
+	for _, item := range spec.Paths {
+		documented += len(*item)
+	}
+	assert.Equal(t, registered, documented, "documented operations that are not registered")
+}
+
+// TestOpenAPI_CoversDTOs parses every handlers/*/dto.go, so adding a DTO
+// or a field without describing it fails here.
+func TestOpenAPI_CoversDTOs(t *testing.T) {
+	spec := Spec()
+
+	files, err := filepath.Glob(filepath.Join("handlers", "*", "dto.go"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		for typeName, fields := range dtoFields(t, file) {
+			schema, ok := schemaFor(spec, typeName)
+			if !assert.True(t, ok, "%s: DTO %s has no schema", file, typeName) {
+				continue
+			}
+			for _, field := range fields {
+				assert.Contains(t, schema.Properties, field, "%s: %s.%s is not documented", file, typeName, field)
+			}
+		}
+	}
+}
+
+func TestOpenAPI_References(t *testing.T) {
+	spec := Spec()
+	raw, err := json.Marshal(spec)
+	require.NoError(t, err)
+
+	var refs []string
+	collectRefs(t, raw, &refs)
+	require.NotEmpty(t, refs)
+
+	for _, ref := range refs {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		assert.Contains(t, spec.Components.Schemas, name, "dangling reference %s", ref)
+	}
+}
+
+func TestOpenAPI_Operations(t *testing.T) {
+	spec := Spec()
+	ids := map[string]bool{}
+
+	for path, item := range spec.Paths {
+		for method, op := range *item {
+			assert.NotEmpty(t, op.OperationID, "%s %s", method, path)
+			assert.False(t, ids[op.OperationID], "duplicate operationId %s", op.OperationID)
+			ids[op.OperationID] = true
+			assert.Contains(t, op.Responses, "500", "%s %s", method, path)
+		}
+	}
+
+	postTweet := (*spec.Paths["/v1/tweets"])["post"]
+	assert.Equal(t, []openapi.SecurityRequirement{{bearerScheme: {}}, {apiKeyScheme: {"tweets:write"}}}, postTweet.Security)
+	assert.Contains(t, postTweet.Responses, "429")
+	assert.Contains(t, postTweet.Responses, "422")
+
+	assert.False(t, postTweet.Deprecated)
+	assert.True(t, (*spec.Paths["/tweets"])["post"].Deprecated)
+
+	health := (*spec.Paths["/health"])["get"]
+	assert.Empty(t, health.Security)
+}
+
+func TestServeOpenAPI(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	newTestRouter().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var doc openapi.Document
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &doc))
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+	assert.Contains(t, doc.Paths, "/v1/timeline")
+	assert.Contains(t, doc.Paths, "/timeline")
+}
+
+// dtoFields returns the json field names of every struct declared in file.
+func dtoFields(t *testing.T, file string) map[string][]string {
+	t.Helper()
+	parsed, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	require.NoError(t, err)
+
+	out := map[string][]string{}
+	ast.Inspect(parsed, func(n ast.Node) bool {
+		spec, ok := n.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		st, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return false
+		}
+		fields := []string{}
+		for _, field := range st.Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+			tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Get("json")
+			if name, _, _ := strings.Cut(tag, ","); name != "" && name != "-" {
+				fields = append(fields, name)
+			}
+		}
+		out[spec.Name.Name] = fields
+		return false
+	})
+	return out
+}
+
+// schemaFor finds the component named after a DTO type, ignoring case so
+// apiKeyResponse matches APIKeyResponse.
+func schemaFor(spec *openapi.Document, typeName string) (*openapi.Schema, bool) {
+	for name, schema := range spec.Components.Schemas {
+		if strings.EqualFold(name, typeName) {
+			return schema, true
+		}
+	}
+	return nil, false
+}
+
+func collectRefs(t *testing.T, raw json.RawMessage, refs *[]string) {
+	t.Helper()
+	var node any
+	require.NoError(t, json.Unmarshal(raw, &node))
+
+	var walk func(any)
+	walk = func(v any) {
+		switch v := v.(type) {
+		case map[string]any:
+			if ref, ok := v["$ref"].(string); ok {
+				*refs = append(*refs, ref)
+			}
+			for _, child := range v {
+				walk(child)
+			}
+		case []any:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(node)
+}
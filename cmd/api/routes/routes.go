@@ -2,15 +2,465 @@ package routes
 
 import (
 	"github.com/gorilla/mux"
+	"go.uber.org/zap"
 	"net/http"
+	"strings"
+	adminhandler "ualaTwitter/cmd/api/routes/handlers/admin"
+	"ualaTwitter/internal/domain/apikey"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/platform/openapi"
+	"ualaTwitter/internal/platform/ratelimit"
 )
 
+// access says who may call a route.
+type access int
+
+const (
+	// accessPublic routes need no credentials.
+	accessPublic access = iota
+	// accessAuthenticated routes accept user sessions and API keys holding
+	// the route scope.
+	accessAuthenticated
+	// accessSession routes accept user sessions only.
+	accessSession
+	// accessAdmin routes accept the operator token only.
+	accessAdmin
+)
+
+// route is one entry of the route table. RegisterRoutes mounts it and the
+// OpenAPI document describes it, so both always agree.
+type route struct {
+	method     string
+	path       string
+	handler    http.HandlerFunc
+	access     access
+	scope      string
+	limit      string
+	idempotent bool
+	// deprecated marks an unprefixed alias of a versioned route.
+	deprecated bool
+	// versionedOnly routes were added after versioning, so no client
+	// needs an unprefixed alias.
+	versionedOnly bool
+	// endpoint names the operation across the paths it is served on, so a
+	// versioned route and its alias share idempotency keys. routeTable
+	// sets it.
+	endpoint string
+	doc      operationDoc
+}
+
+// apiVersion is a set of routes mounted under a path prefix.
+type apiVersion struct {
+	name   string
+	prefix string
+	routes []route
+}
+
+// legacyVersion is the version whose routes are also served, deprecated,
+// without a prefix for clients predating versioning.
+const legacyVersion = "v1"
+
+// operationDoc is the part of the OpenAPI operation that cannot be
+// derived from the route itself. Schema names refer to components.
+type operationDoc struct {
+	id       string
+	summary  string
+	tag      string
+	params   []openapi.Parameter
+	request  string
+	status   int
+	response string
+	// array marks a response that is a list of response schemas.
+	array bool
+	// headers are response headers documented on success.
+	headers map[string]*openapi.Header
+	// errors are statuses specific to the route; the ones implied by
+	// access, limits and idempotency are added automatically.
+	errors []int
+	// failure is a status answered with the success schema rather than a
+	// problem document, like 503 from the readiness probe.
+	failure int
+	// requestMedia and responseMedia are the media types of bodies that
+	// are not JSON, like datasets; they are documented as strings.
+	requestMedia  string
+	responseMedia string
+}
+
+func apiVersions(h Handlers) []apiVersion {
+	return []apiVersion{
+		{name: "v1", prefix: "/v1", routes: v1Routes(h.V1)},
+	}
+}
+
+// unversionedRoutes are served at the root only.
+func unversionedRoutes(h Handlers) []route {
+	return []route{
+		{
+			method: http.MethodGet, path: "/health", handler: h.Health,
+			doc: operationDoc{
+				id: "health", summary: "Service metadata", tag: "health",
+				status: http.StatusOK, response: "ServiceInfo",
+			},
+		},
+		{
+			method: http.MethodGet, path: "/health/live", handler: h.Live,
+			doc: operationDoc{
+				id: "liveness", summary: "Liveness probe; checks no dependency", tag: "health",
+				status: http.StatusOK, response: "LiveStatus",
+			},
+		},
+		{
+			method: http.MethodGet, path: "/health/ready", handler: h.Ready,
+			doc: operationDoc{
+				id: "readiness", summary: "Readiness probe with the status and latency of each dependency", tag: "health",
+				status: http.StatusOK, response: "ReadinessReport",
+				failure: http.StatusServiceUnavailable,
+			},
+		},
+		{
+			method: http.MethodGet, path: "/openapi.json", handler: serveOpenAPI,
+			doc: operationDoc{
+				id: "openAPI", summary: "This OpenAPI document", tag: "meta",
+				status: http.StatusOK, response: "OpenAPIDocument",
+			},
+		},
+		{
+			method: http.MethodGet, path: "/metrics", handler: h.Metrics,
+			access: accessAdmin,
+			doc: operationDoc{
+				id: "metrics", summary: "Prometheus metrics in the text exposition format", tag: "meta",
+				status: http.StatusOK,
+			},
+		},
+		{
+			method: http.MethodGet, path: "/admin/log-level", handler: h.Admin.GetLogLevel,
+			access: accessAdmin,
+			doc: operationDoc{
+				id: "getLogLevel", summary: "Current minimum log level", tag: "admin",
+				status: http.StatusOK, response: "LogLevelResponse",
+			},
+		},
+		{
+			method: http.MethodPut, path: "/admin/log-level", handler: h.Admin.SetLogLevel,
+			access: accessAdmin,
+			doc: operationDoc{
+				id: "setLogLevel", summary: "Change the minimum log level until the next restart", tag: "admin",
+				request: "LogLevelRequest", status: http.StatusOK, response: "LogLevelResponse",
+			},
+		},
+		{
+			method: http.MethodGet, path: "/admin/feature-flags", handler: h.Admin.ListFeatureFlags,
+			access: accessAdmin,
+			doc: operationDoc{
+				id: "listFeatureFlags", summary: "Feature flags as currently loaded", tag: "admin",
+				params: []openapi.Parameter{featureFlagUserParam},
+				status: http.StatusOK, response: "FeatureFlagsResponse",
+			},
+		},
+		{
+			method: http.MethodGet, path: "/admin/stats", handler: h.Admin.Stats,
+			access: accessAdmin,
+			doc: operationDoc{
+				id: "getStats", summary: "Repository sizes and timeline cache counters", tag: "admin",
+				status: http.StatusOK, response: "StatsResponse",
+			},
+		},
+		{
+			method: http.MethodGet, path: "/admin/users/{id}/follow-graph", handler: h.Admin.FollowGraph,
+			access: accessAdmin,
+			doc: operationDoc{
+				id: "getFollowGraph", summary: "Who a user follows and who follows them", tag: "admin",
+				params: []openapi.Parameter{pathID("User ID")},
+				status: http.StatusOK, response: "FollowGraphResponse",
+				errors: []int{http.StatusNotFound},
+			},
+		},
+		{
+			method: http.MethodDelete, path: "/admin/users/{id}/tweets", handler: h.Admin.PurgeTweets,
+			access: accessAdmin,
+			doc: operationDoc{
+				id: "purgeUserTweets", summary: "Delete every tweet of a user along with their likes", tag: "admin",
+				params: []openapi.Parameter{pathID("User ID")},
+				status: http.StatusOK, response: "PurgeTweetsResponse",
+				errors: []int{http.StatusNotFound},
+			},
+		},
+		{
+			method: http.MethodPut, path: "/admin/users/{id}/password", handler: h.Admin.ResetPassword,
+			access: accessAdmin,
+			doc: operationDoc{
+				id: "resetUserPassword", summary: "Set a user's password and revoke existing tokens", tag: "admin",
+				params:  []openapi.Parameter{pathID("User ID")},
+				request: "ResetPasswordRequest", status: http.StatusNoContent,
+				errors: []int{http.StatusNotFound},
+			},
+		},
+		{
+			method: http.MethodPost, path: "/admin/likes/recount", handler: h.Admin.RecountLikes,
+			access: accessAdmin,
+			doc: operationDoc{
+				id: "recountLikes", summary: "Rebuild tweet like counters from the like set", tag: "admin",
+				status: http.StatusOK, response: "RecountLikesResponse",
+			},
+		},
+		{
+			method: http.MethodGet, path: "/admin/export", handler: h.Admin.Export,
+			access: accessAdmin,
+			doc: operationDoc{
+				id: "exportDataset", summary: "Every user, follow, tweet and like as JSON Lines", tag: "admin",
+				status: http.StatusOK, responseMedia: adminhandler.JSONLinesContentType,
+			},
+		},
+		{
+			method: http.MethodPost, path: "/admin/import", handler: h.Admin.Import,
+			access: accessAdmin,
+			doc: operationDoc{
+				id: "importDataset", summary: "Load a JSON Lines dataset and report rejected lines", tag: "admin",
+				requestMedia: adminhandler.JSONLinesContentType,
+				status:       http.StatusOK, response: "ImportReportResponse",
+			},
+		},
+	}
+}
+
+func v1Routes(h V1Handlers) []route {
+	return []route{
+		{
+			method: http.MethodPost, path: "/users", handler: h.CreateUser,
+			limit: ratelimit.LimitCreateUser, idempotent: true,
+			doc: operationDoc{
+				id: "createUser", summary: "Register a user", tag: "users",
+				request: "CreateUserRequest", status: http.StatusOK, response: "CreateUserResponse",
+				errors: []int{http.StatusConflict},
+			},
+		},
+		{
+			method: http.MethodPost, path: "/auth/token", handler: h.IssueToken,
+			limit: ratelimit.LimitIssueToken,
+			doc: operationDoc{
+				id: "issueToken", summary: "Exchange a user ID and password for an access token", tag: "auth",
+				request: "IssueTokenRequest", status: http.StatusOK, response: "IssueTokenResponse",
+				headers: noStoreHeader,
+				errors:  []int{http.StatusUnauthorized, http.StatusForbidden},
+			},
+		},
+		{
+			method: http.MethodPost, path: "/tweets", handler: h.PostTweet,
+			access: accessAuthenticated, scope: apikey.ScopeTweetsWrite, limit: ratelimit.LimitPostTweet, idempotent: true,
+			doc: operationDoc{
+				id: "postTweet", summary: "Publish a tweet", tag: "tweets",
+				request: "PostTweetRequest", status: http.StatusOK, response: "PostTweetResponse",
+				errors: []int{http.StatusNotFound},
+			},
+		},
+		{
+			method: http.MethodGet, path: "/timeline", handler: h.GetTimeline,
+			access: accessAuthenticated, scope: apikey.ScopeTimelineRead,
+			doc: operationDoc{
+				id: "getTimeline", summary: "Tweets of the followed users", tag: "tweets",
+				params: timelineParams,
+				status: http.StatusOK, response: "TweetTimelineResponse", array: true,
+				headers: conditionalHeaders,
+				errors:  []int{http.StatusNotModified, http.StatusBadRequest, http.StatusNotFound},
+			},
+		},
+		{
+			method: http.MethodPost, path: "/graphql", handler: h.GraphQL,
+			access: accessAuthenticated, scope: apikey.ScopeTimelineRead, versionedOnly: true,
+			doc: operationDoc{
+				id: "graphQL", summary: "Run a GraphQL query over users, tweets and the timeline", tag: "graphql",
+				request: "GraphQLRequest", status: http.StatusOK, response: "GraphQLResponse",
+			},
+		},
+		{
+			method: http.MethodPost, path: "/tweets/{id}/like", handler: h.LikeTweet,
+			access: accessAuthenticated, scope: apikey.ScopeLikesWrite, limit: ratelimit.LimitLikeTweet,
+			doc: operationDoc{
+				id: "likeTweet", summary: "Like a tweet", tag: "tweets",
+				params: []openapi.Parameter{pathID("Tweet ID")},
+				status: http.StatusNoContent,
+				errors: []int{http.StatusBadRequest, http.StatusNotFound},
+			},
+		},
+		{
+			method: http.MethodPost, path: "/follow", handler: h.FollowUser,
+			access: accessAuthenticated, scope: apikey.ScopeFollowsWrite, limit: ratelimit.LimitFollowUser, idempotent: true,
+			doc: operationDoc{
+				id: "followUser", summary: "Follow a user", tag: "users",
+				request: "FollowUserRequest", status: http.StatusNoContent,
+				errors: []int{http.StatusNotFound},
+			},
+		},
+		{
+			method: http.MethodPut, path: "/me/password", handler: h.ChangePassword,
+			access: accessSession,
+			doc: operationDoc{
+				id: "changePassword", summary: "Change the password and revoke existing tokens", tag: "auth",
+				request: "ChangePasswordRequest", status: http.StatusNoContent,
+			},
+		},
+		{
+			method: http.MethodPost, path: "/me/api-keys", handler: h.CreateAPIKey,
+			access: accessSession,
+			doc: operationDoc{
+				id: "createAPIKey", summary: "Create an API key; the key is only shown once", tag: "auth",
+				request: "CreateAPIKeyRequest", status: http.StatusCreated, response: "CreateAPIKeyResponse",
+				headers: noStoreHeader,
+				errors:  []int{http.StatusConflict},
+			},
+		},
+		{
+			method: http.MethodGet, path: "/me/api-keys", handler: h.ListAPIKeys,
+			access: accessSession,
+			doc: operationDoc{
+				id: "listAPIKeys", summary: "List the caller's API keys", tag: "auth",
+				status: http.StatusOK, response: "APIKeyResponse", array: true,
+			},
+		},
+		{
+			method: http.MethodDelete, path: "/me/api-keys/{id}", handler: h.RevokeAPIKey,
+			access: accessSession,
+			doc: operationDoc{
+				id: "revokeAPIKey", summary: "Revoke an API key", tag: "auth",
+				params: []openapi.Parameter{pathID("API key ID")},
+				status: http.StatusNoContent,
+				errors: []int{http.StatusBadRequest, http.StatusNotFound},
+			},
+		},
+	}
+}
+
+// routeTable lists every mounted route with its full path: the routes of
+// each version under its prefix, the deprecated unprefixed aliases of the
+// legacy version and the unversioned routes. Operation IDs are qualified
+// so versions cannot collide.
+func routeTable(h Handlers) []route {
+	table := unversionedRoutes(h)
+	for i := range table {
+		table[i].endpoint = table[i].doc.id
+	}
+	for _, v := range apiVersions(h) {
+		for _, rt := range v.routes {
+			versioned := rt
+			versioned.path = v.prefix + rt.path
+			versioned.doc.id = v.name + upperFirst(rt.doc.id)
+			versioned.endpoint = versioned.doc.id
+			table = append(table, versioned)
+
+			if v.name == legacyVersion && !rt.versionedOnly {
+				alias := rt
+				alias.deprecated = true
+				alias.endpoint = versioned.endpoint
+				alias.doc.id = "legacy" + upperFirst(rt.doc.id)
+				table = append(table, alias)
+			}
+		}
+	}
+	return table
+}
+
 func RegisterRoutes(r *mux.Router, h Handlers) {
-	r.HandleFunc("/tweets", h.PostTweet).Methods(http.MethodPost)
-	r.HandleFunc("/timeline", h.GetTimeline).Methods(http.MethodGet)
-	r.HandleFunc("/tweets/{id}/like", h.LikeTweet).Methods(http.MethodPost)
-	r.HandleFunc("/follow", h.FollowUser).Methods(http.MethodPost)
-	r.HandleFunc("/users", h.CreateUser).Methods(http.MethodPost)
+	r.Use(httphelper.NewErrorCodes(errorCodes...).Middleware)
+	for _, mw := range []func(http.Handler) http.Handler{h.Trace, h.AccessLog} {
+		if mw != nil {
+			r.Use(mw)
+		}
+	}
+
+	var current, legacy []route
+	for _, rt := range routeTable(h) {
+		if rt.deprecated {
+			legacy = append(legacy, rt)
+		} else {
+			current = append(current, rt)
+		}
+	}
+
+	mount(r, h, current)
+
+	// Deprecation headers go first so they are also sent on rejections.
+	aliases := r.NewRoute().Subrouter()
+	aliases.Use(deprecation(h.Legacy, "/"+legacyVersion))
+	mount(aliases, h, legacy)
+}
+
+// mount registers routes on r behind the middleware their access level,
+// limits and idempotency call for.
+func mount(r *mux.Router, h Handlers, table []route) {
+	limited := func(name string, handler http.Handler) http.Handler {
+		if name == "" || h.RateLimit == nil {
+			return handler
+		}
+		return h.RateLimit(name)(handler)
+	}
+	idempotent := func(rt route) http.Handler {
+		if !rt.idempotent || h.Idempotent == nil {
+			return rt.handler
+		}
+		return h.Idempotent(rt.endpoint)(rt.handler)
+	}
+	chain := func(rt route) http.Handler {
+		return limited(rt.limit, idempotent(rt))
+	}
+
+	for _, rt := range table {
+		if rt.access == accessPublic {
+			r.Handle(rt.path, chain(rt)).Methods(rt.method)
+		}
+	}
+
+	// User sessions may call every authenticated route; API keys only the
+	// ones their scopes cover. Limits run after authentication so they are
+	// keyed by user.
+	authenticated := r.NewRoute().Subrouter()
+	authenticated.Use(h.Authenticate, logCaller)
+	for _, rt := range table {
+		if rt.access == accessAuthenticated {
+			authenticated.Handle(rt.path, scoped(rt.scope, chain(rt))).Methods(rt.method)
+		}
+	}
+
+	account := authenticated.NewRoute().Subrouter()
+	account.Use(auth.RequireSession)
+	for _, rt := range table {
+		if rt.access == accessSession {
+			account.Handle(rt.path, chain(rt)).Methods(rt.method)
+		}
+	}
+
+	adminAuth := h.AdminAuth
+	if adminAuth == nil {
+		adminAuth = auth.RequireAdminToken("")
+	}
+	admin := r.NewRoute().Subrouter()
+	admin.Use(adminAuth)
+	for _, rt := range table {
+		if rt.access == accessAdmin {
+			admin.Handle(rt.path, chain(rt)).Methods(rt.method)
+		}
+	}
+}
+
+// logCaller tags the request logger with the authenticated user, so the
+// access log and use case logs name who made the call.
+func logCaller(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if userID, ok := auth.UserIDFromContext(r.Context()); ok {
+			logger.AddFields(r.Context(), zap.String("user_id", userID))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func scoped(scope string, handler http.Handler) http.Handler {
+	return auth.RequireScope(scope)(handler)
+}
 
-	r.HandleFunc("/health", h.Health).Methods(http.MethodGet)
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
 }
@@ -0,0 +1,336 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/platform/ratelimit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAuthenticate treats "X-Test-Scopes" as an API key carrying those
+// scopes and any other request as a user session.
+func fakeAuthenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := auth.WithUserID(r.Context(), "usr_1")
+		if scopes, ok := r.Header["X-Test-Scopes"]; ok {
+			ctx = auth.WithScopes(ctx, strings.Split(scopes[0], ","))
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func newTestRouter() *mux.Router {
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	r := mux.NewRouter()
+	RegisterRoutes(r, Handlers{
+		V1: V1Handlers{
+			PostTweet:      ok,
+			FollowUser:     ok,
+			CreateUser:     ok,
+			GetTimeline:    ok,
+			LikeTweet:      ok,
+			IssueToken:     ok,
+			ChangePassword: ok,
+			CreateAPIKey:   ok,
+			ListAPIKeys:    ok,
+			RevokeAPIKey:   ok,
+			GraphQL:        ok,
+		},
+		Health:  ok,
+		Live:    ok,
+		Ready:   ok,
+		Metrics: ok,
+		Admin: AdminHandlers{
+			GetLogLevel:      ok,
+			SetLogLevel:      ok,
+			ListFeatureFlags: ok,
+			Stats:            ok,
+			FollowGraph:      ok,
+			PurgeTweets:      ok,
+			ResetPassword:    ok,
+			RecountLikes:     ok,
+			Export:           ok,
+			Import:           ok,
+		},
+		AdminAuth:    auth.RequireAdminToken(testAdminToken),
+		Authenticate: fakeAuthenticate,
+	})
+	return r
+}
+
+const testAdminToken = "admin-secret"
+
+func TestRegisterRoutes_Scopes(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		scopes         *string
+		adminToken     string
+		expectedStatus int
+	}{
+		{name: "session may post", method: http.MethodPost, path: "/v1/tweets", expectedStatus: http.StatusOK},
+		{name: "key with tweets:write may post", method: http.MethodPost, path: "/v1/tweets", scopes: ptr("tweets:write"), expectedStatus: http.StatusOK},
+		{name: "read-only key may not post", method: http.MethodPost, path: "/v1/tweets", scopes: ptr("timeline:read"), expectedStatus: http.StatusForbidden},
+		{name: "read-only key may read the timeline", method: http.MethodGet, path: "/v1/timeline", scopes: ptr("timeline:read"), expectedStatus: http.StatusOK},
+		{name: "like needs likes:write", method: http.MethodPost, path: "/v1/tweets/t1/like", scopes: ptr("tweets:write"), expectedStatus: http.StatusForbidden},
+		{name: "follow needs follows:write", method: http.MethodPost, path: "/v1/follow", scopes: ptr("follows:write"), expectedStatus: http.StatusOK},
+		{name: "keys cannot manage keys", method: http.MethodPost, path: "/v1/me/api-keys", scopes: ptr("tweets:write,likes:write,follows:write,timeline:read"), expectedStatus: http.StatusForbidden},
+		{name: "keys cannot change the password", method: http.MethodPut, path: "/v1/me/password", scopes: ptr("tweets:write"), expectedStatus: http.StatusForbidden},
+		{name: "session may revoke keys", method: http.MethodDelete, path: "/v1/me/api-keys/k1", expectedStatus: http.StatusOK},
+		{name: "read-only key may query graphql", method: http.MethodPost, path: "/v1/graphql", scopes: ptr("timeline:read"), expectedStatus: http.StatusOK},
+		{name: "graphql needs timeline:read", method: http.MethodPost, path: "/v1/graphql", scopes: ptr("tweets:write"), expectedStatus: http.StatusForbidden},
+		{name: "sessions cannot reach admin routes", method: http.MethodPut, path: "/admin/log-level", expectedStatus: http.StatusUnauthorized},
+		{name: "admin token may change the log level", method: http.MethodPut, path: "/admin/log-level", adminToken: testAdminToken, expectedStatus: http.StatusOK},
+		{name: "feature flags need the admin token", method: http.MethodGet, path: "/admin/feature-flags", expectedStatus: http.StatusUnauthorized},
+		{name: "admin token may list feature flags", method: http.MethodGet, path: "/admin/feature-flags", adminToken: testAdminToken, expectedStatus: http.StatusOK},
+		{name: "purging tweets needs the admin token", method: http.MethodDelete, path: "/admin/users/usr_2/tweets", expectedStatus: http.StatusUnauthorized},
+		{name: "admin token may purge tweets", method: http.MethodDelete, path: "/admin/users/usr_2/tweets", adminToken: testAdminToken, expectedStatus: http.StatusOK},
+		{name: "resetting a password needs the admin token", method: http.MethodPut, path: "/admin/users/usr_2/password", expectedStatus: http.StatusUnauthorized},
+		{name: "admin token may reset a password", method: http.MethodPut, path: "/admin/users/usr_2/password", adminToken: testAdminToken, expectedStatus: http.StatusOK},
+		{name: "admin token may read stats", method: http.MethodGet, path: "/admin/stats", adminToken: testAdminToken, expectedStatus: http.StatusOK},
+		{name: "export needs the admin token", method: http.MethodGet, path: "/admin/export", expectedStatus: http.StatusUnauthorized},
+		{name: "admin token may import", method: http.MethodPost, path: "/admin/import", adminToken: testAdminToken, expectedStatus: http.StatusOK},
+		{name: "metrics need the admin token", method: http.MethodGet, path: "/metrics", expectedStatus: http.StatusUnauthorized},
+		{name: "admin token may scrape metrics", method: http.MethodGet, path: "/metrics", adminToken: testAdminToken, expectedStatus: http.StatusOK},
+	}
+
+	router := newTestRouter()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.scopes != nil {
+				req.Header.Set("X-Test-Scopes", *tc.scopes)
+			}
+			if tc.adminToken != "" {
+				req.Header.Set(auth.AdminTokenHeader, tc.adminToken)
+			}
+			rr := httptest.NewRecorder()
+
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestRegisterRoutes_RateLimits(t *testing.T) {
+	var limited []string
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	r := mux.NewRouter()
+	RegisterRoutes(r, Handlers{
+		V1: V1Handlers{
+			PostTweet:   ok,
+			FollowUser:  ok,
+			CreateUser:  ok,
+			GetTimeline: ok,
+			LikeTweet:   ok,
+			IssueToken:  ok,
+		},
+		Health:       ok,
+		Authenticate: fakeAuthenticate,
+		RateLimit: func(name string) func(http.Handler) http.Handler {
+			return func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					limited = append(limited, name)
+					next.ServeHTTP(w, r)
+				})
+			}
+		},
+	})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/v1/tweets", nil),
+		httptest.NewRequest(http.MethodPost, "/v1/tweets/t1/like", nil),
+		httptest.NewRequest(http.MethodPost, "/v1/follow", nil),
+		httptest.NewRequest(http.MethodPost, "/v1/auth/token", nil),
+		httptest.NewRequest(http.MethodPost, "/v1/users", nil),
+		httptest.NewRequest(http.MethodGet, "/v1/timeline", nil),
+		httptest.NewRequest(http.MethodGet, "/health", nil),
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, []string{ratelimit.LimitPostTweet, ratelimit.LimitLikeTweet, ratelimit.LimitFollowUser, ratelimit.LimitIssueToken, ratelimit.LimitCreateUser}, limited)
+}
+
+func TestRegisterRoutes_Idempotency(t *testing.T) {
+	var wrapped []string
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	r := mux.NewRouter()
+	RegisterRoutes(r, Handlers{
+		V1: V1Handlers{
+			PostTweet:   ok,
+			FollowUser:  ok,
+			CreateUser:  ok,
+			GetTimeline: ok,
+			LikeTweet:   ok,
+			IssueToken:  ok,
+		},
+		Health:       ok,
+		Authenticate: fakeAuthenticate,
+		Idempotent: func(endpoint string) func(http.Handler) http.Handler {
+			return func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					wrapped = append(wrapped, endpoint+" "+r.URL.Path)
+					next.ServeHTTP(w, r)
+				})
+			}
+		},
+	})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/v1/tweets", nil),
+		httptest.NewRequest(http.MethodPost, "/v1/tweets/t1/like", nil),
+		httptest.NewRequest(http.MethodPost, "/v1/follow", nil),
+		httptest.NewRequest(http.MethodPost, "/v1/auth/token", nil),
+		httptest.NewRequest(http.MethodPost, "/v1/users", nil),
+		httptest.NewRequest(http.MethodGet, "/v1/timeline", nil),
+		httptest.NewRequest(http.MethodPost, "/tweets", nil),
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, []string{
+		"v1PostTweet /v1/tweets",
+		"v1FollowUser /v1/follow",
+		"v1CreateUser /v1/users",
+		"v1PostTweet /tweets",
+	}, wrapped)
+}
+
+func TestRegisterRoutes_TraceSeesRouteTemplates(t *testing.T) {
+	var templates []string
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	r := mux.NewRouter()
+	RegisterRoutes(r, Handlers{
+		V1: V1Handlers{
+			GetTimeline: ok,
+			LikeTweet:   ok,
+		},
+		Health:       ok,
+		Metrics:      ok,
+		Authenticate: fakeAuthenticate,
+		Trace: func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				template, _ := mux.CurrentRoute(r).GetPathTemplate()
+				templates = append(templates, template)
+				next.ServeHTTP(w, r)
+			})
+		},
+	})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/v1/tweets/t1/like", nil),
+		httptest.NewRequest(http.MethodPost, "/tweets/t2/like", nil),
+		httptest.NewRequest(http.MethodGet, "/v1/timeline", nil),
+		httptest.NewRequest(http.MethodGet, "/metrics", nil),
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, []string{"/v1/tweets/{id}/like", "/tweets/{id}/like", "/v1/timeline", "/metrics"}, templates)
+}
+
+func TestRegisterRoutes_AccessLogNamesCaller(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	previous := logger.Log
+	logger.Log = zap.New(core)
+	t.Cleanup(func() { logger.Log = previous })
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	r := mux.NewRouter()
+	RegisterRoutes(r, Handlers{
+		V1:           V1Handlers{CreateUser: ok, GetTimeline: ok},
+		Health:       ok,
+		Authenticate: fakeAuthenticate,
+		AccessLog:    logger.AccessLog,
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/timeline", nil))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/v1/users", nil))
+
+	entries := logs.FilterMessage("request").AllUntimed()
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "usr_1", entries[0].ContextMap()["user_id"])
+		assert.Equal(t, "/v1/timeline", entries[0].ContextMap()["route"])
+		assert.NotContains(t, entries[1].ContextMap(), "user_id", "public routes have no caller")
+	}
+}
+
+func TestRegisterRoutes_LegacyAliases(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	deprecatedAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	sunsetAt := time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC)
+
+	r := mux.NewRouter()
+	RegisterRoutes(r, Handlers{
+		V1: V1Handlers{
+			PostTweet:   ok,
+			GetTimeline: ok,
+			LikeTweet:   ok,
+			GraphQL:     ok,
+		},
+		Health:       ok,
+		Authenticate: fakeAuthenticate,
+		Legacy:       LegacyPolicy{DeprecatedAt: deprecatedAt, SunsetAt: sunsetAt},
+	})
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		scopes         *string
+		expectedStatus int
+		successor      string
+	}{
+		{name: "legacy route", method: http.MethodPost, path: "/tweets", expectedStatus: http.StatusOK, successor: "/v1/tweets"},
+		{name: "legacy route with path variables", method: http.MethodPost, path: "/tweets/t1/like", expectedStatus: http.StatusOK, successor: "/v1/tweets/t1/like"},
+		{name: "legacy rejection is still announced", method: http.MethodPost, path: "/tweets", scopes: ptr("timeline:read"), expectedStatus: http.StatusForbidden, successor: "/v1/tweets"},
+		{name: "versioned route", method: http.MethodGet, path: "/v1/timeline", expectedStatus: http.StatusOK},
+		{name: "unversioned route", method: http.MethodGet, path: "/health", expectedStatus: http.StatusOK},
+		{name: "health is not versioned", method: http.MethodGet, path: "/v1/health", expectedStatus: http.StatusNotFound},
+		{name: "routes added after versioning have no alias", method: http.MethodPost, path: "/graphql", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.scopes != nil {
+				req.Header.Set("X-Test-Scopes", *tc.scopes)
+			}
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.successor == "" {
+				assert.Empty(t, rr.Header().Get("Deprecation"))
+				assert.Empty(t, rr.Header().Get("Sunset"))
+				return
+			}
+			assert.Equal(t, "@1792368000", rr.Header().Get("Deprecation"))
+			assert.Equal(t, "Fri, 30 Apr 2027 00:00:00 GMT", rr.Header().Get("Sunset"))
+			assert.Equal(t, "<"+tc.successor+`>; rel="successor-version"`, rr.Header().Get("Link"))
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"ualaTwitter/cmd/api/config"
+	"ualaTwitter/cmd/api/grpcapi"
+	"ualaTwitter/cmd/api/routes/handlers/health"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/cache"
+	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/platform/notify"
+	"ualaTwitter/internal/platform/ratelimit"
+	"ualaTwitter/internal/platform/tracing"
+)
+
+func newHTTPServer(cfg *config.Config, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              ":" + cfg.ServerPort,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.HTTPReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTPReadTimeout,
+		WriteTimeout:      cfg.HTTPWriteTimeout,
+		IdleTimeout:       cfg.HTTPIdleTimeout,
+	}
+}
+
+func serveHTTP(server *http.Server, errs chan<- error) {
+	logger.Log.Info("HTTP server started", zap.String("addr", server.Addr))
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		errs <- err
+	}
+}
+
+func serveGRPC(port string, authenticator *auth.Authenticator, limiter *ratelimit.Limiter, services grpcapi.Services, errs chan<- error) *grpc.Server {
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		logger.Log.Fatal("failed to listen for gRPC", zap.String("port", port), zap.Error(err))
+	}
+	server := grpcapi.NewServer(authenticator, limiter, services,
+		grpc.ChainUnaryInterceptor(tracing.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(tracing.StreamServerInterceptor()),
+	)
+	go func() {
+		logger.Log.Info("gRPC server started", zap.String("addr", listener.Addr().String()))
+		if err := server.Serve(listener); err != nil {
+			errs <- err
+		}
+	}()
+	return server
+}
+
+// resources is everything main starts that shutdown has to stop. Optional
+// fields are nil when disabled.
+type resources struct {
+	readiness      *health.Checker
+	stopWorkers    context.CancelFunc
+	watchers       *notify.Hub
+	httpServer     *http.Server
+	grpcServer     *grpc.Server
+	timelineCache  cache.Store
+	rateLimitStore ratelimit.Store
+	pool           *pgxpool.Pool
+	tracing        func(context.Context) error
+}
+
+// shutdown fails readiness, waits cfg.ShutdownDelay for load balancers to
+// notice, then gives in-flight requests cfg.ShutdownTimeout to finish
+// before closing the stores. A failing step is logged and never stops the
+// ones after it.
+func shutdown(cfg *config.Config, res resources) {
+	res.readiness.Drain()
+	time.Sleep(cfg.ShutdownDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// Watch streams never end on their own and would hold up the gRPC
+	// server until the deadline.
+	res.watchers.Close()
+	res.stopWorkers()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := res.httpServer.Shutdown(ctx); err != nil {
+			logger.Log.Warn("HTTP server did not drain", zap.Error(err))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if res.grpcServer != nil {
+			stopGRPC(ctx, res.grpcServer)
+		}
+	}()
+	wg.Wait()
+
+	if closer, ok := res.timelineCache.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			logger.Log.Error("failed to close timeline cache", zap.Error(err))
+		}
+	}
+	if closer, ok := res.rateLimitStore.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			logger.Log.Error("failed to close rate limit store", zap.Error(err))
+		}
+	}
+	res.pool.Close()
+
+	if err := res.tracing(ctx); err != nil {
+		logger.Log.Error("failed to flush traces", zap.Error(err))
+	}
+	logger.Log.Info("server stopped")
+	_ = logger.Log.Sync()
+}
+
+// stopGRPC waits for in-flight RPCs until ctx is done, then cuts the rest.
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		logger.Log.Warn("gRPC server did not drain", zap.Error(ctx.Err()))
+		server.Stop()
+	}
+}
@@ -6,4 +6,28 @@ CREATE TABLE IF NOT EXISTS users (
  created_at TIMESTAMPTZ DEFAULT now() NOT NULL
 );
 
-CREATE INDEX IF NOT EXISTS idx_users_name ON users (name);
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS idx_users_name ON users (name);
+
+CREATE TABLE IF NOT EXISTS credentials (
+ user_id TEXT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+ password_hash TEXT NOT NULL,
+ failed_attempts INTEGER DEFAULT 0 NOT NULL,
+ locked_until TIMESTAMPTZ,
+ session_version BIGINT DEFAULT 0 NOT NULL,
+ updated_at TIMESTAMPTZ DEFAULT now() NOT NULL
+);
+
+-- Only the SHA-256 of each raw key is stored.
+CREATE TABLE IF NOT EXISTS api_keys (
+ id TEXT PRIMARY KEY,
+ user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+ name TEXT NOT NULL,
+ prefix TEXT NOT NULL,
+ key_hash TEXT UNIQUE NOT NULL,
+ scopes TEXT[] NOT NULL,
+ created_at TIMESTAMPTZ NOT NULL,
+ last_used_at TIMESTAMPTZ,
+ revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys (user_id, created_at DESC);
@@ -0,0 +1,124 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ScopeTweetsWrite  = "tweets:write"
+	ScopeLikesWrite   = "likes:write"
+	ScopeFollowsWrite = "follows:write"
+	ScopeTimelineRead = "timeline:read"
+
+	MaxNameLength = 64
+
+	// keyPrefix marks raw keys so they are easy to spot in leaked logs.
+	keyPrefix = "uak_"
+	// displayLength is how much of the raw key is kept for listings.
+	displayLength = len(keyPrefix) + 6
+)
+
+var validScopes = map[string]struct{}{
+	ScopeTweetsWrite:  {},
+	ScopeLikesWrite:   {},
+	ScopeFollowsWrite: {},
+	ScopeTimelineRead: {},
+}
+
+// APIKey is a long-lived credential owned by a user and restricted to a set
+// of scopes. Only the SHA-256 of the raw key is stored.
+type APIKey struct {
+	ID         string
+	UserID     string
+	Name       string
+	Prefix     string
+	Hash       string
+	Scopes     []string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+	RevokedAt  time.Time
+}
+
+// New builds a key for userID and returns it along with the raw secret,
+// which is shown to the owner once and never stored.
+func New(userID, name string, scopes []string, now time.Time) (APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		return APIKey{}, "", ErrInvalidName
+	}
+
+	normalized, err := normalizeScopes(scopes)
+	if err != nil {
+		return APIKey{}, "", err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return APIKey{}, "", err
+	}
+	raw := keyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	return APIKey{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    raw[:displayLength],
+		Hash:      Hash(raw),
+		Scopes:    normalized,
+		CreatedAt: now,
+	}, raw, nil
+}
+
+// Hash is the lookup key stored for a raw API key. The secret carries 256
+// bits of entropy, so a fast unsalted hash is enough.
+func Hash(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// LooksLikeKey reports whether raw has the shape of an API key, letting
+// callers tell keys apart from other bearer credentials.
+func LooksLikeKey(raw string) bool {
+	return strings.HasPrefix(raw, keyPrefix)
+}
+
+func (k APIKey) IsRevoked() bool {
+	return !k.RevokedAt.IsZero()
+}
+
+func (k APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, ErrNoScopes
+	}
+
+	seen := make(map[string]struct{}, len(scopes))
+	out := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		if _, ok := validScopes[s]; !ok {
+			return nil, ErrInvalidScope
+		}
+		if _, dup := seen[s]; dup {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	return out, nil
+}
@@ -0,0 +1,56 @@
+package apikey_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"ualaTwitter/internal/domain/apikey"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKey_New(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("valid key stores only the hash", func(t *testing.T) {
+		key, raw, err := apikey.New("usr_1", " deploy bot ", []string{apikey.ScopeTweetsWrite, apikey.ScopeTweetsWrite}, now)
+
+		require.NoError(t, err)
+		assert.True(t, apikey.LooksLikeKey(raw))
+		assert.Equal(t, "deploy bot", key.Name)
+		assert.Equal(t, apikey.Hash(raw), key.Hash)
+		assert.NotContains(t, key.Hash, raw)
+		assert.True(t, strings.HasPrefix(raw, key.Prefix))
+		assert.Equal(t, []string{apikey.ScopeTweetsWrite}, key.Scopes)
+		assert.Equal(t, now, key.CreatedAt)
+		assert.True(t, key.HasScope(apikey.ScopeTweetsWrite))
+		assert.False(t, key.HasScope(apikey.ScopeLikesWrite))
+	})
+
+	t.Run("every key is different", func(t *testing.T) {
+		_, a, _ := apikey.New("usr_1", "bot", []string{apikey.ScopeTimelineRead}, now)
+		_, b, _ := apikey.New("usr_1", "bot", []string{apikey.ScopeTimelineRead}, now)
+		assert.NotEqual(t, a, b)
+	})
+
+	tests := []struct {
+		name      string
+		keyName   string
+		scopes    []string
+		wantError error
+	}{
+		{name: "empty name", keyName: "  ", scopes: []string{apikey.ScopeTweetsWrite}, wantError: apikey.ErrInvalidName},
+		{name: "name too long", keyName: strings.Repeat("a", apikey.MaxNameLength+1), scopes: []string{apikey.ScopeTweetsWrite}, wantError: apikey.ErrInvalidName},
+		{name: "no scopes", keyName: "bot", scopes: nil, wantError: apikey.ErrNoScopes},
+		{name: "unknown scope", keyName: "bot", scopes: []string{"admin"}, wantError: apikey.ErrInvalidScope},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, raw, err := apikey.New("usr_1", tc.keyName, tc.scopes, now)
+			assert.ErrorIs(t, err, tc.wantError)
+			assert.Empty(t, raw)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package apikey
+
+import "errors"
+
+var (
+	ErrNotFound     = errors.New("api key not found")
+	ErrInvalidName  = errors.New("api key name is empty or too long")
+	ErrInvalidScope = errors.New("unknown api key scope")
+	ErrNoScopes     = errors.New("api key needs at least one scope")
+	ErrRevoked      = errors.New("api key has been revoked")
+)
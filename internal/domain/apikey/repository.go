@@ -0,0 +1,14 @@
+package apikey
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	Create(ctx context.Context, key APIKey) error
+	GetByHash(ctx context.Context, hash string) (APIKey, error)
+	ListByUser(ctx context.Context, userID string) ([]APIKey, error)
+	Revoke(ctx context.Context, userID, keyID string, at time.Time) error
+	TouchLastUsed(ctx context.Context, keyID string, at time.Time) error
+}
@@ -0,0 +1,46 @@
+package credential
+
+import "time"
+
+// Credential is the login secret of a user, kept apart from the user profile
+// so the hash never travels with user reads.
+type Credential struct {
+	UserID         string
+	PasswordHash   string
+	FailedAttempts int
+	LockedUntil    time.Time
+	// SessionVersion is embedded in issued tokens; bumping it invalidates
+	// every token issued before.
+	SessionVersion int64
+	UpdatedAt      time.Time
+}
+
+func (c Credential) IsLocked(now time.Time) bool {
+	return now.Before(c.LockedUntil)
+}
+
+// RegisterFailure counts a failed login and locks the credential for
+// lockout once maxAttempts consecutive failures are reached.
+func (c *Credential) RegisterFailure(now time.Time, maxAttempts int, lockout time.Duration) {
+	c.FailedAttempts++
+	if c.FailedAttempts >= maxAttempts {
+		c.FailedAttempts = 0
+		c.LockedUntil = now.Add(lockout)
+	}
+	c.UpdatedAt = now
+}
+
+func (c *Credential) RegisterSuccess(now time.Time) {
+	c.FailedAttempts = 0
+	c.LockedUntil = time.Time{}
+	c.UpdatedAt = now
+}
+
+// ChangePassword stores the new hash and revokes existing sessions.
+func (c *Credential) ChangePassword(hash string, now time.Time) {
+	c.PasswordHash = hash
+	c.SessionVersion++
+	c.FailedAttempts = 0
+	c.LockedUntil = time.Time{}
+	c.UpdatedAt = now
+}
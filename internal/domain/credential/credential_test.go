@@ -0,0 +1,40 @@
+package credential_test
+
+import (
+	"testing"
+	"time"
+	"ualaTwitter/internal/domain/credential"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredential_Lockout(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := credential.Credential{UserID: "usr_1"}
+
+	c.RegisterFailure(now, 3, time.Minute)
+	c.RegisterFailure(now, 3, time.Minute)
+	assert.False(t, c.IsLocked(now))
+	assert.Equal(t, 2, c.FailedAttempts)
+
+	c.RegisterFailure(now, 3, time.Minute)
+	assert.True(t, c.IsLocked(now))
+	assert.True(t, c.IsLocked(now.Add(59*time.Second)))
+	assert.False(t, c.IsLocked(now.Add(time.Minute)))
+
+	c.RegisterSuccess(now.Add(2 * time.Minute))
+	assert.Zero(t, c.FailedAttempts)
+	assert.False(t, c.IsLocked(now))
+}
+
+func TestCredential_ChangePassword(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := credential.Credential{UserID: "usr_1", PasswordHash: "old", SessionVersion: 3, FailedAttempts: 2}
+
+	c.ChangePassword("new", now)
+
+	assert.Equal(t, "new", c.PasswordHash)
+	assert.Equal(t, int64(4), c.SessionVersion)
+	assert.Zero(t, c.FailedAttempts)
+	assert.Equal(t, now, c.UpdatedAt)
+}
@@ -0,0 +1,8 @@
+package credential
+
+import "errors"
+
+var (
+	ErrNotFound      = errors.New("credentials not found")
+	ErrAlreadyExists = errors.New("credentials already exist")
+)
@@ -0,0 +1,29 @@
+package credential
+
+import (
+	"context"
+	"time"
+)
+
+// Repository stores credentials. Every write is a single atomic change to
+// the stored row, never a write-back of a copy read earlier, so concurrent
+// logins and password changes cannot undo each other.
+type Repository interface {
+	Create(ctx context.Context, c Credential) error
+	GetByUserID(ctx context.Context, userID string) (Credential, error)
+	// RecordFailure applies Credential.RegisterFailure to the stored
+	// credential and returns the result.
+	RecordFailure(ctx context.Context, userID string, now time.Time, maxAttempts int, lockout time.Duration) (Credential, error)
+	// ResetFailures applies Credential.RegisterSuccess unless the stored
+	// credential is locked at now.
+	ResetFailures(ctx context.Context, userID string, now time.Time) error
+	// ChangePassword applies Credential.ChangePassword to the stored
+	// credential.
+	ChangePassword(ctx context.Context, userID, hash string, now time.Time) error
+}
+
+// Hasher turns passwords into storable hashes and checks them back.
+type Hasher interface {
+	Hash(password string) (string, error)
+	Verify(password, encoded string) (bool, error)
+}
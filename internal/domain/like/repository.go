@@ -4,5 +4,7 @@ import "context"
 
 type Repository interface {
 	HasLiked(ctx context.Context, userID, tweetID string) (bool, error)
+	HasLikedMany(ctx context.Context, userID string, tweetIDs []string) (map[string]bool, error)
 	Like(ctx context.Context, userID, tweetID string) error
+	FindTweetsLikedBy(ctx context.Context, userID string) ([]string, error)
 }
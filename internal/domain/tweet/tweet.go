@@ -7,6 +7,7 @@ import (
 	"unicode/utf8"
 )
 
+// MaxContentLength is the default limit on tweet length, in characters.
 const MaxContentLength = 280
 
 type Tweet struct {
@@ -18,6 +19,11 @@ type Tweet struct {
 }
 
 func New(userID, content string, createdAt time.Time) (Tweet, error) {
+	return NewWithMaxLength(userID, content, createdAt, MaxContentLength)
+}
+
+// NewWithMaxLength is New with a configured limit on content length.
+func NewWithMaxLength(userID, content string, createdAt time.Time, maxLength int) (Tweet, error) {
 	if userID == "" {
 		return Tweet{}, ErrInvalidUser
 	}
@@ -28,7 +34,7 @@ func New(userID, content string, createdAt time.Time) (Tweet, error) {
 		return Tweet{}, ErrEmptyTweet
 	}
 
-	if utf8.RuneCountInString(trimmedTweet) > MaxContentLength {
+	if utf8.RuneCountInString(trimmedTweet) > maxLength {
 		return Tweet{}, ErrTooLong
 	}
 
@@ -10,4 +10,5 @@ var (
 	ErrInvalidDocument   = errors.New("invalid document")
 	ErrSelfFollow        = errors.New("cannot follow yourself")
 	ErrUserAlreadyExists = errors.New("user already exists")
+	ErrWeakPassword      = errors.New("password must be 10 to 128 characters, mix letters and digits and not contain the document")
 )
@@ -0,0 +1,40 @@
+package user
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	MinPasswordLength = 10
+	MaxPasswordLength = 128
+)
+
+// ValidatePassword enforces the password policy: between MinPasswordLength
+// and MaxPasswordLength characters, with at least one letter and one digit,
+// and not containing the user's document.
+func ValidatePassword(password, document string) error {
+	length := utf8.RuneCountInString(password)
+	if length < MinPasswordLength || length > MaxPasswordLength {
+		return ErrWeakPassword
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return ErrWeakPassword
+	}
+
+	if document != "" && strings.Contains(password, document) {
+		return ErrWeakPassword
+	}
+	return nil
+}
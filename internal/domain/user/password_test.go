@@ -0,0 +1,37 @@
+package user_test
+
+import (
+	"strings"
+	"testing"
+	"ualaTwitter/internal/domain/user"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		name      string
+		password  string
+		document  string
+		wantError error
+	}{
+		{name: "letters and digits", password: "correcthorse42", document: "12345678"},
+		{name: "multi-byte letters count as letters", password: "contraseña99x", document: "12345678"},
+		{name: "too short", password: "abc12345", document: "12345678", wantError: user.ErrWeakPassword},
+		{name: "too long", password: strings.Repeat("a1", 65), document: "12345678", wantError: user.ErrWeakPassword},
+		{name: "only letters", password: "onlyletterspassword", document: "12345678", wantError: user.ErrWeakPassword},
+		{name: "only digits", password: "98765432109", document: "12345678", wantError: user.ErrWeakPassword},
+		{name: "contains the document", password: "alice12345678", document: "12345678", wantError: user.ErrWeakPassword},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := user.ValidatePassword(tc.password, tc.document)
+			if tc.wantError != nil {
+				assert.ErrorIs(t, err, tc.wantError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -5,11 +5,15 @@ import "context"
 type InMemoryRepository interface {
 	Create(ctx context.Context, user User) error
 	GetByID(ctx context.Context, id string) (User, error)
+	GetByIDs(ctx context.Context, ids []string) ([]User, error)
 	Follow(ctx context.Context, followerID, followeeID string) error
 	GetUsersFollowedBy(ctx context.Context, userID string) ([]string, error)
+	GetFollowersOf(ctx context.Context, userID string) ([]string, error)
 }
 
 type PostgresRepository interface {
 	Create(ctx context.Context, user User) error
 	GetByID(ctx context.Context, id string) (User, error)
+	// Delete removes the user and, by cascade, their credentials.
+	Delete(ctx context.Context, id string) error
 }
@@ -3,6 +3,7 @@ package user
 import (
 	"fmt"
 	"strings"
+	"time"
 	"unicode/utf8"
 )
 
@@ -15,6 +16,8 @@ type User struct {
 	ID       string
 	Name     string
 	Document string
+	// CreatedAt is zero until the user is registered.
+	CreatedAt time.Time
 }
 
 func New(name, document string) (User, error) {
@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"ualaTwitter/internal/platform/httphelper"
+)
+
+// AdminTokenHeader carries the operator token of admin endpoints.
+const AdminTokenHeader = "X-Admin-Token"
+
+var (
+	ErrAdminDisabled     = errors.New("admin endpoints are disabled")
+	ErrInvalidAdminToken = errors.New("missing or invalid admin token")
+)
+
+// RequireAdminToken guards operator endpoints with a shared token sent in
+// X-Admin-Token. User sessions and API keys grant no access; an empty
+// token disables the endpoints.
+func RequireAdminToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				httphelper.RenderProblem(w, r, http.StatusForbidden, ErrAdminDisabled)
+				return
+			}
+			given := r.Header.Get(AdminTokenHeader)
+			if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+				httphelper.RenderProblem(w, r, http.StatusUnauthorized, ErrInvalidAdminToken)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireAdminToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		configured     string
+		sent           string
+		expectedStatus int
+	}{
+		{name: "matching token", configured: "s3cret", sent: "s3cret", expectedStatus: http.StatusOK},
+		{name: "wrong token", configured: "s3cret", sent: "guess", expectedStatus: http.StatusUnauthorized},
+		{name: "missing token", configured: "s3cret", expectedStatus: http.StatusUnauthorized},
+		{name: "disabled when unset", sent: "", expectedStatus: http.StatusForbidden},
+	}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/log-level", nil)
+			if tc.sent != "" {
+				req.Header.Set(AdminTokenHeader, tc.sent)
+			}
+			rr := httptest.NewRecorder()
+
+			RequireAdminToken(tc.configured)(ok).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"ualaTwitter/internal/platform/httphelper"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+var (
+	ErrMissingScope    = errors.New("api key is missing the required scope")
+	ErrSessionRequired = errors.New("endpoint requires a user session, not an api key")
+)
+
+type apiKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (userID string, scopes []string, err error)
+}
+
+// APIKeyFunc adapts a function to the API key lookup used by
+// APIKeyMiddleware. Errors are mapped with httphelper.StatusFromError.
+type APIKeyFunc func(ctx context.Context, key string) (string, []string, error)
+
+func (f APIKeyFunc) AuthenticateAPIKey(ctx context.Context, key string) (string, []string, error) {
+	return f(ctx, key)
+}
+
+// APIKeyMiddleware authenticates requests carrying an X-API-Key header and
+// stores the key owner and scopes in the context. Requests without the
+// header are handed to fallback, usually the JWT Middleware.
+func APIKeyMiddleware(keys apiKeyAuthenticator, fallback func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		withFallback := fallback(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := strings.TrimSpace(r.Header.Get(APIKeyHeader))
+			if key == "" {
+				withFallback.ServeHTTP(w, r)
+				return
+			}
+
+			userID, scopes, err := keys.AuthenticateAPIKey(r.Context(), key)
+			if err != nil {
+				status := httphelper.StatusFromError(err)
+				if status == http.StatusUnauthorized {
+					unauthorized(w, r, err)
+					return
+				}
+				httphelper.RenderProblem(w, r, status, err)
+				return
+			}
+
+			ctx := WithScopes(WithUserID(r.Context(), userID), scopes)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireScope rejects API key requests whose key lacks scope. User
+// sessions always pass.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !Allowed(r.Context(), scope) {
+				httphelper.RenderProblem(w, r, http.StatusForbidden, fmt.Errorf("%w: %s", ErrMissingScope, scope))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireSession rejects API key requests, for endpoints such as key
+// management that only the account owner may call.
+func RequireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, restricted := ScopesFromContext(r.Context()); restricted {
+			httphelper.RenderProblem(w, r, http.StatusForbidden, ErrSessionRequired)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Allowed reports whether the caller may use scope: user sessions always
+// may, API keys only when they hold it.
+func Allowed(ctx context.Context, scope string) bool {
+	scopes, restricted := ScopesFromContext(ctx)
+	return !restricted || contains(scopes, scope)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ualaTwitter/internal/platform/errors/usecase"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	keys := APIKeyFunc(func(_ context.Context, key string) (string, []string, error) {
+		switch key {
+		case "uak_good":
+			return "usr_bot", []string{"tweets:write"}, nil
+		case "uak_broken":
+			return "", nil, usecase.InternalServerError("db down")
+		default:
+			return "", nil, usecase.Unauthorized("invalid api key")
+		}
+	})
+	fallback := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(WithUserID(r.Context(), "usr_session")))
+		})
+	}
+
+	tests := []struct {
+		name           string
+		apiKey         string
+		expectedStatus int
+		expectedUserID string
+		expectedScopes []string
+	}{
+		{name: "valid key", apiKey: "uak_good", expectedStatus: http.StatusOK, expectedUserID: "usr_bot", expectedScopes: []string{"tweets:write"}},
+		{name: "no key falls back", expectedStatus: http.StatusOK, expectedUserID: "usr_session"},
+		{name: "invalid key", apiKey: "uak_bad", expectedStatus: http.StatusUnauthorized},
+		{name: "lookup failure", apiKey: "uak_broken", expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				gotUserID string
+				gotScopes []string
+			)
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotUserID, _ = UserIDFromContext(r.Context())
+				gotScopes, _ = ScopesFromContext(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/tweets", nil)
+			if tc.apiKey != "" {
+				req.Header.Set(APIKeyHeader, tc.apiKey)
+			}
+			rr := httptest.NewRecorder()
+
+			APIKeyMiddleware(keys, fallback)(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			assert.Equal(t, tc.expectedUserID, gotUserID)
+			assert.Equal(t, tc.expectedScopes, gotScopes)
+		})
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		name           string
+		ctx            func(context.Context) context.Context
+		expectedStatus int
+	}{
+		{
+			name:           "user session passes",
+			ctx:            func(ctx context.Context) context.Context { return ctx },
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "key with the scope passes",
+			ctx: func(ctx context.Context) context.Context {
+				return WithScopes(ctx, []string{"likes:write", "tweets:write"})
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "key without the scope is forbidden",
+			ctx:            func(ctx context.Context) context.Context { return WithScopes(ctx, []string{"timeline:read"}) },
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/tweets", nil)
+			req = req.WithContext(tc.ctx(req.Context()))
+			rr := httptest.NewRecorder()
+
+			RequireScope("tweets:write")(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestRequireSession(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	req := httptest.NewRequest(http.MethodGet, "/me/api-keys", nil)
+	rr := httptest.NewRecorder()
+	RequireSession(next).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	req = req.WithContext(WithScopes(req.Context(), []string{"tweets:write"}))
+	rr = httptest.NewRecorder()
+	RequireSession(next).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Contains(t, rr.Body.String(), "requires a user session")
+}
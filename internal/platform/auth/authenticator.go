@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"strings"
+)
+
+// Authenticator checks the credentials APIKeyMiddleware and Middleware
+// accept, for transports other than net/http such as gRPC.
+type Authenticator struct {
+	tokens   tokenVerifier
+	sessions sessionChecker
+	keys     apiKeyAuthenticator
+}
+
+// NewAuthenticator builds an Authenticator. A nil sessions skips the
+// session version check; a nil keys rejects API keys.
+func NewAuthenticator(tokens tokenVerifier, sessions sessionChecker, keys apiKeyAuthenticator) *Authenticator {
+	return &Authenticator{
+		tokens:   tokens,
+		sessions: sessions,
+		keys:     keys,
+	}
+}
+
+// Authenticate returns ctx carrying the caller of an API key or, without
+// one, of an "Authorization: Bearer" credential. Session lookup failures
+// wrap ErrSessionLookup; API key errors are returned as the lookup
+// reported them.
+func (a *Authenticator) Authenticate(ctx context.Context, authorization, apiKey string) (context.Context, error) {
+	if apiKey = strings.TrimSpace(apiKey); apiKey != "" {
+		if a.keys == nil {
+			return ctx, ErrInvalidToken
+		}
+		userID, scopes, err := a.keys.AuthenticateAPIKey(ctx, apiKey)
+		if err != nil {
+			return ctx, err
+		}
+		return WithScopes(WithUserID(ctx, userID), scopes), nil
+	}
+
+	token, ok := ParseBearer(authorization)
+	if !ok {
+		return ctx, ErrMissingToken
+	}
+	userID, err := verifyToken(ctx, a.tokens, a.sessions, token)
+	if err != nil {
+		return ctx, err
+	}
+	return WithUserID(ctx, userID), nil
+}
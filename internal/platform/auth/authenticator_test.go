@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	keys := APIKeyFunc(func(_ context.Context, key string) (string, []string, error) {
+		if key != "uak_good" {
+			return "", nil, errors.New("invalid api key")
+		}
+		return "usr_key", []string{"tweets:write"}, nil
+	})
+
+	tests := []struct {
+		name           string
+		authorization  string
+		apiKey         string
+		verifier       *fakeVerifier
+		sessions       *fakeSessions
+		expectErr      error
+		expectErrText  string
+		expectedUserID string
+		expectedScopes []string
+	}{
+		{
+			name:           "bearer token",
+			authorization:  "Bearer abc",
+			verifier:       &fakeVerifier{Claims: Claims{Subject: "usr_1", SessionVersion: 2}},
+			sessions:       &fakeSessions{Version: 2},
+			expectedUserID: "usr_1",
+		},
+		{
+			name:          "stale session",
+			authorization: "Bearer abc",
+			verifier:      &fakeVerifier{Claims: Claims{Subject: "usr_1", SessionVersion: 1}},
+			sessions:      &fakeSessions{Version: 2},
+			expectErr:     ErrRevokedToken,
+		},
+		{
+			name:          "session lookup failure",
+			authorization: "Bearer abc",
+			verifier:      &fakeVerifier{Claims: Claims{Subject: "usr_1"}},
+			sessions:      &fakeSessions{Err: errors.New("db down")},
+			expectErr:     ErrSessionLookup,
+		},
+		{
+			name:          "invalid token",
+			authorization: "Bearer abc",
+			verifier:      &fakeVerifier{Err: ErrExpiredToken},
+			expectErr:     ErrExpiredToken,
+		},
+		{
+			name:      "no credentials",
+			verifier:  &fakeVerifier{},
+			expectErr: ErrMissingToken,
+		},
+		{
+			name:           "api key wins over bearer",
+			authorization:  "Bearer abc",
+			apiKey:         "uak_good",
+			verifier:       &fakeVerifier{Claims: Claims{Subject: "usr_1"}},
+			expectedUserID: "usr_key",
+			expectedScopes: []string{"tweets:write"},
+		},
+		{
+			name:          "unknown api key",
+			apiKey:        "uak_bad",
+			verifier:      &fakeVerifier{},
+			expectErrText: "invalid api key",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var sessions sessionChecker
+			if tc.sessions != nil {
+				sessions = tc.sessions
+			}
+			a := NewAuthenticator(tc.verifier, sessions, keys)
+
+			ctx, err := a.Authenticate(context.Background(), tc.authorization, tc.apiKey)
+
+			switch {
+			case tc.expectErr != nil:
+				assert.ErrorIs(t, err, tc.expectErr)
+				return
+			case tc.expectErrText != "":
+				assert.ErrorContains(t, err, tc.expectErrText)
+				return
+			}
+			require.NoError(t, err)
+			userID, _ := UserIDFromContext(ctx)
+			assert.Equal(t, tc.expectedUserID, userID)
+			scopes, restricted := ScopesFromContext(ctx)
+			assert.Equal(t, tc.expectedScopes != nil, restricted)
+			assert.Equal(t, tc.expectedScopes, scopes)
+		})
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	session := WithUserID(context.Background(), "usr_1")
+	key := WithScopes(session, []string{"timeline:read"})
+
+	assert.True(t, Allowed(session, "tweets:write"))
+	assert.True(t, Allowed(key, "timeline:read"))
+	assert.False(t, Allowed(key, "tweets:write"))
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"ualaTwitter/internal/platform/httphelper"
+)
+
+var (
+	ErrMissingToken = errors.New("missing bearer token")
+	ErrRevokedToken = errors.New("token has been revoked")
+	// ErrSessionLookup wraps failures to load the user's session version.
+	ErrSessionLookup = errors.New("failed to validate session")
+)
+
+type tokenVerifier interface {
+	Verify(token string) (Claims, error)
+}
+
+type sessionChecker interface {
+	SessionVersion(ctx context.Context, userID string) (int64, error)
+}
+
+// SessionVersionFunc adapts a function to the session lookup used by
+// Middleware. It should return ErrRevokedToken when the user has no session.
+type SessionVersionFunc func(ctx context.Context, userID string) (int64, error)
+
+func (f SessionVersionFunc) SessionVersion(ctx context.Context, userID string) (int64, error) {
+	return f(ctx, userID)
+}
+
+// Middleware authenticates requests with an "Authorization: Bearer <jwt>"
+// header and stores the token subject in the request context. Tokens whose
+// session version is older than the user's current one are rejected; a nil
+// sessions skips that check.
+func Middleware(verifier tokenVerifier, sessions sessionChecker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok {
+				unauthorized(w, r, ErrMissingToken)
+				return
+			}
+
+			userID, err := verifyToken(r.Context(), verifier, sessions, token)
+			switch {
+			case errors.Is(err, ErrSessionLookup):
+				httphelper.RenderError(w, http.StatusInternalServerError, ErrSessionLookup.Error())
+				return
+			case err != nil:
+				unauthorized(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithUserID(r.Context(), userID)))
+		})
+	}
+}
+
+// verifyToken checks a bearer token and, unless sessions is nil, that it
+// belongs to the user's current session. It returns the token subject.
+func verifyToken(ctx context.Context, verifier tokenVerifier, sessions sessionChecker, token string) (string, error) {
+	claims, err := verifier.Verify(token)
+	if err != nil {
+		return "", err
+	}
+
+	if sessions != nil {
+		current, err := sessions.SessionVersion(ctx, claims.Subject)
+		switch {
+		case errors.Is(err, ErrRevokedToken):
+			return "", ErrRevokedToken
+		case err != nil:
+			return "", fmt.Errorf("%w: %v", ErrSessionLookup, err)
+		case claims.SessionVersion != current:
+			return "", ErrRevokedToken
+		}
+	}
+	return claims.Subject, nil
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	return ParseBearer(r.Header.Get("Authorization"))
+}
+
+// ParseBearer extracts the token of a "Bearer <token>" credential.
+func ParseBearer(header string) (string, bool) {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+func unauthorized(w http.ResponseWriter, r *http.Request, err error) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="uala-twitter"`)
+	httphelper.RenderProblem(w, r, http.StatusUnauthorized, err)
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeVerifier struct {
+	Claims Claims
+	Err    error
+	Token  string
+}
+
+func (f *fakeVerifier) Verify(token string) (Claims, error) {
+	f.Token = token
+	return f.Claims, f.Err
+}
+
+type fakeSessions struct {
+	Version int64
+	Err     error
+}
+
+func (f *fakeSessions) SessionVersion(_ context.Context, _ string) (int64, error) {
+	return f.Version, f.Err
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		authorization  string
+		verifier       *fakeVerifier
+		sessions       *fakeSessions
+		expectedStatus int
+		expectedUserID string
+		expectedToken  string
+	}{
+		{
+			name:           "valid bearer token",
+			authorization:  "Bearer abc.def.ghi",
+			verifier:       &fakeVerifier{Claims: Claims{Subject: "usr_123"}},
+			expectedStatus: http.StatusOK,
+			expectedUserID: "usr_123",
+			expectedToken:  "abc.def.ghi",
+		},
+		{
+			name:           "scheme is case insensitive",
+			authorization:  "bearer abc.def.ghi",
+			verifier:       &fakeVerifier{Claims: Claims{Subject: "usr_123"}},
+			expectedStatus: http.StatusOK,
+			expectedUserID: "usr_123",
+			expectedToken:  "abc.def.ghi",
+		},
+		{
+			name:           "missing header",
+			verifier:       &fakeVerifier{},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "wrong scheme",
+			authorization:  "Basic dXNlcjpwYXNz",
+			verifier:       &fakeVerifier{},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "invalid token",
+			authorization:  "Bearer forged",
+			verifier:       &fakeVerifier{Err: errors.New("bad signature")},
+			expectedStatus: http.StatusUnauthorized,
+			expectedToken:  "forged",
+		},
+		{
+			name:           "current session version",
+			authorization:  "Bearer abc.def.ghi",
+			verifier:       &fakeVerifier{Claims: Claims{Subject: "usr_123", SessionVersion: 2}},
+			sessions:       &fakeSessions{Version: 2},
+			expectedStatus: http.StatusOK,
+			expectedUserID: "usr_123",
+			expectedToken:  "abc.def.ghi",
+		},
+		{
+			name:           "token issued before a password change",
+			authorization:  "Bearer abc.def.ghi",
+			verifier:       &fakeVerifier{Claims: Claims{Subject: "usr_123", SessionVersion: 1}},
+			sessions:       &fakeSessions{Version: 2},
+			expectedStatus: http.StatusUnauthorized,
+			expectedToken:  "abc.def.ghi",
+		},
+		{
+			name:           "user without a session",
+			authorization:  "Bearer abc.def.ghi",
+			verifier:       &fakeVerifier{Claims: Claims{Subject: "usr_123"}},
+			sessions:       &fakeSessions{Err: ErrRevokedToken},
+			expectedStatus: http.StatusUnauthorized,
+			expectedToken:  "abc.def.ghi",
+		},
+		{
+			name:           "session lookup failure",
+			authorization:  "Bearer abc.def.ghi",
+			verifier:       &fakeVerifier{Claims: Claims{Subject: "usr_123"}},
+			sessions:       &fakeSessions{Err: errors.New("db down")},
+			expectedStatus: http.StatusInternalServerError,
+			expectedToken:  "abc.def.ghi",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotUserID string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotUserID, _ = UserIDFromContext(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/timeline", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			rr := httptest.NewRecorder()
+
+			var sessions sessionChecker
+			if tc.sessions != nil {
+				sessions = tc.sessions
+			}
+			Middleware(tc.verifier, sessions)(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			assert.Equal(t, tc.expectedUserID, gotUserID)
+			assert.Equal(t, tc.expectedToken, tc.verifier.Token)
+			if tc.expectedStatus == http.StatusUnauthorized {
+				assert.Contains(t, rr.Header().Get("WWW-Authenticate"), "Bearer")
+			}
+		})
+	}
+}
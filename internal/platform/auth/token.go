@@ -0,0 +1,244 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgEdDSA = "EdDSA"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token has expired")
+	ErrUnknownKey   = errors.New("token signed with an unknown key")
+	ErrInvalidKey   = errors.New("invalid signing key")
+)
+
+var b64 = base64.RawURLEncoding
+
+// Key is one signing key, identified in tokens by its kid. HS256 keys use
+// Secret; EdDSA keys use PrivateKey.
+type Key struct {
+	ID         string
+	Algorithm  string
+	Secret     []byte
+	PrivateKey ed25519.PrivateKey
+}
+
+type Claims struct {
+	Subject   string `json:"sub"`
+	Issuer    string `json:"iss,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	// SessionVersion ties the token to the user's credentials; it stops
+	// being accepted once the password changes.
+	SessionVersion int64 `json:"sv"`
+}
+
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+// TokenManager issues JWTs with the active key and verifies tokens signed
+// by any configured key, so keys can be rotated by adding a new key,
+// making it active and removing the old one once its tokens expire.
+type TokenManager struct {
+	keys      map[string]Key
+	activeKID string
+	issuer    string
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+func NewTokenManager(keys []Key, activeKID, issuer string, ttl time.Duration) (*TokenManager, error) {
+	byID := make(map[string]Key, len(keys))
+	for _, k := range keys {
+		if err := validateKey(k); err != nil {
+			return nil, err
+		}
+		byID[k.ID] = k
+	}
+
+	if _, ok := byID[activeKID]; !ok {
+		return nil, fmt.Errorf("%w: active kid %q is not configured", ErrInvalidKey, activeKID)
+	}
+
+	return &TokenManager{
+		keys:      byID,
+		activeKID: activeKID,
+		issuer:    issuer,
+		ttl:       ttl,
+		now:       time.Now,
+	}, nil
+}
+
+func (m *TokenManager) Issue(subject string, sessionVersion int64) (string, time.Time, error) {
+	now := m.now()
+	expiresAt := now.Add(m.ttl)
+
+	token, err := m.sign(Claims{
+		Subject:   subject,
+		Issuer:    m.issuer,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+
+		SessionVersion: sessionVersion,
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+func (m *TokenManager) Verify(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrInvalidToken
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	key, ok := m.keys[h.KeyID]
+	if !ok {
+		return Claims{}, ErrUnknownKey
+	}
+	// The algorithm is pinned by the key, never taken from the token.
+	if h.Algorithm != key.Algorithm {
+		return Claims{}, ErrInvalidToken
+	}
+
+	signature, err := b64.DecodeString(parts[2])
+	if err != nil || !verify(key, parts[0]+"."+parts[1], signature) {
+		return Claims{}, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+	if claims.Subject == "" || (m.issuer != "" && claims.Issuer != m.issuer) {
+		return Claims{}, ErrInvalidToken
+	}
+	if m.now().Unix() >= claims.ExpiresAt {
+		return Claims{}, ErrExpiredToken
+	}
+
+	return claims, nil
+}
+
+func (m *TokenManager) sign(claims Claims) (string, error) {
+	key := m.keys[m.activeKID]
+
+	h, err := json.Marshal(header{Algorithm: key.Algorithm, Type: "JWT", KeyID: key.ID})
+	if err != nil {
+		return "", err
+	}
+	c, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := b64.EncodeToString(h) + "." + b64.EncodeToString(c)
+	return signingInput + "." + b64.EncodeToString(signature(key, signingInput)), nil
+}
+
+func signature(key Key, signingInput string) []byte {
+	switch key.Algorithm {
+	case AlgEdDSA:
+		return ed25519.Sign(key.PrivateKey, []byte(signingInput))
+	default:
+		mac := hmac.New(sha256.New, key.Secret)
+		mac.Write([]byte(signingInput))
+		return mac.Sum(nil)
+	}
+}
+
+func verify(key Key, signingInput string, sig []byte) bool {
+	switch key.Algorithm {
+	case AlgEdDSA:
+		return ed25519.Verify(key.PrivateKey.Public().(ed25519.PublicKey), []byte(signingInput), sig)
+	default:
+		return hmac.Equal(signature(key, signingInput), sig)
+	}
+}
+
+func decodeSegment(segment string, v any) error {
+	raw, err := b64.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+func validateKey(k Key) error {
+	if k.ID == "" {
+		return fmt.Errorf("%w: empty kid", ErrInvalidKey)
+	}
+	switch k.Algorithm {
+	case AlgHS256:
+		if len(k.Secret) < 32 {
+			return fmt.Errorf("%w: HS256 key %q must be at least 32 bytes", ErrInvalidKey, k.ID)
+		}
+	case AlgEdDSA:
+		if len(k.PrivateKey) != ed25519.PrivateKeySize {
+			return fmt.Errorf("%w: EdDSA key %q is not an ed25519 private key", ErrInvalidKey, k.ID)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q for key %q", ErrInvalidKey, k.Algorithm, k.ID)
+	}
+	return nil
+}
+
+// ParseKeys reads a comma separated list of kid:alg:base64 entries, where
+// the material is the HMAC secret for HS256 and the 32 byte seed for EdDSA.
+func ParseKeys(spec string) ([]Key, error) {
+	var keys []Key
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		fields := strings.SplitN(entry, ":", 3)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%w: expected kid:alg:base64, got %q", ErrInvalidKey, entry)
+		}
+
+		material, err := base64.StdEncoding.DecodeString(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %q is not valid base64", ErrInvalidKey, fields[0])
+		}
+
+		key := Key{ID: fields[0], Algorithm: fields[1]}
+		switch key.Algorithm {
+		case AlgEdDSA:
+			if len(material) != ed25519.SeedSize {
+				return nil, fmt.Errorf("%w: EdDSA key %q must be a 32 byte seed", ErrInvalidKey, key.ID)
+			}
+			key.PrivateKey = ed25519.NewKeyFromSeed(material)
+		default:
+			key.Secret = material
+		}
+
+		if err := validateKey(key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
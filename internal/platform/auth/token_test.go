@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	hsSecret = []byte("0123456789abcdef0123456789abcdef")
+	edSeed   = []byte("fedcba9876543210fedcba9876543210")
+)
+
+func newTestManager(t *testing.T, activeKID string, keys ...Key) *TokenManager {
+	t.Helper()
+	m, err := NewTokenManager(keys, activeKID, "uala-twitter", time.Hour)
+	require.NoError(t, err)
+	return m
+}
+
+func TestTokenManager_IssueAndVerify(t *testing.T) {
+	hsKey := Key{ID: "hs-1", Algorithm: AlgHS256, Secret: hsSecret}
+	edKey := Key{ID: "ed-1", Algorithm: AlgEdDSA, PrivateKey: ed25519.NewKeyFromSeed(edSeed)}
+
+	for _, key := range []Key{hsKey, edKey} {
+		t.Run(key.Algorithm+" round trip", func(t *testing.T) {
+			m := newTestManager(t, key.ID, key)
+
+			token, expiresAt, err := m.Issue("usr_123", 7)
+			require.NoError(t, err)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, 2*time.Second)
+
+			claims, err := m.Verify(token)
+			require.NoError(t, err)
+			assert.Equal(t, "usr_123", claims.Subject)
+			assert.Equal(t, "uala-twitter", claims.Issuer)
+			assert.Equal(t, int64(7), claims.SessionVersion)
+		})
+	}
+
+	t.Run("tokens signed with a rotated-out key remain valid while configured", func(t *testing.T) {
+		oldManager := newTestManager(t, hsKey.ID, hsKey)
+		token, _, err := oldManager.Issue("usr_123", 0)
+		require.NoError(t, err)
+
+		rotated := newTestManager(t, edKey.ID, edKey, hsKey)
+		claims, err := rotated.Verify(token)
+		require.NoError(t, err)
+		assert.Equal(t, "usr_123", claims.Subject)
+
+		newToken, _, err := rotated.Issue("usr_123", 0)
+		require.NoError(t, err)
+		_, err = oldManager.Verify(newToken)
+		assert.ErrorIs(t, err, ErrUnknownKey)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		m := newTestManager(t, hsKey.ID, hsKey)
+		m.now = func() time.Time { return time.Now().Add(-2 * time.Hour) }
+		token, _, err := m.Issue("usr_123", 0)
+		require.NoError(t, err)
+
+		m.now = time.Now
+		_, err = m.Verify(token)
+		assert.ErrorIs(t, err, ErrExpiredToken)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		m := newTestManager(t, hsKey.ID, hsKey)
+		token, _, err := m.Issue("usr_123", 0)
+		require.NoError(t, err)
+
+		parts := strings.Split(token, ".")
+		parts[1] = b64.EncodeToString([]byte(`{"sub":"usr_admin","iss":"uala-twitter","iat":0,"exp":9999999999}`))
+		_, err = m.Verify(strings.Join(parts, "."))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("algorithm in the token cannot override the key", func(t *testing.T) {
+		m := newTestManager(t, hsKey.ID, hsKey)
+		token, _, err := m.Issue("usr_123", 0)
+		require.NoError(t, err)
+
+		parts := strings.Split(token, ".")
+		parts[0] = b64.EncodeToString([]byte(`{"alg":"none","typ":"JWT","kid":"hs-1"}`))
+		_, err = m.Verify(strings.Join(parts[:2], ".") + ".")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("wrong issuer", func(t *testing.T) {
+		other, err := NewTokenManager([]Key{hsKey}, hsKey.ID, "someone-else", time.Hour)
+		require.NoError(t, err)
+		token, _, err := other.Issue("usr_123", 0)
+		require.NoError(t, err)
+
+		_, err = newTestManager(t, hsKey.ID, hsKey).Verify(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("malformed tokens", func(t *testing.T) {
+		m := newTestManager(t, hsKey.ID, hsKey)
+		for _, token := range []string{"", "abc", "a.b", "a.b.c", "!!.!!.!!"} {
+			_, err := m.Verify(token)
+			assert.Error(t, err, token)
+		}
+	})
+}
+
+func TestNewTokenManager_Validation(t *testing.T) {
+	tests := []struct {
+		name      string
+		keys      []Key
+		activeKID string
+	}{
+		{name: "active kid missing", keys: []Key{{ID: "a", Algorithm: AlgHS256, Secret: hsSecret}}, activeKID: "b"},
+		{name: "short HS256 secret", keys: []Key{{ID: "a", Algorithm: AlgHS256, Secret: []byte("short")}}, activeKID: "a"},
+		{name: "missing EdDSA key", keys: []Key{{ID: "a", Algorithm: AlgEdDSA}}, activeKID: "a"},
+		{name: "unsupported algorithm", keys: []Key{{ID: "a", Algorithm: "RS256", Secret: hsSecret}}, activeKID: "a"},
+		{name: "empty kid", keys: []Key{{Algorithm: AlgHS256, Secret: hsSecret}}, activeKID: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewTokenManager(tc.keys, tc.activeKID, "", time.Hour)
+			assert.ErrorIs(t, err, ErrInvalidKey)
+		})
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString(hsSecret)
+	seed := base64.StdEncoding.EncodeToString(edSeed)
+
+	t.Run("parses HS256 and EdDSA keys", func(t *testing.T) {
+		keys, err := ParseKeys("2025-06:EdDSA:" + seed + ", 2025-01:HS256:" + secret)
+
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+		assert.Equal(t, "2025-06", keys[0].ID)
+		assert.Equal(t, ed25519.NewKeyFromSeed(edSeed), keys[0].PrivateKey)
+		assert.Equal(t, "2025-01", keys[1].ID)
+		assert.Equal(t, hsSecret, keys[1].Secret)
+	})
+
+	t.Run("empty spec yields no keys", func(t *testing.T) {
+		keys, err := ParseKeys("")
+		assert.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+
+	for _, spec := range []string{"nokind", "k:HS256:not-base64!", "k:EdDSA:" + secret[:10], "k:HS256:c2hvcnQ="} {
+		t.Run("rejects "+spec, func(t *testing.T) {
+			_, err := ParseKeys(spec)
+			assert.ErrorIs(t, err, ErrInvalidKey)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrClosed = errors.New("cache store is closed")
+
+// Store keeps cached values grouped under a key, so that every field of a
+// key (e.g. every page of a user's timeline) can be dropped at once.
+type Store interface {
+	Get(ctx context.Context, key, field string) ([]byte, bool, error)
+	Set(ctx context.Context, key, field string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Invalidator drops every cached read that belongs to the given users.
+type Invalidator interface {
+	Invalidate(ctx context.Context, userIDs ...string) error
+}
+
+type NopInvalidator struct{}
+
+func (NopInvalidator) Invalidate(_ context.Context, _ ...string) error {
+	return nil
+}
+
+// Invalidators notifies several invalidators in order. Every one is called
+// even if an earlier one fails; the first error is returned.
+type Invalidators []Invalidator
+
+func (is Invalidators) Invalidate(ctx context.Context, userIDs ...string) error {
+	var first error
+	for _, i := range is {
+		if err := i.Invalidate(ctx, userIDs...); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,30 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingInvalidator struct {
+	users []string
+	err   error
+}
+
+func (r *recordingInvalidator) Invalidate(_ context.Context, userIDs ...string) error {
+	r.users = append(r.users, userIDs...)
+	return r.err
+}
+
+func TestInvalidators(t *testing.T) {
+	failing := &recordingInvalidator{err: errors.New("redis down")}
+	next := &recordingInvalidator{}
+
+	err := Invalidators{failing, next, NopInvalidator{}}.Invalidate(context.Background(), "u1", "u2")
+
+	assert.EqualError(t, err, "redis down")
+	assert.Equal(t, []string{"u1", "u2"}, failing.users)
+	assert.Equal(t, []string{"u1", "u2"}, next.users)
+}
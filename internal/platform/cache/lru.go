@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+type lruEntry struct {
+	key       string
+	field     string
+	value     []byte
+	expiresAt time.Time
+}
+
+// LRUStore is an in-process Store bounded by the total number of fields.
+type LRUStore struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]map[string]*list.Element
+	now      func() time.Time
+}
+
+func NewLRUStore(capacity int) *LRUStore {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &LRUStore{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+func (s *LRUStore) Get(_ context.Context, key, field string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.entries[key][field]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := el.Value.(*lruEntry)
+	if !entry.expiresAt.IsZero() && s.now().After(entry.expiresAt) {
+		s.remove(el)
+		return nil, false, nil
+	}
+
+	s.order.MoveToFront(el)
+	return entry.value, true, nil
+}
+
+func (s *LRUStore) Set(_ context.Context, key, field string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = s.now().Add(ttl)
+	}
+
+	if el, ok := s.entries[key][field]; ok {
+		entry := el.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		s.order.MoveToFront(el)
+		return nil
+	}
+
+	if s.entries[key] == nil {
+		s.entries[key] = make(map[string]*list.Element)
+	}
+	s.entries[key][field] = s.order.PushFront(&lruEntry{
+		key:       key,
+		field:     field,
+		value:     value,
+		expiresAt: expiresAt,
+	})
+
+	for s.order.Len() > s.capacity {
+		s.remove(s.order.Back())
+	}
+	return nil
+}
+
+func (s *LRUStore) Delete(_ context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		for _, el := range s.entries[key] {
+			s.order.Remove(el)
+		}
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+func (s *LRUStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.order.Len()
+}
+
+func (s *LRUStore) remove(el *list.Element) {
+	entry := s.order.Remove(el).(*lruEntry)
+	delete(s.entries[entry.key], entry.field)
+	if len(s.entries[entry.key]) == 0 {
+		delete(s.entries, entry.key)
+	}
+}
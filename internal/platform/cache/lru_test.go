@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Set and Get round trip", func(t *testing.T) {
+		store := NewLRUStore(10)
+		require.NoError(t, store.Set(ctx, "usr_1", "page0", []byte("a"), 0))
+
+		value, ok, err := store.Get(ctx, "usr_1", "page0")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("a"), value)
+	})
+
+	t.Run("Get misses unknown field", func(t *testing.T) {
+		store := NewLRUStore(10)
+		_, ok, err := store.Get(ctx, "usr_1", "page0")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("evicts least recently used field when full", func(t *testing.T) {
+		store := NewLRUStore(2)
+		require.NoError(t, store.Set(ctx, "usr_1", "a", []byte("a"), 0))
+		require.NoError(t, store.Set(ctx, "usr_2", "b", []byte("b"), 0))
+		_, _, _ = store.Get(ctx, "usr_1", "a")
+		require.NoError(t, store.Set(ctx, "usr_3", "c", []byte("c"), 0))
+
+		_, okA, _ := store.Get(ctx, "usr_1", "a")
+		_, okB, _ := store.Get(ctx, "usr_2", "b")
+		assert.True(t, okA)
+		assert.False(t, okB)
+		assert.Equal(t, 2, store.Len())
+	})
+
+	t.Run("expired entries are misses", func(t *testing.T) {
+		store := NewLRUStore(10)
+		now := time.Now()
+		store.now = func() time.Time { return now }
+		require.NoError(t, store.Set(ctx, "usr_1", "a", []byte("a"), time.Second))
+
+		store.now = func() time.Time { return now.Add(2 * time.Second) }
+		_, ok, _ := store.Get(ctx, "usr_1", "a")
+		assert.False(t, ok)
+		assert.Equal(t, 0, store.Len())
+	})
+
+	t.Run("Delete drops every field of a key", func(t *testing.T) {
+		store := NewLRUStore(10)
+		require.NoError(t, store.Set(ctx, "usr_1", "a", []byte("a"), 0))
+		require.NoError(t, store.Set(ctx, "usr_1", "b", []byte("b"), 0))
+		require.NoError(t, store.Set(ctx, "usr_2", "a", []byte("a"), 0))
+
+		require.NoError(t, store.Delete(ctx, "usr_1"))
+
+		_, okA, _ := store.Get(ctx, "usr_1", "a")
+		_, okB, _ := store.Get(ctx, "usr_1", "b")
+		_, okOther, _ := store.Get(ctx, "usr_2", "a")
+		assert.False(t, okA)
+		assert.False(t, okB)
+		assert.True(t, okOther)
+	})
+}
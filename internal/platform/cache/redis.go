@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultRedisTimeout = 2 * time.Second
+
+// RedisConfig connects a RedisStore.
+type RedisConfig struct {
+	Addr     string
+	Username string
+	Password string
+	// TLS encrypts the connection, verifying the server against the system
+	// roots.
+	TLS      bool
+	PoolSize int
+}
+
+// RedisStore is a Store backed by Redis. Each field is its own string key
+// with its own expiry, and a set under the key indexes them, so a single
+// Delete drops every field of the key without stretching their TTLs.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// deletePages drops each index set in KEYS along with the pages it lists.
+// It runs atomically, so no Set can slip a page in between.
+var deletePages = redis.NewScript(`
+for _, key in ipairs(KEYS) do
+	for _, page in ipairs(redis.call('SMEMBERS', key)) do
+		redis.call('DEL', page)
+	end
+	redis.call('DEL', key)
+end
+return 0
+`)
+
+func NewRedisStore(cfg RedisConfig) *RedisStore {
+	return &RedisStore{client: NewRedisClient(cfg)}
+}
+
+// NewRedisClient connects with cfg, for other stores that share the Redis
+// settings.
+func NewRedisClient(cfg RedisConfig) *redis.Client {
+	opts := &redis.Options{
+		Addr:         cfg.Addr,
+		Username:     cfg.Username,
+		Password:     cfg.Password,
+		PoolSize:     cfg.PoolSize,
+		DialTimeout:  defaultRedisTimeout,
+		ReadTimeout:  defaultRedisTimeout,
+		WriteTimeout: defaultRedisTimeout,
+	}
+	if cfg.TLS {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	return redis.NewClient(opts)
+}
+
+func (s *RedisStore) Get(ctx context.Context, key, field string) ([]byte, bool, error) {
+	value, err := s.client.Get(ctx, pageKey(key, field)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, storeError(err)
+	}
+	return value, true, nil
+}
+
+// Set stores the page and adds it to the key's index. The index takes the
+// page's TTL: every page of a store shares it, so the index never expires
+// before a page it lists.
+func (s *RedisStore) Set(ctx context.Context, key, field string, value []byte, ttl time.Duration) error {
+	page := pageKey(key, field)
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, page, value, ttl)
+		pipe.SAdd(ctx, key, page)
+		if ttl > 0 {
+			pipe.PExpire(ctx, key, ttl)
+		} else {
+			pipe.Persist(ctx, key)
+		}
+		return nil
+	})
+	return storeError(err)
+}
+
+func (s *RedisStore) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return storeError(deletePages.Run(ctx, s.client, keys).Err())
+}
+
+func (s *RedisStore) Ping(ctx context.Context) error {
+	return storeError(s.client.Ping(ctx).Err())
+}
+
+func (s *RedisStore) Close() error {
+	return storeError(s.client.Close())
+}
+
+func pageKey(key, field string) string {
+	return key + "/" + field
+}
+
+func storeError(err error) error {
+	if errors.Is(err, redis.ErrClosed) {
+		return ErrClosed
+	}
+	return err
+}
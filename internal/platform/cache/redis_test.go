@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisStore(t *testing.T) {
+	ctx := context.Background()
+
+	server := miniredis.RunT(t)
+	store := NewRedisStore(RedisConfig{Addr: server.Addr(), PoolSize: 2})
+	t.Cleanup(func() { _ = store.Close() })
+
+	t.Run("Ping", func(t *testing.T) {
+		assert.NoError(t, store.Ping(ctx))
+	})
+
+	t.Run("Set and Get round trip", func(t *testing.T) {
+		require.NoError(t, store.Set(ctx, "timeline:usr_1", "page0", []byte(`[{"id":"t1"}]`), time.Minute))
+
+		value, ok, err := store.Get(ctx, "timeline:usr_1", "page0")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, `[{"id":"t1"}]`, string(value))
+		assert.Equal(t, time.Minute, server.TTL("timeline:usr_1/page0"))
+	})
+
+	t.Run("Get misses unknown field", func(t *testing.T) {
+		_, ok, err := store.Get(ctx, "timeline:usr_1", "missing")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("writing a page does not extend the others", func(t *testing.T) {
+		require.NoError(t, store.Set(ctx, "timeline:usr_3", "old", []byte("old"), time.Minute))
+		server.FastForward(40 * time.Second)
+		require.NoError(t, store.Set(ctx, "timeline:usr_3", "new", []byte("new"), time.Minute))
+		server.FastForward(30 * time.Second)
+
+		_, okOld, err := store.Get(ctx, "timeline:usr_3", "old")
+		require.NoError(t, err)
+		_, okNew, err := store.Get(ctx, "timeline:usr_3", "new")
+		require.NoError(t, err)
+		assert.False(t, okOld)
+		assert.True(t, okNew)
+	})
+
+	t.Run("Delete drops every field of a key", func(t *testing.T) {
+		require.NoError(t, store.Set(ctx, "timeline:usr_2", "a", []byte("a"), 0))
+		require.NoError(t, store.Set(ctx, "timeline:usr_2", "b", []byte("b"), 0))
+		require.NoError(t, store.Set(ctx, "timeline:usr_4", "a", []byte("a"), 0))
+
+		require.NoError(t, store.Delete(ctx, "timeline:usr_2"))
+
+		_, okA, _ := store.Get(ctx, "timeline:usr_2", "a")
+		_, okB, _ := store.Get(ctx, "timeline:usr_2", "b")
+		_, okOther, _ := store.Get(ctx, "timeline:usr_4", "a")
+		assert.False(t, okA)
+		assert.False(t, okB)
+		assert.True(t, okOther)
+		assert.False(t, server.Exists("timeline:usr_2"))
+	})
+
+	t.Run("password is sent", func(t *testing.T) {
+		secured := miniredis.RunT(t)
+		secured.RequireAuth("s3cret")
+
+		authed := NewRedisStore(RedisConfig{Addr: secured.Addr(), Password: "s3cret", PoolSize: 1})
+		t.Cleanup(func() { _ = authed.Close() })
+		anonymous := NewRedisStore(RedisConfig{Addr: secured.Addr(), PoolSize: 1})
+		t.Cleanup(func() { _ = anonymous.Close() })
+
+		assert.NoError(t, authed.Ping(ctx))
+		assert.Error(t, anonymous.Ping(ctx))
+	})
+
+	t.Run("unreachable server fails", func(t *testing.T) {
+		unreachable := NewRedisStore(RedisConfig{Addr: "127.0.0.1:1", PoolSize: 1})
+		t.Cleanup(func() { _ = unreachable.Close() })
+		_, _, err := unreachable.Get(ctx, "k", "f")
+		assert.Error(t, err)
+	})
+
+	t.Run("closed store fails", func(t *testing.T) {
+		closed := NewRedisStore(RedisConfig{Addr: server.Addr(), PoolSize: 1})
+		require.NoError(t, closed.Close())
+		assert.ErrorIs(t, closed.Ping(ctx), ErrClosed)
+	})
+}
@@ -0,0 +1,106 @@
+// Package dataloader batches lookups by key. Resolvers of a GraphQL level
+// each ask for one key and get a thunk back; the first thunk evaluated
+// fetches every key queued so far in one call, so a list of N items costs
+// one repository call instead of N.
+package dataloader
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchFunc fetches keys at once. Keys missing from the result are
+// reported as not found.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader queues keys until a thunk is evaluated and caches every result,
+// errors included, for its lifetime: use one loader per request.
+type Loader[K comparable, V any] struct {
+	batch BatchFunc[K, V]
+
+	mu      sync.Mutex
+	pending []K
+	results map[K]*result[V]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	found bool
+	err   error
+}
+
+func New[K comparable, V any](batch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		batch:   batch,
+		results: make(map[K]*result[V]),
+	}
+}
+
+// Load queues key and returns a thunk yielding its value, whether it was
+// found and the batch error.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) func() (V, bool, error) {
+	l.mu.Lock()
+	res, ok := l.results[key]
+	if !ok {
+		res = &result[V]{done: make(chan struct{})}
+		l.results[key] = res
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, bool, error) {
+		l.dispatch(ctx)
+		<-res.done
+		return res.value, res.found, res.err
+	}
+}
+
+// LoadMany queues keys and returns a thunk yielding the values found, in
+// key order.
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) func() ([]V, error) {
+	thunks := make([]func() (V, bool, error), len(keys))
+	for i, key := range keys {
+		thunks[i] = l.Load(ctx, key)
+	}
+
+	return func() ([]V, error) {
+		values := make([]V, 0, len(keys))
+		for _, thunk := range thunks {
+			value, found, err := thunk()
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				values = append(values, value)
+			}
+		}
+		return values, nil
+	}
+}
+
+// dispatch fetches the pending keys, if any.
+func (l *Loader[K, V]) dispatch(ctx context.Context) {
+	l.mu.Lock()
+	keys := l.pending
+	l.pending = nil
+	batch := make([]*result[V], len(keys))
+	for i, key := range keys {
+		batch[i] = l.results[key]
+	}
+	l.mu.Unlock()
+
+	if len(keys) == 0 {
+		return
+	}
+
+	values, err := l.batch(ctx, keys)
+	for i, key := range keys {
+		res := batch[i]
+		res.err = err
+		if err == nil {
+			res.value, res.found = values[key]
+		}
+		close(res.done)
+	}
+}
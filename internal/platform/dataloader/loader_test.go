@@ -0,0 +1,108 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]string
+	err     error
+}
+
+func (r *recorder) fetch(_ context.Context, keys []string) (map[string]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.batches = append(r.batches, keys)
+	if r.err != nil {
+		return nil, r.err
+	}
+	values := map[string]int{}
+	for _, key := range keys {
+		if key != "missing" {
+			values[key] = len(key)
+		}
+	}
+	return values, nil
+}
+
+func TestLoader_BatchesQueuedKeys(t *testing.T) {
+	rec := &recorder{}
+	loader := New(rec.fetch)
+	ctx := context.Background()
+
+	a := loader.Load(ctx, "a")
+	bb := loader.Load(ctx, "bb")
+	again := loader.Load(ctx, "a")
+	missing := loader.Load(ctx, "missing")
+
+	value, found, err := bb()
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 2, value)
+
+	value, found, err = a()
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, 1, value)
+
+	_, _, _ = again()
+	_, found, err = missing()
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	assert.Equal(t, [][]string{{"a", "bb", "missing"}}, rec.batches)
+}
+
+func TestLoader_CachesAcrossBatches(t *testing.T) {
+	rec := &recorder{}
+	loader := New(rec.fetch)
+	ctx := context.Background()
+
+	_, _, _ = loader.Load(ctx, "a")()
+	values, err := loader.LoadMany(ctx, []string{"a", "ccc", "missing"})()
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 3}, values)
+	assert.Equal(t, [][]string{{"a"}, {"ccc", "missing"}}, rec.batches)
+}
+
+func TestLoader_BatchError(t *testing.T) {
+	boom := errors.New("boom")
+	loader := New((&recorder{err: boom}).fetch)
+
+	_, err := loader.LoadMany(context.Background(), []string{"a", "b"})()
+	assert.ErrorIs(t, err, boom)
+}
+
+func TestLoader_ConcurrentThunks(t *testing.T) {
+	rec := &recorder{}
+	loader := New(rec.fetch)
+	ctx := context.Background()
+
+	thunks := make([]func() (int, bool, error), 50)
+	for i := range thunks {
+		thunks[i] = loader.Load(ctx, string(rune('a'+i%26)))
+	}
+
+	var wg sync.WaitGroup
+	for _, thunk := range thunks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, found, err := thunk()
+			assert.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, 1, value)
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, rec.batches, 1)
+}
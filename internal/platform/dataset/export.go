@@ -0,0 +1,107 @@
+package dataset
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/domain/user"
+)
+
+// Source lists every entity of a backend.
+type Source interface {
+	Users(ctx context.Context) ([]user.User, error)
+	Follows(ctx context.Context) ([]Follow, error)
+	Tweets(ctx context.Context) ([]tweet.Tweet, error)
+	Likes(ctx context.Context) ([]Like, error)
+}
+
+// Counts is the number of lines by record type.
+type Counts map[string]int
+
+// Export writes every entity of src to w, users first, then follows,
+// tweets and likes, each sorted so that equal datasets export equal
+// files. Nothing is written unless every entity could be read.
+func Export(ctx context.Context, src Source, w io.Writer) (Counts, error) {
+	users, err := src.Users(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+	follows, err := src.Follows(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list follows: %w", err)
+	}
+	tweets, err := src.Tweets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list tweets: %w", err)
+	}
+	likes, err := src.Likes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list likes: %w", err)
+	}
+
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	sort.Slice(follows, func(i, j int) bool {
+		if follows[i].FollowerID != follows[j].FollowerID {
+			return follows[i].FollowerID < follows[j].FollowerID
+		}
+		return follows[i].FolloweeID < follows[j].FolloweeID
+	})
+	sort.Slice(tweets, func(i, j int) bool {
+		if !tweets[i].CreatedAt.Equal(tweets[j].CreatedAt) {
+			return tweets[i].CreatedAt.Before(tweets[j].CreatedAt)
+		}
+		return tweets[i].ID < tweets[j].ID
+	})
+	sort.Slice(likes, func(i, j int) bool {
+		if likes[i].UserID != likes[j].UserID {
+			return likes[i].UserID < likes[j].UserID
+		}
+		return likes[i].TweetID < likes[j].TweetID
+	})
+
+	buf := bufio.NewWriter(w)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	counts := Counts{}
+	write := func(r record) error {
+		counts[r.Type]++
+		return enc.Encode(r)
+	}
+
+	for _, u := range users {
+		if err := write(record{Type: TypeUser, ID: u.ID, Name: u.Name, Document: u.Document, CreatedAt: optionalTime(u.CreatedAt)}); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range follows {
+		if err := write(record{Type: TypeFollow, FollowerID: f.FollowerID, FolloweeID: f.FolloweeID}); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range tweets {
+		if err := write(record{Type: TypeTweet, ID: t.ID, UserID: t.UserID, Content: t.Content, CreatedAt: optionalTime(t.CreatedAt)}); err != nil {
+			return nil, err
+		}
+	}
+	for _, l := range likes {
+		if err := write(record{Type: TypeLike, UserID: l.UserID, TweetID: l.TweetID}); err != nil {
+			return nil, err
+		}
+	}
+
+	return counts, buf.Flush()
+}
+
+// Total is the number of lines of every type.
+func (c Counts) Total() int {
+	total := 0
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
@@ -0,0 +1,88 @@
+package dataset
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/repository/memory"
+)
+
+func newMemoryBackend() *MemoryBackend {
+	return NewMemoryBackend(memory.NewInMemoryUserRepository(), memory.NewInMemoryTweetRepository(), memory.NewInMemoryLikeRepository(), memory.NewInMemoryUserRepository())
+}
+
+// seed stores two users following each other, a tweet each and a like.
+func seed(t *testing.T, b *MemoryBackend) {
+	t.Helper()
+	ctx := context.Background()
+	at := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	require.NoError(t, b.users.Create(ctx, user.User{ID: "usr_1111111", Name: "Ana", Document: "1111111", CreatedAt: at}))
+	require.NoError(t, b.users.Create(ctx, user.User{ID: "usr_2222222", Name: "Bruno", Document: "2222222"}))
+	require.NoError(t, b.users.Follow(ctx, "usr_2222222", "usr_1111111"))
+	require.NoError(t, b.users.Follow(ctx, "usr_1111111", "usr_2222222"))
+	require.NoError(t, b.tweets.Save(ctx, tweet.Tweet{ID: "t2", UserID: "usr_2222222", Content: "second", CreatedAt: at.Add(time.Minute)}))
+	require.NoError(t, b.tweets.Save(ctx, tweet.Tweet{ID: "t1", UserID: "usr_1111111", Content: "<first>", CreatedAt: at, Likes: 1}))
+	require.NoError(t, b.likes.Like(ctx, "usr_2222222", "t1"))
+}
+
+const seededDataset = `{"type":"user","id":"usr_1111111","name":"Ana","document":"1111111","created_at":"2024-03-01T10:00:00Z"}
+{"type":"user","id":"usr_2222222","name":"Bruno","document":"2222222"}
+{"type":"follow","follower_id":"usr_1111111","followee_id":"usr_2222222"}
+{"type":"follow","follower_id":"usr_2222222","followee_id":"usr_1111111"}
+{"type":"tweet","id":"t1","user_id":"usr_1111111","content":"<first>","created_at":"2024-03-01T10:00:00Z"}
+{"type":"tweet","id":"t2","user_id":"usr_2222222","content":"second","created_at":"2024-03-01T10:01:00Z"}
+{"type":"like","user_id":"usr_2222222","tweet_id":"t1"}
+`
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("writes every entity in reference order", func(t *testing.T) {
+		backend := newMemoryBackend()
+		seed(t, backend)
+
+		var out bytes.Buffer
+		counts, err := Export(ctx, backend, &out)
+		require.NoError(t, err)
+		assert.Equal(t, seededDataset, out.String())
+		assert.Equal(t, Counts{TypeUser: 2, TypeFollow: 2, TypeTweet: 2, TypeLike: 1}, counts)
+	})
+
+	t.Run("empty backend writes nothing", func(t *testing.T) {
+		var out bytes.Buffer
+		counts, err := Export(ctx, newMemoryBackend(), &out)
+		require.NoError(t, err)
+		assert.Empty(t, out.String())
+		assert.Empty(t, counts)
+	})
+
+	t.Run("round trip keeps IDs, times and like counters", func(t *testing.T) {
+		source := newMemoryBackend()
+		seed(t, source)
+		var first bytes.Buffer
+		_, err := Export(ctx, source, &first)
+		require.NoError(t, err)
+
+		target := newMemoryBackend()
+		report, err := NewImporter(target).Import(ctx, bytes.NewReader(first.Bytes()))
+		require.NoError(t, err)
+		assert.Empty(t, report.Rejected)
+
+		var second bytes.Buffer
+		_, err = Export(ctx, target, &second)
+		require.NoError(t, err)
+		assert.Equal(t, first.String(), second.String())
+
+		liked, err := target.tweets.GetByID(ctx, "t1")
+		require.NoError(t, err)
+		assert.Equal(t, 1, liked.Likes)
+	})
+}
@@ -0,0 +1,158 @@
+package dataset
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"ualaTwitter/internal/domain/like"
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/domain/user"
+)
+
+// maxLineSize bounds a single line; longer ones stop the import.
+const maxLineSize = 1 << 20
+
+// Sink stores imported entities. It rejects references to entities it does
+// not hold and returns ErrUnsupported for types its backend does not store.
+type Sink interface {
+	CreateUser(ctx context.Context, u user.User) error
+	Follow(ctx context.Context, f Follow) error
+	SaveTweet(ctx context.Context, t tweet.Tweet) error
+	Like(ctx context.Context, l Like) error
+}
+
+// Report sums up an import. Line numbers start at 1.
+type Report struct {
+	Imported Counts      `json:"imported"`
+	Skipped  Counts      `json:"skipped"`
+	Rejected []Rejection `json:"rejected"`
+}
+
+// Rejection is a line that was not imported, and why.
+type Rejection struct {
+	Line   int    `json:"line"`
+	Type   string `json:"type,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// Importer validates lines with the domain constructors and stores the
+// valid ones in a Sink.
+type Importer struct {
+	sink Sink
+	// MaxContentLength caps tweet length, in characters, as when posting.
+	MaxContentLength int
+}
+
+func NewImporter(sink Sink) *Importer {
+	return &Importer{
+		sink:             sink,
+		MaxContentLength: tweet.MaxContentLength,
+	}
+}
+
+// Import stores every valid line of r and reports the others. Lines are
+// independent: a rejected line never stops the ones after it. Only a read
+// failure or a cancelled ctx ends the import early, with the lines before
+// it already stored.
+func (im *Importer) Import(ctx context.Context, r io.Reader) (Report, error) {
+	report := Report{Imported: Counts{}, Skipped: Counts{}, Rejected: []Rejection{}}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		rec, err := decode(raw)
+		if err == nil {
+			err = im.store(ctx, rec)
+		}
+		switch {
+		case err == nil:
+			report.Imported[rec.Type]++
+		case errors.Is(err, ErrUnsupported):
+			report.Skipped[rec.Type]++
+		default:
+			report.Rejected = append(report.Rejected, Rejection{Line: line, Type: rec.Type, Reason: err.Error()})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return report, fmt.Errorf("read dataset: %w", err)
+	}
+	return report, nil
+}
+
+func decode(raw []byte) (record, error) {
+	var rec record
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&rec); err != nil {
+		return record{}, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if dec.More() {
+		return record{}, errors.New("invalid JSON: more than one value on the line")
+	}
+	return rec, nil
+}
+
+func (im *Importer) store(ctx context.Context, rec record) error {
+	switch rec.Type {
+	case TypeUser:
+		u, err := user.New(rec.Name, rec.Document)
+		if err != nil {
+			return err
+		}
+		if rec.ID != u.ID {
+			return fmt.Errorf("id %q does not match the document, want %q", rec.ID, u.ID)
+		}
+		if rec.CreatedAt != nil {
+			u.CreatedAt = rec.CreatedAt.UTC()
+		}
+		return im.sink.CreateUser(ctx, u)
+
+	case TypeFollow:
+		if rec.FollowerID == "" || rec.FolloweeID == "" {
+			return user.ErrInvalidInput
+		}
+		if rec.FollowerID == rec.FolloweeID {
+			return user.ErrSelfFollow
+		}
+		return im.sink.Follow(ctx, Follow{FollowerID: rec.FollowerID, FolloweeID: rec.FolloweeID})
+
+	case TypeTweet:
+		if rec.ID == "" {
+			return errors.New("tweet id is required")
+		}
+		if rec.CreatedAt == nil {
+			return errors.New("tweet created_at is required")
+		}
+		t, err := tweet.NewWithMaxLength(rec.UserID, rec.Content, rec.CreatedAt.UTC(), im.MaxContentLength)
+		switch {
+		case errors.Is(err, tweet.ErrEmptyTweet), errors.Is(err, tweet.ErrTooLong):
+			return fmt.Errorf("tweet content %w", err)
+		case err != nil:
+			return err
+		}
+		t.ID = rec.ID
+		return im.sink.SaveTweet(ctx, t)
+
+	case TypeLike:
+		if rec.UserID == "" || rec.TweetID == "" {
+			return like.ErrInvalidInput
+		}
+		return im.sink.Like(ctx, Like{UserID: rec.UserID, TweetID: rec.TweetID})
+
+	default:
+		return fmt.Errorf("unknown type %q", rec.Type)
+	}
+}
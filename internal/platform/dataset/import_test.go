@@ -0,0 +1,128 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/repository/memory"
+	"ualaTwitter/internal/test/mocks"
+)
+
+func TestImporter_Import(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("imports valid lines and reports the others", func(t *testing.T) {
+		backend := newMemoryBackend()
+		input := strings.Join([]string{
+			`{"type":"user","id":"usr_1111111","name":"Ana","document":"1111111"}`,
+			`{"type":"user","id":"usr_9","name":"Bruno","document":"2222222"}`,
+			`{"type":"user","id":"usr_abc","name":"Carla","document":"abc"}`,
+			``,
+			`{"type":"tweet","id":"t1","user_id":"usr_1111111","content":"hello","created_at":"2024-03-01T10:00:00Z"}`,
+			`{"type":"tweet","id":"t2","user_id":"usr_1111111","content":"   ","created_at":"2024-03-01T10:00:00Z"}`,
+			`{"type":"tweet","id":"t3","user_id":"usr_1111111","content":"no time"}`,
+			`{"type":"tweet","id":"t4","user_id":"usr_2222222","content":"ghost","created_at":"2024-03-01T10:00:00Z"}`,
+			`{"type":"tweet","id":"t1","user_id":"usr_1111111","content":"again","created_at":"2024-03-01T10:00:00Z"}`,
+			`{"type":"follow","follower_id":"usr_1111111","followee_id":"usr_1111111"}`,
+			`{"type":"like","user_id":"usr_1111111","tweet_id":"t1"}`,
+			`{"type":"like","user_id":"usr_1111111","tweet_id":"t1"}`,
+			`{"type":"like","user_id":"usr_1111111","tweet_id":"t9"}`,
+			`{"type":"retweet","id":"r1"}`,
+			`{"type":"user","nickname":"x"}`,
+			`not json`,
+		}, "\n")
+
+		report, err := NewImporter(backend).Import(ctx, strings.NewReader(input))
+		require.NoError(t, err)
+
+		assert.Equal(t, Counts{TypeUser: 1, TypeTweet: 1, TypeLike: 1}, report.Imported)
+		assert.Empty(t, report.Skipped)
+		assert.Equal(t, []Rejection{
+			{Line: 2, Type: TypeUser, Reason: `id "usr_9" does not match the document, want "usr_2222222"`},
+			{Line: 3, Type: TypeUser, Reason: user.ErrInvalidDocument.Error()},
+			{Line: 6, Type: TypeTweet, Reason: "tweet content empty"},
+			{Line: 7, Type: TypeTweet, Reason: "tweet created_at is required"},
+			{Line: 8, Type: TypeTweet, Reason: "author usr_2222222: user not found"},
+			{Line: 9, Type: TypeTweet, Reason: "tweet t1: already exists"},
+			{Line: 10, Type: TypeFollow, Reason: user.ErrSelfFollow.Error()},
+			{Line: 12, Type: TypeLike, Reason: "like of t1 by usr_1111111: already exists"},
+			{Line: 13, Type: TypeLike, Reason: "tweet t9: not found"},
+			{Line: 14, Type: "retweet", Reason: `unknown type "retweet"`},
+			{Line: 15, Reason: `invalid JSON: json: unknown field "nickname"`},
+			{Line: 16, Reason: "invalid JSON: invalid character 'o' in literal null (expecting 'u')"},
+		}, report.Rejected)
+
+		got, err := backend.tweets.GetByID(ctx, "t1")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", got.Content)
+		assert.Equal(t, 1, got.Likes)
+	})
+
+	t.Run("tweets longer than the limit are rejected", func(t *testing.T) {
+		backend := newMemoryBackend()
+		require.NoError(t, backend.users.Create(ctx, user.User{ID: "usr_1111111", Name: "Ana", Document: "1111111"}))
+		importer := NewImporter(backend)
+		importer.MaxContentLength = 3
+
+		report, err := importer.Import(ctx, strings.NewReader(
+			`{"type":"tweet","id":"t1","user_id":"usr_1111111","content":"hello","created_at":"2024-03-01T10:00:00Z"}`))
+		require.NoError(t, err)
+		require.Len(t, report.Rejected, 1)
+		assert.Equal(t, "tweet content "+tweet.ErrTooLong.Error(), report.Rejected[0].Reason)
+	})
+
+	t.Run("users are stored in the account store too", func(t *testing.T) {
+		accounts := &mocks.FakeUserRepo{Users: map[string]*user.User{
+			"usr_2222222": {ID: "usr_2222222", Name: "Bruno", Document: "2222222"},
+		}}
+		backend := NewMemoryBackend(memory.NewInMemoryUserRepository(), memory.NewInMemoryTweetRepository(), memory.NewInMemoryLikeRepository(), accounts)
+
+		report, err := NewImporter(backend).Import(ctx, strings.NewReader(strings.Join([]string{
+			`{"type":"user","id":"usr_1111111","name":"Ana","document":"1111111"}`,
+			`{"type":"user","id":"usr_2222222","name":"Bruno","document":"2222222"}`,
+			`{"type":"user","id":"usr_2222222","name":"Bruno","document":"2222222"}`,
+		}, "\n")))
+		require.NoError(t, err)
+		assert.Equal(t, Counts{TypeUser: 2}, report.Imported)
+		assert.Equal(t, []Rejection{{Line: 3, Type: TypeUser, Reason: user.ErrUserAlreadyExists.Error()}}, report.Rejected)
+		assert.Contains(t, accounts.Users, "usr_1111111")
+		_, err = backend.users.GetByID(ctx, "usr_2222222")
+		assert.NoError(t, err)
+	})
+
+	t.Run("account store failures reject the user", func(t *testing.T) {
+		accounts := &mocks.FakeUserRepo{CreateErr: errors.New("db down")}
+		backend := NewMemoryBackend(memory.NewInMemoryUserRepository(), memory.NewInMemoryTweetRepository(), memory.NewInMemoryLikeRepository(), accounts)
+
+		report, err := NewImporter(backend).Import(ctx, strings.NewReader(`{"type":"user","id":"usr_1111111","name":"Ana","document":"1111111"}`))
+		require.NoError(t, err)
+		assert.Equal(t, []Rejection{{Line: 1, Type: TypeUser, Reason: "db down"}}, report.Rejected)
+		_, err = backend.users.GetByID(ctx, "usr_1111111")
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
+	})
+
+	t.Run("types the backend does not store are skipped", func(t *testing.T) {
+		report, err := NewImporter(&PostgresBackend{}).Import(ctx, strings.NewReader(strings.Join([]string{
+			`{"type":"follow","follower_id":"usr_1111111","followee_id":"usr_2222222"}`,
+			`{"type":"tweet","id":"t1","user_id":"usr_1111111","content":"hello","created_at":"2024-03-01T10:00:00Z"}`,
+			`{"type":"like","user_id":"usr_2222222","tweet_id":"t1"}`,
+		}, "\n")))
+		require.NoError(t, err)
+		assert.Empty(t, report.Imported)
+		assert.Equal(t, Counts{TypeFollow: 1, TypeTweet: 1, TypeLike: 1}, report.Skipped)
+		assert.Empty(t, report.Rejected)
+	})
+
+	t.Run("lines over the size limit stop the import", func(t *testing.T) {
+		long := `{"type":"user","name":"` + strings.Repeat("a", maxLineSize) + `"}`
+		_, err := NewImporter(newMemoryBackend()).Import(ctx, strings.NewReader(long))
+		assert.ErrorContains(t, err, "read dataset")
+	})
+}
@@ -0,0 +1,131 @@
+package dataset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/repository/memory"
+)
+
+// AccountStore keeps the users the API signs in, the Postgres user
+// repository in production.
+type AccountStore interface {
+	Create(ctx context.Context, u user.User) error
+}
+
+// MemoryBackend reads and writes the in-memory repositories of the API.
+// Imported users are written to its AccountStore too, as registration does.
+type MemoryBackend struct {
+	users    *memory.InMemoryUserRepository
+	tweets   *memory.InMemoryTweetRepository
+	likes    *memory.InMemoryLikeRepository
+	accounts AccountStore
+}
+
+func NewMemoryBackend(users *memory.InMemoryUserRepository, tweets *memory.InMemoryTweetRepository, likes *memory.InMemoryLikeRepository, accounts AccountStore) *MemoryBackend {
+	return &MemoryBackend{
+		users:    users,
+		tweets:   tweets,
+		likes:    likes,
+		accounts: accounts,
+	}
+}
+
+func (b *MemoryBackend) Users(ctx context.Context) ([]user.User, error) {
+	return b.users.All(ctx)
+}
+
+func (b *MemoryBackend) Follows(ctx context.Context) ([]Follow, error) {
+	byFollower, err := b.users.AllFollows(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var follows []Follow
+	for followerID, followees := range byFollower {
+		for _, followeeID := range followees {
+			follows = append(follows, Follow{FollowerID: followerID, FolloweeID: followeeID})
+		}
+	}
+	return follows, nil
+}
+
+func (b *MemoryBackend) Tweets(ctx context.Context) ([]tweet.Tweet, error) {
+	return b.tweets.All(ctx)
+}
+
+func (b *MemoryBackend) Likes(ctx context.Context) ([]Like, error) {
+	byUser, err := b.likes.AllLikes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var likes []Like
+	for userID, tweetIDs := range byUser {
+		for _, tweetID := range tweetIDs {
+			likes = append(likes, Like{UserID: userID, TweetID: tweetID})
+		}
+	}
+	return likes, nil
+}
+
+// CreateUser stores u in the AccountStore, so it outlives a restart and can
+// be given credentials, then in memory. A user the AccountStore already
+// holds is one the API lost on restart, or one imported directly into
+// Postgres: it is loaded into memory again.
+func (b *MemoryBackend) CreateUser(ctx context.Context, u user.User) error {
+	if _, err := b.users.GetByID(ctx, u.ID); err == nil {
+		return user.ErrUserAlreadyExists
+	}
+	if err := b.accounts.Create(ctx, u); err != nil && !errors.Is(err, user.ErrUserAlreadyExists) {
+		return err
+	}
+	return b.users.Create(ctx, u)
+}
+
+func (b *MemoryBackend) Follow(ctx context.Context, f Follow) error {
+	if err := b.requireUser(ctx, "follower", f.FollowerID); err != nil {
+		return err
+	}
+	if err := b.requireUser(ctx, "followee", f.FolloweeID); err != nil {
+		return err
+	}
+	return b.users.Follow(ctx, f.FollowerID, f.FolloweeID)
+}
+
+// SaveTweet stores t without likes; the like lines count them again.
+func (b *MemoryBackend) SaveTweet(ctx context.Context, t tweet.Tweet) error {
+	if err := b.requireUser(ctx, "author", t.UserID); err != nil {
+		return err
+	}
+	if _, err := b.tweets.GetByID(ctx, t.ID); err == nil {
+		return fmt.Errorf("tweet %s: %w", t.ID, ErrDuplicate)
+	}
+	t.Likes = 0
+	return b.tweets.Save(ctx, t)
+}
+
+func (b *MemoryBackend) Like(ctx context.Context, l Like) error {
+	if err := b.requireUser(ctx, "user", l.UserID); err != nil {
+		return err
+	}
+	liked, err := b.likes.HasLiked(ctx, l.UserID, l.TweetID)
+	if err != nil {
+		return err
+	}
+	if liked {
+		return fmt.Errorf("like of %s by %s: %w", l.TweetID, l.UserID, ErrDuplicate)
+	}
+	if err := b.tweets.IncrementLikes(ctx, l.TweetID); err != nil {
+		return err
+	}
+	return b.likes.Like(ctx, l.UserID, l.TweetID)
+}
+
+func (b *MemoryBackend) requireUser(ctx context.Context, role, id string) error {
+	if _, err := b.users.GetByID(ctx, id); err != nil {
+		return fmt.Errorf("%s %s: %w", role, id, err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package dataset
+
+import (
+	"context"
+
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/domain/user"
+)
+
+// UserStore is the part of the Postgres user repository a PostgresBackend
+// needs.
+type UserStore interface {
+	All(ctx context.Context) ([]user.User, error)
+	Create(ctx context.Context, u user.User) error
+}
+
+// PostgresBackend reads and writes the users table, the only entities kept
+// in Postgres. It exports no follows, tweets or likes and skips them on
+// import.
+type PostgresBackend struct {
+	users UserStore
+}
+
+func NewPostgresBackend(users UserStore) *PostgresBackend {
+	return &PostgresBackend{
+		users: users,
+	}
+}
+
+func (b *PostgresBackend) Users(ctx context.Context) ([]user.User, error) {
+	return b.users.All(ctx)
+}
+
+func (b *PostgresBackend) Follows(context.Context) ([]Follow, error) {
+	return nil, nil
+}
+
+func (b *PostgresBackend) Tweets(context.Context) ([]tweet.Tweet, error) {
+	return nil, nil
+}
+
+func (b *PostgresBackend) Likes(context.Context) ([]Like, error) {
+	return nil, nil
+}
+
+func (b *PostgresBackend) CreateUser(ctx context.Context, u user.User) error {
+	return b.users.Create(ctx, u)
+}
+
+func (b *PostgresBackend) Follow(context.Context, Follow) error {
+	return ErrUnsupported
+}
+
+func (b *PostgresBackend) SaveTweet(context.Context, tweet.Tweet) error {
+	return ErrUnsupported
+}
+
+func (b *PostgresBackend) Like(context.Context, Like) error {
+	return ErrUnsupported
+}
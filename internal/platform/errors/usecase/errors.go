@@ -9,6 +9,7 @@ const (
 	TypeInvalidParam        = "invalid_param"
 	TypeInternalServerError = "internal_server_error"
 	TypeConflict            = "conflict"
+	TypeUnauthorized        = "unauthorized"
 )
 
 type UseCaseError struct {
@@ -44,6 +45,10 @@ func Conflict(message string, causes ...error) *UseCaseError {
 	return Create(TypeConflict, message, causes...)
 }
 
+func Unauthorized(message string, causes ...error) *UseCaseError {
+	return Create(TypeUnauthorized, message, causes...)
+}
+
 func Create(Type, message string, errors ...error) *UseCaseError {
 	return &UseCaseError{
 		Type:    Type,
@@ -42,6 +42,12 @@ func TestUseCaseErrors(t *testing.T) {
 			expectedType: TypeInvalidParam,
 			expectedMsg:  "Invalid parameter",
 		},
+		{
+			name:         "Unauthorized",
+			createFunc:   Unauthorized,
+			expectedType: TypeUnauthorized,
+			expectedMsg:  "Invalid credentials",
+		},
 	}
 
 	for _, tt := range tests {
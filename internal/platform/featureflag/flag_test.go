@@ -0,0 +1,127 @@
+package featureflag
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlag_EnabledFor(t *testing.T) {
+	tests := []struct {
+		name string
+		flag Flag
+		user string
+		want bool
+	}{
+		{
+			name: "disabled is off even for allowed users",
+			flag: Flag{Name: "f", Enabled: false, Percentage: 100, Allow: []string{"usr_1"}},
+			user: "usr_1",
+			want: false,
+		},
+		{
+			name: "allow list",
+			flag: Flag{Name: "f", Enabled: true, Allow: []string{"usr_1"}},
+			user: "usr_1",
+			want: true,
+		},
+		{
+			name: "deny wins over allow and percentage",
+			flag: Flag{Name: "f", Enabled: true, Percentage: 100, Allow: []string{"usr_1"}, Deny: []string{"usr_1"}},
+			user: "usr_1",
+			want: false,
+		},
+		{
+			name: "full rollout",
+			flag: Flag{Name: "f", Enabled: true, Percentage: 100},
+			user: "usr_1",
+			want: true,
+		},
+		{
+			name: "no rollout",
+			flag: Flag{Name: "f", Enabled: true},
+			user: "usr_1",
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.flag.EnabledFor(tc.user))
+		})
+	}
+
+	t.Run("percentage rollout is stable and only grows", func(t *testing.T) {
+		ten := Flag{Name: "f", Enabled: true, Percentage: 10}
+		fifty := Flag{Name: "f", Enabled: true, Percentage: 50}
+
+		on := 0
+		for i := 0; i < 10000; i++ {
+			user := fmt.Sprintf("usr_%d", i)
+			if ten.EnabledFor(user) {
+				on++
+				assert.True(t, fifty.EnabledFor(user), "user %s dropped when raising the rollout", user)
+			}
+			assert.Equal(t, ten.EnabledFor(user), ten.EnabledFor(user))
+		}
+		assert.InDelta(t, 1000, on, 150)
+	})
+
+	t.Run("flags pick different cohorts", func(t *testing.T) {
+		a := Flag{Name: "a", Enabled: true, Percentage: 50}
+		b := Flag{Name: "b", Enabled: true, Percentage: 50}
+
+		differ := 0
+		for i := 0; i < 1000; i++ {
+			user := fmt.Sprintf("usr_%d", i)
+			if a.EnabledFor(user) != b.EnabledFor(user) {
+				differ++
+			}
+		}
+		assert.Greater(t, differ, 300)
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("reads flags by name", func(t *testing.T) {
+		set, err := Parse([]byte(`
+ranked_timeline:
+  description: Ranked timeline by default
+  enabled: true
+  percentage: 100
+  deny: [usr_2]
+edit_tweet:
+  enabled: false
+`))
+		require.NoError(t, err)
+
+		flags := set.Flags()
+		require.Len(t, flags, 2)
+		assert.Equal(t, "edit_tweet", flags[0].Name)
+		assert.Equal(t, "ranked_timeline", flags[1].Name)
+		assert.Equal(t, "Ranked timeline by default", flags[1].Description)
+		assert.True(t, set.Enabled("ranked_timeline", "usr_1"))
+		assert.False(t, set.Enabled("ranked_timeline", "usr_2"))
+		assert.False(t, set.Enabled("unknown", "usr_1"))
+	})
+
+	t.Run("empty document has no flags", func(t *testing.T) {
+		set, err := Parse(nil)
+		require.NoError(t, err)
+		assert.Empty(t, set.Flags())
+	})
+
+	t.Run("rejects invalid flags", func(t *testing.T) {
+		_, err := Parse([]byte("a:\n  percentage: 150\nb:\n  percentage: -1\n"))
+		assert.ErrorIs(t, err, ErrInvalidFlag)
+		assert.ErrorContains(t, err, "a: percentage must be between 0 and 100, got 150")
+		assert.ErrorContains(t, err, "b: percentage must be between 0 and 100, got -1")
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		_, err := Parse([]byte("a:\n  percent: 10\n"))
+		assert.ErrorIs(t, err, ErrInvalidFlag)
+	})
+}
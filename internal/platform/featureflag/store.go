@@ -0,0 +1,116 @@
+package featureflag
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"ualaTwitter/internal/platform/logger"
+)
+
+// Store serves the flags of a file and reloads them when it changes. A
+// file that fails to load keeps the previous flags in place. Without a
+// file every flag is off.
+type Store struct {
+	path    string
+	current atomic.Pointer[Set]
+	// version identifies the loaded file contents; only Watch touches it.
+	version fileVersion
+}
+
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+func NewStore(path string) *Store {
+	s := &Store{path: path}
+	s.current.Store(&Set{})
+	return s
+}
+
+// Load reads the file once; call it before serving so a broken file fails
+// the start.
+func (s *Store) Load() error {
+	if s.path == "" {
+		return nil
+	}
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return fmt.Errorf("feature flags: %w", err)
+	}
+	if err := s.load(); err != nil {
+		return err
+	}
+	s.version = fileVersion{modTime: info.ModTime(), size: info.Size()}
+	return nil
+}
+
+func (s *Store) load() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("feature flags: %w", err)
+	}
+	set, err := Parse(data)
+	if err != nil {
+		return fmt.Errorf("feature flags %s: %w", s.path, err)
+	}
+	s.current.Store(set)
+	return nil
+}
+
+// Watch polls the file every interval and reloads it when its size or
+// modification time changes, until ctx is done.
+func (s *Store) Watch(ctx context.Context, interval time.Duration) {
+	if s.path == "" {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.reloadIfChanged(ctx)
+		}
+	}
+}
+
+func (s *Store) reloadIfChanged(ctx context.Context) {
+	log := logger.FromContext(ctx)
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		log.Error("failed to check feature flags file", zap.String("path", s.path), zap.Error(err))
+		return
+	}
+	version := fileVersion{modTime: info.ModTime(), size: info.Size()}
+	if version == s.version {
+		return
+	}
+	// Remember the version even on failure, so a broken file is reported
+	// once rather than every interval.
+	s.version = version
+
+	if err := s.load(); err != nil {
+		log.Error("failed to reload feature flags, keeping the previous ones", zap.Error(err))
+		return
+	}
+	log.Info("feature flags reloaded", zap.String("path", s.path), zap.Int("flags", len(s.current.Load().flags)))
+}
+
+// Enabled reports whether the flag name is on for userID.
+func (s *Store) Enabled(_ context.Context, name, userID string) bool {
+	return s.current.Load().Enabled(name, userID)
+}
+
+// Flags returns the loaded flags sorted by name.
+func (s *Store) Flags() []Flag {
+	return s.current.Load().Flags()
+}
@@ -0,0 +1,85 @@
+package featureflag
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	write := func(t *testing.T, path, content string, modTime time.Time) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	t.Run("without a file every flag is off", func(t *testing.T) {
+		store := NewStore("")
+		require.NoError(t, store.Load())
+		assert.False(t, store.Enabled(ctx, "ranked_timeline", "usr_1"))
+		assert.Empty(t, store.Flags())
+	})
+
+	t.Run("missing file fails the load", func(t *testing.T) {
+		store := NewStore(filepath.Join(t.TempDir(), "flags.yaml"))
+		assert.Error(t, store.Load())
+	})
+
+	t.Run("reloads when the file changes", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "flags.yaml")
+		start := time.Now().Add(-time.Hour)
+		write(t, path, "f:\n  enabled: false\n", start)
+
+		store := NewStore(path)
+		require.NoError(t, store.Load())
+		assert.False(t, store.Enabled(ctx, "f", "usr_1"))
+
+		write(t, path, "f:\n  enabled: true\n  percentage: 100\n", start.Add(time.Minute))
+		store.reloadIfChanged(ctx)
+		assert.True(t, store.Enabled(ctx, "f", "usr_1"))
+	})
+
+	t.Run("keeps the previous flags when the file breaks", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "flags.yaml")
+		start := time.Now().Add(-time.Hour)
+		write(t, path, "f:\n  enabled: true\n  percentage: 100\n", start)
+
+		store := NewStore(path)
+		require.NoError(t, store.Load())
+
+		write(t, path, "f:\n  percentage: 500\n", start.Add(time.Minute))
+		store.reloadIfChanged(ctx)
+		assert.True(t, store.Enabled(ctx, "f", "usr_1"))
+	})
+
+	t.Run("watch stops with its context", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "flags.yaml")
+		write(t, path, "", time.Now().Add(-time.Hour))
+		store := NewStore(path)
+		require.NoError(t, store.Load())
+
+		watchCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			store.Watch(watchCtx, time.Millisecond)
+			close(done)
+		}()
+
+		write(t, path, "f:\n  enabled: true\n  percentage: 100\n", time.Now())
+		assert.Eventually(t, func() bool { return store.Enabled(ctx, "f", "usr_1") }, time.Second, time.Millisecond)
+
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Watch did not return")
+		}
+	})
+}
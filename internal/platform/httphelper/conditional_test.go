@@ -0,0 +1,116 @@
+package httphelper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrongETag(t *testing.T) {
+	assert.Equal(t, StrongETag("a", "b"), StrongETag("a", "b"))
+	assert.NotEqual(t, StrongETag("a", "b"), StrongETag("ab"))
+	assert.NotEqual(t, StrongETag("t1:0"), StrongETag("t1:1"))
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, StrongETag("x"))
+}
+
+func TestWriteNotModified(t *testing.T) {
+	etag := StrongETag("t1:3")
+	modified := time.Date(2025, 5, 29, 18, 23, 12, 500, time.UTC)
+
+	tests := []struct {
+		name         string
+		method       string
+		headers      map[string]string
+		lastModified time.Time
+		expect304    bool
+	}{
+		{
+			name:         "no preconditions",
+			method:       http.MethodGet,
+			lastModified: modified,
+		},
+		{
+			name:      "matching If-None-Match",
+			method:    http.MethodGet,
+			headers:   map[string]string{"If-None-Match": etag},
+			expect304: true,
+		},
+		{
+			name:      "matching entry in an If-None-Match list",
+			method:    http.MethodGet,
+			headers:   map[string]string{"If-None-Match": `"other", ` + etag},
+			expect304: true,
+		},
+		{
+			name:      "weak form of the tag matches",
+			method:    http.MethodGet,
+			headers:   map[string]string{"If-None-Match": "W/" + etag},
+			expect304: true,
+		},
+		{
+			name:      "wildcard If-None-Match",
+			method:    http.MethodGet,
+			headers:   map[string]string{"If-None-Match": "*"},
+			expect304: true,
+		},
+		{
+			name:    "stale If-None-Match",
+			method:  http.MethodGet,
+			headers: map[string]string{"If-None-Match": `"stale"`},
+		},
+		{
+			name:         "If-None-Match wins over If-Modified-Since",
+			method:       http.MethodGet,
+			headers:      map[string]string{"If-None-Match": `"stale"`, "If-Modified-Since": modified.Add(time.Hour).Format(http.TimeFormat)},
+			lastModified: modified,
+		},
+		{
+			name:         "not modified since",
+			method:       http.MethodGet,
+			headers:      map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)},
+			lastModified: modified,
+			expect304:    true,
+		},
+		{
+			name:         "modified since",
+			method:       http.MethodGet,
+			headers:      map[string]string{"If-Modified-Since": modified.Add(-time.Minute).Format(http.TimeFormat)},
+			lastModified: modified,
+		},
+		{
+			name:         "invalid If-Modified-Since is ignored",
+			method:       http.MethodGet,
+			headers:      map[string]string{"If-Modified-Since": "yesterday"},
+			lastModified: modified,
+		},
+		{
+			name:    "preconditions are ignored on unsafe methods",
+			method:  http.MethodPost,
+			headers: map[string]string{"If-None-Match": etag},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/timeline", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			rr := httptest.NewRecorder()
+
+			notModified := WriteNotModified(rr, req, etag, tc.lastModified)
+
+			assert.Equal(t, tc.expect304, notModified)
+			assert.Equal(t, etag, rr.Header().Get("ETag"))
+			if tc.expect304 {
+				assert.Equal(t, http.StatusNotModified, rr.Code)
+			}
+			if !tc.lastModified.IsZero() {
+				assert.Equal(t, tc.lastModified.Format(http.TimeFormat), rr.Header().Get("Last-Modified"))
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package httphelper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"ualaTwitter/internal/platform/errors/usecase"
+)
+
+// ProblemContentType is the media type of error responses (RFC 9457).
+const ProblemContentType = "application/problem+json"
+
+// internalDetail replaces the detail of every 5xx problem so messages and
+// causes of unexpected failures never reach clients.
+const internalDetail = "an unexpected error occurred"
+
+// Problem is an RFC 9457 problem details object. Code is a stable, machine
+// readable identifier clients may switch on; Title and Detail are for
+// humans and may change.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError points a validation failure at one request field.
+type FieldError struct {
+	Field  string `json:"field"`
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+}
+
+// ErrorCode maps a sentinel error to its stable code and, for validation
+// errors, to the request field it concerns.
+type ErrorCode struct {
+	Err   error
+	Code  string
+	Field string
+}
+
+// ErrorCodes maps sentinel errors to their ErrorCode. A table never changes
+// once built, so one can serve any number of routers; Middleware hands it
+// to the renderers of each request, and without it errors are coded by
+// their use case type or status.
+type ErrorCodes struct {
+	entries []ErrorCode
+}
+
+type errorCodesKey struct{}
+
+// NewErrorCodes builds a table. A later entry for the same error replaces
+// the earlier one.
+func NewErrorCodes(entries ...ErrorCode) *ErrorCodes {
+	codes := &ErrorCodes{}
+	for _, entry := range entries {
+		replaced := false
+		for i := range codes.entries {
+			if codes.entries[i].Err == entry.Err {
+				codes.entries[i] = entry
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			codes.entries = append(codes.entries, entry)
+		}
+	}
+	return codes
+}
+
+// Middleware makes the table the one problems rendered for the request
+// are coded with.
+func (c *ErrorCodes) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), errorCodesKey{}, c)))
+	})
+}
+
+func (c *ErrorCodes) lookup(errs ...error) (ErrorCode, bool) {
+	if c == nil {
+		return ErrorCode{}, false
+	}
+	for _, err := range errs {
+		for _, entry := range c.entries {
+			if errors.Is(err, entry.Err) {
+				return entry, true
+			}
+		}
+	}
+	return ErrorCode{}, false
+}
+
+func errorCodesFrom(ctx context.Context) *ErrorCodes {
+	codes, _ := ctx.Value(errorCodesKey{}).(*ErrorCodes)
+	return codes
+}
+
+// RenderError writes a problem with a code derived from the status alone.
+func RenderError(w http.ResponseWriter, status int, message string) {
+	WriteProblem(w, Problem{Status: status, Detail: message, Code: statusCode(status)})
+}
+
+// RenderProblem writes err as a problem with the given status. Errors in
+// the request's ErrorCodes carry their code and field; use case errors are
+// described by their message rather than their "type: message" string.
+func RenderProblem(w http.ResponseWriter, r *http.Request, status int, err error) {
+	problem := Problem{Status: status, Code: statusCode(status), Detail: err.Error()}
+	causes := []error{err}
+
+	var ue *usecase.UseCaseError
+	if errors.As(err, &ue) {
+		problem.Code = ue.Type
+		problem.Detail = ue.Message
+		if problem.Detail == "" && len(ue.Causes) > 0 && ue.Causes[0] != nil {
+			problem.Detail = ue.Causes[0].Error()
+		}
+		causes = ue.Causes
+	}
+
+	if entry, ok := errorCodesFrom(r.Context()).lookup(causes...); ok {
+		problem.Code = entry.Code
+		if entry.Field != "" {
+			problem.Errors = []FieldError{{Field: entry.Field, Code: entry.Code, Detail: entry.Err.Error()}}
+		}
+	}
+
+	WriteProblem(w, problem)
+}
+
+// WriteError renders a use case error with the status its type maps to.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	RenderProblem(w, r, StatusFromError(err), err)
+}
+
+// WriteProblem fills in the RFC 9457 defaults and the request ID and writes
+// the problem. Server errors lose their detail and field errors.
+func WriteProblem(w http.ResponseWriter, problem Problem) {
+	if problem.Type == "" {
+		problem.Type = "about:blank"
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+	if problem.Code == "" {
+		problem.Code = statusCode(problem.Status)
+	}
+	if problem.Status >= http.StatusInternalServerError {
+		problem.Code = statusCode(problem.Status)
+		problem.Detail = internalDetail
+		problem.Errors = nil
+	}
+	if problem.RequestID == "" {
+		problem.RequestID = w.Header().Get(RequestIDHeader)
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// statusCode is the fallback code for errors without a registered one,
+// e.g. "too_many_requests" for 429.
+func statusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return "error"
+	}
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
+
+func StatusFromError(err error) int {
+	var ue *usecase.UseCaseError
+	if errors.As(err, &ue) {
+		switch ue.Type {
+		case usecase.TypeInvalidParam:
+			return http.StatusBadRequest
+		case usecase.TypeNotFound:
+			return http.StatusNotFound
+		case usecase.TypeForbidden:
+			return http.StatusForbidden
+		case usecase.TypeConflict:
+			return http.StatusConflict
+		case usecase.TypeUnauthorized:
+			return http.StatusUnauthorized
+		default:
+			return http.StatusInternalServerError
+		}
+	}
+	return http.StatusInternalServerError
+}
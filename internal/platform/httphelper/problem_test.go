@@ -0,0 +1,155 @@
+package httphelper
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ualaTwitter/internal/platform/errors/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errTooLong  = errors.New("too long")
+	errConflict = errors.New("already exists")
+)
+
+var testCodes = NewErrorCodes(
+	ErrorCode{Err: errTooLong, Code: "content_too_long", Field: "content"},
+	ErrorCode{Err: errConflict, Code: "duplicate"},
+)
+
+// codedRequest is a request that went through testCodes.Middleware.
+func codedRequest(t *testing.T) *http.Request {
+	t.Helper()
+	var coded *http.Request
+	testCodes.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		coded = r
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	return coded
+}
+
+func decodeProblem(t *testing.T, rr *httptest.ResponseRecorder) Problem {
+	t.Helper()
+	assert.Equal(t, ProblemContentType, rr.Header().Get("Content-Type"))
+	var p Problem
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &p))
+	return p
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected Problem
+	}{
+		{
+			name: "registered cause gives code and field",
+			err:  usecase.InvalidParam("invalid tweet content", errTooLong),
+			expected: Problem{
+				Type: "about:blank", Title: "Bad Request", Status: http.StatusBadRequest,
+				Detail: "invalid tweet content", Code: "content_too_long",
+				Errors: []FieldError{{Field: "content", Code: "content_too_long", Detail: "too long"}},
+			},
+		},
+		{
+			name: "registered cause without field",
+			err:  usecase.Conflict("user already exists", errConflict),
+			expected: Problem{
+				Type: "about:blank", Title: "Conflict", Status: http.StatusConflict,
+				Detail: "user already exists", Code: "duplicate",
+			},
+		},
+		{
+			name: "unregistered cause falls back to the use case type",
+			err:  usecase.NotFound("tweet not found", errors.New("missing row")),
+			expected: Problem{
+				Type: "about:blank", Title: "Not Found", Status: http.StatusNotFound,
+				Detail: "tweet not found", Code: usecase.TypeNotFound,
+			},
+		},
+		{
+			name: "empty message uses the cause",
+			err:  usecase.NotFound("", errConflict),
+			expected: Problem{
+				Type: "about:blank", Title: "Not Found", Status: http.StatusNotFound,
+				Detail: "already exists", Code: "duplicate",
+			},
+		},
+		{
+			name: "server errors hide message and causes",
+			err:  usecase.InternalServerError("failed to persist tweet", errors.New("pq: connection refused")),
+			expected: Problem{
+				Type: "about:blank", Title: "Internal Server Error", Status: http.StatusInternalServerError,
+				Detail: internalDetail, Code: "internal_server_error",
+			},
+		},
+		{
+			name: "plain errors are server errors",
+			err:  errors.New("boom"),
+			expected: Problem{
+				Type: "about:blank", Title: "Internal Server Error", Status: http.StatusInternalServerError,
+				Detail: internalDetail, Code: "internal_server_error",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+
+			WriteError(rr, codedRequest(t), tc.err)
+
+			assert.Equal(t, tc.expected.Status, rr.Code)
+			assert.Equal(t, tc.expected, decodeProblem(t, rr))
+		})
+	}
+}
+
+func TestRenderProblem(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	RenderProblem(rr, codedRequest(t), http.StatusBadRequest, errTooLong)
+
+	p := decodeProblem(t, rr)
+	assert.Equal(t, "content_too_long", p.Code)
+	assert.Equal(t, "too long", p.Detail)
+	assert.Equal(t, []FieldError{{Field: "content", Code: "content_too_long", Detail: "too long"}}, p.Errors)
+}
+
+func TestRenderError(t *testing.T) {
+	rr := httptest.NewRecorder()
+	rr.Header().Set(RequestIDHeader, "req-1")
+
+	RenderError(rr, http.StatusTooManyRequests, "rate limit exceeded")
+
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, Problem{
+		Type: "about:blank", Title: "Too Many Requests", Status: http.StatusTooManyRequests,
+		Detail: "rate limit exceeded", Code: "too_many_requests", RequestID: "req-1",
+	}, decodeProblem(t, rr))
+}
+
+func TestRenderProblem_WithoutErrorCodes(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	RenderProblem(rr, httptest.NewRequest(http.MethodGet, "/", nil), http.StatusBadRequest, errTooLong)
+
+	p := decodeProblem(t, rr)
+	assert.Equal(t, "bad_request", p.Code)
+	assert.Empty(t, p.Errors)
+}
+
+func TestNewErrorCodes_Replaces(t *testing.T) {
+	errTemp := errors.New("temp")
+	codes := NewErrorCodes(ErrorCode{Err: errTemp, Code: "first"}, ErrorCode{Err: errTemp, Code: "second"})
+
+	entry, ok := codes.lookup(errTemp)
+
+	require.True(t, ok)
+	assert.Equal(t, "second", entry.Code)
+}
@@ -0,0 +1,61 @@
+package httphelper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name    string
+		inbound string
+		reused  bool
+	}{
+		{name: "generates an ID", inbound: ""},
+		{name: "propagates the client ID", inbound: "abc-123", reused: true},
+		{name: "replaces IDs with spaces", inbound: "abc 123"},
+		{name: "replaces overlong IDs", inbound: strings.Repeat("a", maxRequestIDLength+1)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var fromContext string
+			h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fromContext, _ = RequestIDFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.inbound != "" {
+				req.Header.Set(RequestIDHeader, tc.inbound)
+			}
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			id := rr.Header().Get(RequestIDHeader)
+			assert.NotEmpty(t, id)
+			assert.Equal(t, id, fromContext)
+			if tc.reused {
+				assert.Equal(t, tc.inbound, id)
+			} else {
+				assert.NotEqual(t, tc.inbound, id)
+			}
+		})
+	}
+}
+
+func TestRequestID_InProblems(t *testing.T) {
+	h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		RenderError(w, http.StatusNotFound, "nothing here")
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(RequestIDHeader, "req-42")
+	rr := httptest.NewRecorder()
+
+	h.ServeHTTP(rr, req)
+
+	assert.Contains(t, rr.Body.String(), `"request_id":"req-42"`)
+}
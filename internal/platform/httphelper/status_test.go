@@ -0,0 +1,24 @@
+package httphelper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusRecorder(t *testing.T) {
+	t.Run("defaults to 200", func(t *testing.T) {
+		rec := NewStatusRecorder(httptest.NewRecorder())
+		_, _ = rec.Write([]byte("ok"))
+		assert.Equal(t, http.StatusOK, rec.Status())
+	})
+
+	t.Run("keeps the first status", func(t *testing.T) {
+		rec := NewStatusRecorder(httptest.NewRecorder())
+		rec.WriteHeader(http.StatusNotFound)
+		rec.WriteHeader(http.StatusInternalServerError)
+		assert.Equal(t, http.StatusNotFound, rec.Status())
+	})
+}
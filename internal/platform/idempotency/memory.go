@@ -0,0 +1,137 @@
+package idempotency
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultMaxEntries bounds a MemoryStore when no capacity is given. With
+// bodies capped at MaxResponseSize it holds at most about 160 MB.
+const DefaultMaxEntries = 10000
+
+type memoryEntry struct {
+	key       string
+	record    Record
+	expiresAt time.Time
+}
+
+// MemoryStore keeps records in process, bounded by the number of keys. When
+// full, the least recently used finished or expired key is evicted, so a
+// retry after that runs the handler again. Keys of requests still in
+// progress are never evicted; if nothing else is left, Reserve fails with
+// ErrStoreFull. Expired records are ignored on read and evicted like any
+// other.
+type MemoryStore struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+	now      func() time.Time
+}
+
+func NewMemoryStore(capacity int) *MemoryStore {
+	if capacity <= 0 {
+		capacity = DefaultMaxEntries
+	}
+	return &MemoryStore{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+func (s *MemoryStore) Reserve(_ context.Context, key, fingerprint string, ttl time.Duration) (Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if el, ok := s.entries[key]; ok {
+		entry := el.Value.(*memoryEntry)
+		if now.Before(entry.expiresAt) {
+			s.order.MoveToFront(el)
+			return entry.record, false, nil
+		}
+		s.remove(el)
+	}
+
+	if !s.put(key, Record{Fingerprint: fingerprint}, now.Add(ttl)) {
+		return Record{}, false, ErrStoreFull
+	}
+	return Record{}, true, nil
+}
+
+func (s *MemoryStore) Complete(_ context.Context, key string, record Record, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record.Completed = true
+	expiresAt := s.now().Add(ttl)
+	if el, ok := s.entries[key]; ok {
+		entry := el.Value.(*memoryEntry)
+		entry.record = record
+		entry.expiresAt = expiresAt
+		s.order.MoveToFront(el)
+		return nil
+	}
+	if !s.put(key, record, expiresAt) {
+		return ErrStoreFull
+	}
+	return nil
+}
+
+func (s *MemoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[key]; ok {
+		s.remove(el)
+	}
+	return nil
+}
+
+// Len returns the number of live records.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(s.now())
+	return len(s.entries)
+}
+
+// put adds a record, evicting the least recently used ones to make room.
+// Every record of a store shares the TTL, so those are also the first to
+// expire. Reservations still in progress are skipped, since dropping one
+// would let a retry run the handler a second time; put reports false when
+// only those are left.
+func (s *MemoryStore) put(key string, record Record, expiresAt time.Time) bool {
+	now := s.now()
+	for el := s.order.Back(); len(s.entries) >= s.capacity; {
+		if el == nil {
+			return false
+		}
+		prev := el.Prev()
+		if entry := el.Value.(*memoryEntry); entry.record.Completed || !now.Before(entry.expiresAt) {
+			s.remove(el)
+		}
+		el = prev
+	}
+	s.entries[key] = s.order.PushFront(&memoryEntry{key: key, record: record, expiresAt: expiresAt})
+	return true
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, el := range s.entries {
+		if !now.Before(el.Value.(*memoryEntry).expiresAt) {
+			s.order.Remove(el)
+			delete(s.entries, key)
+		}
+	}
+}
+
+func (s *MemoryStore) remove(el *list.Element) {
+	s.order.Remove(el)
+	delete(s.entries, el.Value.(*memoryEntry).key)
+}
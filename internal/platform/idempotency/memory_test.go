@@ -0,0 +1,142 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	newStore := func() *MemoryStore {
+		s := NewMemoryStore(3)
+		s.now = func() time.Time { return now }
+		return s
+	}
+
+	t.Run("first reserve wins", func(t *testing.T) {
+		s := newStore()
+
+		_, reserved, err := s.Reserve(ctx, "k", "fp", time.Hour)
+		require.NoError(t, err)
+		assert.True(t, reserved)
+
+		existing, reserved, err := s.Reserve(ctx, "k", "other", time.Hour)
+		require.NoError(t, err)
+		assert.False(t, reserved)
+		assert.Equal(t, Record{Fingerprint: "fp"}, existing)
+	})
+
+	t.Run("completed records are returned", func(t *testing.T) {
+		s := newStore()
+		_, _, _ = s.Reserve(ctx, "k", "fp", time.Hour)
+		require.NoError(t, s.Complete(ctx, "k", Record{Fingerprint: "fp", Status: 201, Body: []byte("ok")}, time.Hour))
+
+		existing, reserved, _ := s.Reserve(ctx, "k", "fp", time.Hour)
+
+		assert.False(t, reserved)
+		assert.True(t, existing.Completed)
+		assert.Equal(t, 201, existing.Status)
+		assert.Equal(t, []byte("ok"), existing.Body)
+	})
+
+	t.Run("released keys can be reserved again", func(t *testing.T) {
+		s := newStore()
+		_, _, _ = s.Reserve(ctx, "k", "fp", time.Hour)
+		require.NoError(t, s.Release(ctx, "k"))
+
+		_, reserved, _ := s.Reserve(ctx, "k", "fp", time.Hour)
+		assert.True(t, reserved)
+	})
+
+	t.Run("records expire", func(t *testing.T) {
+		s := newStore()
+		_, _, _ = s.Reserve(ctx, "k", "fp", time.Hour)
+
+		s.now = func() time.Time { return now.Add(time.Hour) }
+		_, reserved, _ := s.Reserve(ctx, "k", "fp", time.Hour)
+
+		assert.True(t, reserved)
+	})
+
+	t.Run("expired records are not counted", func(t *testing.T) {
+		s := newStore()
+		_, _, _ = s.Reserve(ctx, "old", "fp", time.Minute)
+
+		s.now = func() time.Time { return now.Add(time.Hour) }
+		_, _, _ = s.Reserve(ctx, "fresh", "fp", time.Minute)
+
+		assert.Equal(t, 1, s.Len())
+	})
+
+	t.Run("full store evicts the least recently used key", func(t *testing.T) {
+		s := newStore()
+		for i := 0; i < 3; i++ {
+			key := fmt.Sprint("k", i)
+			_, _, _ = s.Reserve(ctx, key, "fp", time.Hour)
+			require.NoError(t, s.Complete(ctx, key, Record{Fingerprint: "fp", Status: 201}, time.Hour))
+		}
+		_, reserved, _ := s.Reserve(ctx, "k0", "fp", time.Hour)
+		require.False(t, reserved)
+
+		_, _, _ = s.Reserve(ctx, "k3", "fp", time.Hour)
+
+		assert.Equal(t, 3, s.Len())
+		_, reserved, _ = s.Reserve(ctx, "k1", "fp", time.Hour)
+		assert.True(t, reserved, "k1 was evicted")
+		_, reserved, _ = s.Reserve(ctx, "k0", "fp", time.Hour)
+		assert.False(t, reserved, "k0 was used recently")
+	})
+
+	t.Run("reservations in progress are not evicted", func(t *testing.T) {
+		s := newStore()
+		_, _, _ = s.Reserve(ctx, "running", "fp", time.Hour)
+		for i := 0; i < 2; i++ {
+			key := fmt.Sprint("k", i)
+			_, _, _ = s.Reserve(ctx, key, "fp", time.Hour)
+			require.NoError(t, s.Complete(ctx, key, Record{Fingerprint: "fp", Status: 201}, time.Hour))
+		}
+
+		_, reserved, err := s.Reserve(ctx, "k2", "fp", time.Hour)
+		require.NoError(t, err)
+		require.True(t, reserved)
+
+		existing, reserved, _ := s.Reserve(ctx, "running", "fp", time.Hour)
+		assert.False(t, reserved, "running was kept")
+		assert.False(t, existing.Completed)
+		_, reserved, _ = s.Reserve(ctx, "k0", "fp", time.Hour)
+		assert.True(t, reserved, "k0 was evicted instead")
+	})
+
+	t.Run("store full of reservations in progress", func(t *testing.T) {
+		s := newStore()
+		for i := 0; i < 3; i++ {
+			_, _, _ = s.Reserve(ctx, fmt.Sprint("k", i), "fp", time.Hour)
+		}
+
+		_, reserved, err := s.Reserve(ctx, "k3", "fp", time.Hour)
+
+		assert.ErrorIs(t, err, ErrStoreFull)
+		assert.False(t, reserved)
+		assert.Equal(t, 3, s.Len())
+	})
+
+	t.Run("expired reservations are evicted", func(t *testing.T) {
+		s := newStore()
+		for i := 0; i < 3; i++ {
+			_, _, _ = s.Reserve(ctx, fmt.Sprint("k", i), "fp", time.Minute)
+		}
+
+		s.now = func() time.Time { return now.Add(time.Hour) }
+		_, reserved, err := s.Reserve(ctx, "k3", "fp", time.Hour)
+
+		require.NoError(t, err)
+		assert.True(t, reserved)
+	})
+}
@@ -0,0 +1,220 @@
+package idempotency
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/platform/logger"
+)
+
+const (
+	HeaderKey      = "Idempotency-Key"
+	HeaderReplayed = "Idempotent-Replayed"
+
+	DefaultTTL = 24 * time.Hour
+
+	// MaxResponseSize bounds the stored body. Larger responses are not
+	// stored, so a retry runs the handler again.
+	MaxResponseSize = 16 * 1024
+
+	maxKeyLength = 255
+	// maxBodySize bounds what is read to fingerprint the request; handlers
+	// apply their own, smaller limits.
+	maxBodySize = 64 * 1024
+)
+
+// Middleware makes write endpoints safe to retry. The first request with a
+// given Idempotency-Key runs the handler and its response is stored for
+// ttl; later requests with the same key and body get that response again.
+// Reusing a key with a different body is rejected with 422, and a retry
+// arriving while the first request still runs gets 409. When the store is
+// full of requests still running, new keys get 503 with Retry-After. Server
+// errors are not stored so the client can retry them. Requests without the
+// header are passed through.
+//
+// Keys are scoped to the endpoint name rather than the path, so an
+// operation served under several paths, like /tweets and /v1/tweets,
+// shares them.
+func Middleware(store Store, ttl time.Duration) func(endpoint string) func(http.Handler) http.Handler {
+	return func(endpoint string) func(http.Handler) http.Handler {
+		return endpointMiddleware(store, endpoint, ttl)
+	}
+}
+
+func endpointMiddleware(store Store, endpoint string, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			idemKey := r.Header.Get(HeaderKey)
+			if idemKey == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(idemKey) > maxKeyLength {
+				httphelper.RenderProblem(w, r, http.StatusBadRequest, ErrKeyTooLong)
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+			if err != nil || len(body) > maxBodySize {
+				httphelper.RenderError(w, http.StatusRequestEntityTooLarge, "request body too large")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := r.Context()
+			key := storeKey(r, endpoint, idemKey)
+			fingerprint := fingerprintOf(r, body)
+
+			existing, reserved, err := store.Reserve(ctx, key, fingerprint, ttl)
+			if errors.Is(err, ErrStoreFull) {
+				w.Header().Set("Retry-After", "1")
+				httphelper.RenderProblem(w, r, http.StatusServiceUnavailable, err)
+				return
+			}
+			if err != nil {
+				logger.FromContext(r.Context()).Warn("idempotency store failed, handling request without it", zap.Error(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !reserved {
+				switch {
+				case existing.Fingerprint != fingerprint:
+					httphelper.RenderProblem(w, r, http.StatusUnprocessableEntity, ErrKeyReused)
+				case !existing.Completed:
+					httphelper.RenderProblem(w, r, http.StatusConflict, ErrInProgress)
+				default:
+					replay(w, existing)
+				}
+				return
+			}
+
+			rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+			completed := false
+			defer func() {
+				if !completed {
+					if err := store.Release(ctx, key); err != nil {
+						logger.FromContext(r.Context()).Warn("failed to release idempotency key", zap.Error(err))
+					}
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+
+			if rec.status >= http.StatusInternalServerError {
+				return
+			}
+			if rec.overflow {
+				logger.FromContext(r.Context()).Warn("idempotent response too large to store", zap.String("endpoint", endpoint))
+				return
+			}
+
+			record := Record{
+				Fingerprint: fingerprint,
+				Status:      rec.status,
+				Header:      storedHeader(w.Header()),
+				Body:        rec.body.Bytes(),
+			}
+			if err := store.Complete(ctx, key, record, ttl); err != nil {
+				logger.FromContext(r.Context()).Warn("failed to store idempotent response", zap.Error(err))
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// storeKey scopes keys per caller and endpoint, so two users picking the
+// same key never see each other's responses.
+func storeKey(r *http.Request, endpoint, idemKey string) string {
+	caller, ok := auth.UserIDFromContext(r.Context())
+	if !ok {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		caller = "ip:" + host
+	}
+	return caller + "|" + endpoint + "|" + idemKey
+}
+
+// fingerprintOf identifies the request independently of the path it came
+// in on: path variables, query and body.
+func fingerprintOf(r *http.Request, body []byte) string {
+	vars := mux.Vars(r)
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		h.Write([]byte(name + "=" + vars[name] + "\n"))
+	}
+	h.Write([]byte("?" + r.URL.RawQuery + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// storedHeader keeps the response headers that describe the body.
+func storedHeader(h http.Header) http.Header {
+	out := make(http.Header)
+	for _, name := range []string{"Content-Type", "Location"} {
+		if v := h.Values(name); len(v) > 0 {
+			out[name] = append([]string(nil), v...)
+		}
+	}
+	return out
+}
+
+func replay(w http.ResponseWriter, record Record) {
+	for name, values := range record.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(HeaderReplayed, "true")
+	w.WriteHeader(record.Status)
+	_, _ = w.Write(record.Body)
+}
+
+type recorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+	// overflow is set once the body outgrows MaxResponseSize; buffering
+	// stops there.
+	overflow bool
+}
+
+func (r *recorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(p []byte) (int, error) {
+	r.wroteHeader = true
+	if !r.overflow {
+		if r.body.Len()+len(p) > MaxResponseSize {
+			r.overflow = true
+			r.body.Reset()
+		} else {
+			r.body.Write(p)
+		}
+	}
+	return r.ResponseWriter.Write(p)
+}
@@ -0,0 +1,242 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/logger"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+// countingHandler creates a new resource on every call, like POST /tweets.
+type countingHandler struct {
+	calls  int
+	status int
+}
+
+func (h *countingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.calls++
+	_, _ = io.ReadAll(r.Body)
+	status := h.status
+	if status == 0 {
+		status = http.StatusCreated
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, `{"id":"tweet_%d"}`, h.calls)
+}
+
+type failingStore struct{}
+
+func (failingStore) Reserve(_ context.Context, _, _ string, _ time.Duration) (Record, bool, error) {
+	return Record{}, false, errors.New("store down")
+}
+
+func (failingStore) Complete(_ context.Context, _ string, _ Record, _ time.Duration) error {
+	return errors.New("store down")
+}
+
+func (failingStore) Release(_ context.Context, _ string) error {
+	return errors.New("store down")
+}
+
+func send(h http.Handler, userID, key, body string) *httptest.ResponseRecorder {
+	return sendTo(h, "/tweets", userID, key, body)
+}
+
+func sendTo(h http.Handler, path, userID, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(HeaderKey, key)
+	}
+	if userID != "" {
+		req = req.WithContext(auth.WithUserID(req.Context(), userID))
+	}
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestMiddleware(t *testing.T) {
+	body := `{"content":"hello"}`
+
+	t.Run("retry replays the first response", func(t *testing.T) {
+		next := &countingHandler{}
+		h := Middleware(NewMemoryStore(0), DefaultTTL)("v1PostTweet")(next)
+
+		first := send(h, "usr_1", "abc", body)
+		second := send(h, "usr_1", "abc", body)
+
+		assert.Equal(t, 1, next.calls)
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, "application/json", second.Header().Get("Content-Type"))
+		assert.Equal(t, "true", second.Header().Get(HeaderReplayed))
+		assert.Empty(t, first.Header().Get(HeaderReplayed))
+	})
+
+	t.Run("the endpoint's paths share keys", func(t *testing.T) {
+		next := &countingHandler{}
+		h := Middleware(NewMemoryStore(0), DefaultTTL)("v1PostTweet")(next)
+
+		first := sendTo(h, "/tweets", "usr_1", "abc", body)
+		second := sendTo(h, "/v1/tweets", "usr_1", "abc", body)
+
+		assert.Equal(t, 1, next.calls)
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, "true", second.Header().Get(HeaderReplayed))
+	})
+
+	t.Run("endpoints do not share keys", func(t *testing.T) {
+		next := &countingHandler{}
+		store := NewMemoryStore(0)
+
+		send(Middleware(store, DefaultTTL)("v1PostTweet")(next), "usr_1", "abc", body)
+		send(Middleware(store, DefaultTTL)("v1CreateUser")(next), "usr_1", "abc", body)
+
+		assert.Equal(t, 2, next.calls)
+	})
+
+	t.Run("responses over the size limit are not stored", func(t *testing.T) {
+		calls := 0
+		h := Middleware(NewMemoryStore(0), DefaultTTL)("v1PostTweet")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(strings.Repeat("x", MaxResponseSize+1)))
+		}))
+
+		first := send(h, "usr_1", "abc", body)
+		send(h, "usr_1", "abc", body)
+
+		assert.Equal(t, MaxResponseSize+1, first.Body.Len())
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("different body with the same key is rejected", func(t *testing.T) {
+		next := &countingHandler{}
+		h := Middleware(NewMemoryStore(0), DefaultTTL)("v1PostTweet")(next)
+
+		send(h, "usr_1", "abc", body)
+		rr := send(h, "usr_1", "abc", `{"content":"other"}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+		assert.Equal(t, 1, next.calls)
+	})
+
+	t.Run("keys are scoped per user", func(t *testing.T) {
+		next := &countingHandler{}
+		h := Middleware(NewMemoryStore(0), DefaultTTL)("v1PostTweet")(next)
+
+		send(h, "usr_1", "abc", body)
+		rr := send(h, "usr_2", "abc", body)
+
+		assert.Equal(t, 2, next.calls)
+		assert.Equal(t, `{"id":"tweet_2"}`, rr.Body.String())
+	})
+
+	t.Run("requests without a key are not deduplicated", func(t *testing.T) {
+		next := &countingHandler{}
+		h := Middleware(NewMemoryStore(0), DefaultTTL)("v1PostTweet")(next)
+
+		send(h, "usr_1", "", body)
+		send(h, "usr_1", "", body)
+
+		assert.Equal(t, 2, next.calls)
+	})
+
+	t.Run("server errors are not stored", func(t *testing.T) {
+		next := &countingHandler{status: http.StatusInternalServerError}
+		h := Middleware(NewMemoryStore(0), DefaultTTL)("v1PostTweet")(next)
+
+		send(h, "usr_1", "abc", body)
+		next.status = 0
+		rr := send(h, "usr_1", "abc", body)
+
+		assert.Equal(t, 2, next.calls)
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("client errors are replayed", func(t *testing.T) {
+		next := &countingHandler{status: http.StatusBadRequest}
+		h := Middleware(NewMemoryStore(0), DefaultTTL)("v1PostTweet")(next)
+
+		send(h, "usr_1", "abc", body)
+		rr := send(h, "usr_1", "abc", body)
+
+		assert.Equal(t, 1, next.calls)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("retry while the first request runs", func(t *testing.T) {
+		store := NewMemoryStore(0)
+		var inner *httptest.ResponseRecorder
+		h := Middleware(store, DefaultTTL)("v1PostTweet")(nil)
+		h = Middleware(store, DefaultTTL)("v1PostTweet")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			inner = send(h, "usr_1", "abc", body)
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		send(h, "usr_1", "abc", body)
+
+		assert.Equal(t, http.StatusConflict, inner.Code)
+	})
+
+	t.Run("store full of requests in progress", func(t *testing.T) {
+		store := NewMemoryStore(1)
+		var inner *httptest.ResponseRecorder
+		h := Middleware(store, DefaultTTL)("v1PostTweet")(nil)
+		h = Middleware(store, DefaultTTL)("v1PostTweet")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if inner == nil {
+				inner = send(h, "usr_1", "other", body)
+			}
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		send(h, "usr_1", "abc", body)
+
+		assert.Equal(t, http.StatusServiceUnavailable, inner.Code)
+		assert.Equal(t, "1", inner.Header().Get("Retry-After"))
+	})
+
+	t.Run("panicking handler releases the key", func(t *testing.T) {
+		store := NewMemoryStore(0)
+		h := Middleware(store, DefaultTTL)("v1PostTweet")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		}))
+
+		assert.Panics(t, func() { send(h, "usr_1", "abc", body) })
+		assert.Zero(t, store.Len())
+	})
+
+	t.Run("oversized key", func(t *testing.T) {
+		h := Middleware(NewMemoryStore(0), DefaultTTL)("v1PostTweet")(&countingHandler{})
+
+		rr := send(h, "usr_1", strings.Repeat("k", maxKeyLength+1), body)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("store failure handles the request without deduplication", func(t *testing.T) {
+		next := &countingHandler{}
+		h := Middleware(failingStore{}, DefaultTTL)("v1PostTweet")(next)
+
+		rr := send(h, "usr_1", "abc", body)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, 1, next.calls)
+	})
+}
@@ -0,0 +1,39 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrInProgress = errors.New("a request with this idempotency key is in progress")
+	ErrKeyReused  = errors.New("idempotency key was already used with a different request")
+	ErrKeyTooLong = errors.New("idempotency key is too long")
+	// ErrStoreFull is returned by Reserve when every stored key belongs to
+	// a request still in progress. Retrying later may succeed.
+	ErrStoreFull = errors.New("too many requests with an idempotency key are in progress")
+)
+
+// Record is what is kept per key: the fingerprint of the first request and,
+// once it finished, its response.
+type Record struct {
+	Fingerprint string
+	Completed   bool
+	Status      int
+	Header      http.Header
+	Body        []byte
+}
+
+// Store keeps records for a limited time. Reserve must be atomic per key so
+// only one of several concurrent requests runs the handler.
+type Store interface {
+	// Reserve claims key for a new request. If the key is already known it
+	// returns the existing record and reserved is false.
+	Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (existing Record, reserved bool, err error)
+	// Complete stores the response of a reserved key.
+	Complete(ctx context.Context, key string, record Record, ttl time.Duration) error
+	// Release forgets a reserved key so the request can be retried.
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+type contextKey struct{}
+
+// scope holds the request logger. It is shared by pointer so fields learnt
+// deeper in the middleware chain, like the authenticated user, also reach
+// the access log written on the way out.
+type scope struct {
+	mu  sync.RWMutex
+	log *zap.Logger
+}
+
+// NewContext returns a context carrying l as the request logger.
+func NewContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, &scope{log: l})
+}
+
+// FromContext returns the request logger, or the global one outside a
+// request.
+func FromContext(ctx context.Context) *zap.Logger {
+	if s, ok := ctx.Value(contextKey{}).(*scope); ok {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return s.log
+	}
+	if Log == nil {
+		return zap.NewNop()
+	}
+	return Log
+}
+
+// AddFields adds fields to the request logger in ctx for the rest of the
+// request. It does nothing outside a request.
+func AddFields(ctx context.Context, fields ...zap.Field) {
+	if s, ok := ctx.Value(contextKey{}).(*scope); ok {
+		s.mu.Lock()
+		s.log = s.log.With(fields...)
+		s.mu.Unlock()
+	}
+}
@@ -1,15 +1,101 @@
 package logger
 
 import (
+	"fmt"
+	"os"
+	"time"
+
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-var Log *zap.Logger
+// Formats accepted by Config.Format.
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
 
-func Init() {
-	var err error
-	Log, err = zap.NewDevelopment()
+var (
+	Log *zap.Logger
+	// Level is the minimum level of Log; changing it takes effect at once.
+	Level = zap.NewAtomicLevel()
+)
+
+// Config builds the global logger.
+type Config struct {
+	// Format is FormatJSON, for log collectors, or FormatConsole.
+	Format string
+	// Level is the initial minimum level, e.g. "info".
+	Level string
+	// Sampling keeps the first 100 identical entries per second and then
+	// one in 100, so a hot error cannot flood the sink.
+	Sampling bool
+	// File, when set, also writes logs to a file rotated at FileMaxSizeMB,
+	// keeping FileMaxBackups files for FileMaxAgeDays.
+	File           string
+	FileMaxSizeMB  int
+	FileMaxBackups int
+	FileMaxAgeDays int
+	// RedactKeys are field keys whose values are never written; empty uses
+	// DefaultRedactKeys.
+	RedactKeys []string
+}
+
+// Init replaces Log with a logger built from cfg.
+func Init(cfg Config) error {
+	l, err := New(cfg)
 	if err != nil {
-		panic("failed to initialize logger: " + err.Error())
+		return err
+	}
+	Log = l
+	return nil
+}
+
+// New builds a logger from cfg whose level follows Level.
+func New(cfg Config) (*zap.Logger, error) {
+	if cfg.Level != "" {
+		if err := Level.UnmarshalText([]byte(cfg.Level)); err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+		}
+	}
+
+	var encoder zapcore.Encoder
+	switch cfg.Format {
+	case FormatJSON:
+		encoder = zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	case "", FormatConsole:
+		encoder = zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	default:
+		return nil, fmt.Errorf("unknown log format %q", cfg.Format)
+	}
+
+	sink := zapcore.Lock(os.Stdout)
+	if cfg.File != "" {
+		sink = zapcore.NewMultiWriteSyncer(sink, zapcore.AddSync(&lumberjack.Logger{
+			Filename:   cfg.File,
+			MaxSize:    cfg.FileMaxSizeMB,
+			MaxBackups: cfg.FileMaxBackups,
+			MaxAge:     cfg.FileMaxAgeDays,
+		}))
+	}
+
+	return zap.New(wrapCore(zapcore.NewCore(encoder, sink, Level), cfg),
+		zap.AddCaller(),
+		zap.AddStacktrace(zapcore.ErrorLevel),
+	), nil
+}
+
+// wrapCore applies the redaction and sampling cfg asks for to core.
+// Redaction sits inside the sampler, whose Check decides what reaches it.
+func wrapCore(core zapcore.Core, cfg Config) zapcore.Core {
+	keys := cfg.RedactKeys
+	if len(keys) == 0 {
+		keys = DefaultRedactKeys
+	}
+	core = NewRedactingCore(core, keys)
+	if cfg.Sampling {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, 100, 100)
 	}
+	return core
 }
@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func resetLevel(t *testing.T) {
+	t.Helper()
+	previous := Level.Level()
+	t.Cleanup(func() { Level.SetLevel(previous) })
+}
+
+func TestNew(t *testing.T) {
+	resetLevel(t)
+
+	t.Run("rejects unknown levels and formats", func(t *testing.T) {
+		_, err := New(Config{Level: "loud"})
+		assert.Error(t, err)
+		_, err = New(Config{Format: "xml"})
+		assert.Error(t, err)
+	})
+
+	t.Run("writes redacted JSON to the file sink", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "api.log")
+		l, err := New(Config{Format: FormatJSON, Level: "warn", File: file, FileMaxSizeMB: 1})
+		require.NoError(t, err)
+
+		l.Info("dropped")
+		l.Warn("user created", zap.String("user_id", "usr_1"), zap.String("Document", "12345678"))
+		_ = l.Sync() // syncing stdout fails under go test
+
+		raw, err := os.ReadFile(file)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+		require.Len(t, lines, 1)
+
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+		assert.Equal(t, "user created", entry["msg"])
+		assert.Equal(t, "usr_1", entry["user_id"])
+		assert.Equal(t, Redacted, entry["Document"])
+	})
+
+	t.Run("level can change at runtime", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "api.log")
+		l, err := New(Config{Format: FormatJSON, Level: "error", File: file})
+		require.NoError(t, err)
+
+		l.Info("before")
+		Level.SetLevel(zapcore.InfoLevel)
+		l.Info("after")
+		_ = l.Sync() // syncing stdout fails under go test
+
+		raw, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.NotContains(t, string(raw), "before")
+		assert.Contains(t, string(raw), "after")
+	})
+}
+
+func TestRedactingCore(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := zap.New(NewRedactingCore(core, DefaultRedactKeys)).With(zap.String("password", "hunter22"))
+
+	l.Info("login", zap.String("user_id", "usr_1"), zap.String("TOKEN", "abc"))
+
+	entry := logs.AllUntimed()[0].ContextMap()
+	assert.Equal(t, Redacted, entry["password"], "fields added with With are redacted")
+	assert.Equal(t, Redacted, entry["TOKEN"])
+	assert.Equal(t, "usr_1", entry["user_id"])
+}
+
+func TestWrapCore_Sampling(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := zap.New(wrapCore(core, Config{Sampling: true}))
+
+	for i := 0; i < 150; i++ {
+		l.Warn("hot path", zap.String("document", "12345678"))
+	}
+
+	assert.Equal(t, 100, logs.Len())
+	assert.Equal(t, Redacted, logs.AllUntimed()[0].ContextMap()["document"])
+}
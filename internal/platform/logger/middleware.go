@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"ualaTwitter/internal/platform/httphelper"
+)
+
+// AccessLog puts a logger tagged with the request ID (and trace ID when
+// traced) in the request context and writes one line per request once it
+// is served. Install it on the router, after httphelper.RequestID, so the
+// route template is known.
+func AccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		base := FromContext(r.Context())
+		if id, ok := httphelper.RequestIDFromContext(r.Context()); ok {
+			base = base.With(zap.String("request_id", id))
+		}
+		if span := trace.SpanContextFromContext(r.Context()); span.HasTraceID() {
+			base = base.With(zap.String("trace_id", span.TraceID().String()))
+		}
+		ctx := NewContext(r.Context(), base)
+
+		rec := httphelper.NewStatusRecorder(w)
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		route := r.URL.Path
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+		FromContext(ctx).Info("request",
+			zap.String("method", r.Method),
+			zap.String("route", route),
+			zap.Int("status", rec.Status()),
+			zap.Duration("latency", time.Since(start)),
+		)
+	})
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"ualaTwitter/internal/platform/httphelper"
+)
+
+func observe(t *testing.T) *observer.ObservedLogs {
+	t.Helper()
+	core, logs := observer.New(zapcore.InfoLevel)
+	previous := Log
+	Log = zap.New(core)
+	t.Cleanup(func() { Log = previous })
+	return logs
+}
+
+func TestAccessLog(t *testing.T) {
+	logs := observe(t)
+
+	r := mux.NewRouter()
+	r.Use(AccessLog)
+	r.HandleFunc("/v1/tweets/{id}/like", func(w http.ResponseWriter, r *http.Request) {
+		AddFields(r.Context(), zap.String("user_id", "usr_1"))
+		FromContext(r.Context()).Info("liking")
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods(http.MethodPost)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/tweets/t1/like", nil)
+	req.Header.Set(httphelper.RequestIDHeader, "req-1")
+	httphelper.RequestID(r).ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+
+	handler := entries[0].ContextMap()
+	assert.Equal(t, "liking", entries[0].Message)
+	assert.Equal(t, "req-1", handler["request_id"])
+	assert.Equal(t, "usr_1", handler["user_id"])
+
+	access := entries[1].ContextMap()
+	assert.Equal(t, "request", entries[1].Message)
+	assert.Equal(t, "req-1", access["request_id"])
+	assert.Equal(t, "usr_1", access["user_id"], "fields added by inner middleware reach the access log")
+	assert.Equal(t, http.MethodPost, access["method"])
+	assert.Equal(t, "/v1/tweets/{id}/like", access["route"])
+	assert.EqualValues(t, http.StatusNoContent, access["status"])
+	assert.Contains(t, access, "latency")
+}
+
+func TestFromContext(t *testing.T) {
+	logs := observe(t)
+
+	t.Run("falls back to the global logger", func(t *testing.T) {
+		FromContext(context.Background()).Info("global")
+		assert.Equal(t, 1, logs.FilterMessage("global").Len())
+	})
+
+	t.Run("AddFields is a no-op outside a request", func(t *testing.T) {
+		ctx := context.Background()
+		AddFields(ctx, zap.String("user_id", "usr_1"))
+		FromContext(ctx).Info("plain")
+		assert.Empty(t, logs.FilterMessage("plain").All()[0].Context)
+	})
+
+	t.Run("never nil", func(t *testing.T) {
+		Log = nil
+		assert.NotNil(t, FromContext(context.Background()))
+	})
+}
@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Redacted replaces the value of redacted fields.
+const Redacted = "[REDACTED]"
+
+// DefaultRedactKeys are the field keys that may carry personal data or
+// secrets.
+var DefaultRedactKeys = []string{
+	"document",
+	"password",
+	"current_password",
+	"new_password",
+	"token",
+	"access_token",
+	"api_key",
+	"authorization",
+	"secret",
+}
+
+// redactingCore blanks the value of fields whose key is in keys, whatever
+// logged them. Keys match case-insensitively, so "Document" is caught too.
+type redactingCore struct {
+	zapcore.Core
+	keys map[string]bool
+}
+
+// NewRedactingCore wraps core so fields named in keys are logged as
+// Redacted.
+func NewRedactingCore(core zapcore.Core, keys []string) zapcore.Core {
+	set := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		set[strings.ToLower(k)] = true
+	}
+	return &redactingCore{Core: core, keys: set}
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(c.redact(fields)), keys: c.keys}
+}
+
+func (c *redactingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *redactingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, c.redact(fields))
+}
+
+func (c *redactingCore) redact(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, f := range fields {
+		if !c.keys[strings.ToLower(f.Key)] {
+			continue
+		}
+		if out == nil {
+			out = append([]zapcore.Field(nil), fields...)
+		}
+		out[i] = zap.String(f.Key, Redacted)
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"ualaTwitter/internal/platform/httphelper"
+)
+
+// unmatchedRoute labels requests no route matched: 404 and 405 answers.
+const unmatchedRoute = "unmatched"
+
+// HTTPHandler serves router and records every request by route template
+// rather than path, so IDs in paths do not create series. It wraps the
+// router instead of running as route middleware, which mux skips when no
+// route matches.
+func (m *Metrics) HTTPHandler(router *mux.Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := httphelper.NewStatusRecorder(w)
+
+		router.ServeHTTP(rec, r)
+
+		route := unmatchedRoute
+		var match mux.RouteMatch
+		if router.Match(r, &match) && match.Route != nil {
+			if template, err := match.Route.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+		status := strconv.Itoa(rec.Status())
+		m.httpRequests.WithLabelValues(route, r.Method, status).Inc()
+		m.httpDuration.WithLabelValues(route, r.Method, status).Observe(time.Since(start).Seconds())
+	})
+}
@@ -0,0 +1,123 @@
+// Package metrics collects Prometheus metrics for the HTTP layer, the use
+// cases, the repositories and the timeline, and serves them for scraping.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "twitter"
+
+// Metrics owns a registry, so tests and several servers in one process do
+// not collide on the global one.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	useCaseDuration *prometheus.HistogramVec
+	useCaseErrors   *prometheus.CounterVec
+
+	repositoryDuration *prometheus.HistogramVec
+
+	timelineFollowees prometheus.Histogram
+	timelineTweets    prometheus.Histogram
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by route template, method and status.",
+		}, []string{"route", "method", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route template, method and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		useCaseDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "usecase_duration_seconds",
+			Help:      "Use case latency.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"usecase"}),
+		useCaseErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "usecase_errors_total",
+			Help:      "Use case errors by UseCaseError type.",
+		}, []string{"usecase", "type"}),
+		repositoryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "repository_call_duration_seconds",
+			Help:      "Repository call latency by outcome.",
+			Buckets:   []float64{.0001, .0005, .001, .005, .01, .05, .1, .5, 1},
+		}, []string{"repository", "method", "outcome"}),
+		timelineFollowees: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "timeline_fanin_followees",
+			Help:      "Followees aggregated per timeline build.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		}),
+		timelineTweets: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "timeline_fanin_tweets",
+			Help:      "Tweets aggregated per timeline build, before pagination.",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.useCaseDuration,
+		m.useCaseErrors,
+		m.repositoryDuration,
+		m.timelineFollowees,
+		m.timelineTweets,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterRepositorySize exports size as the number of stored entities of
+// an in-memory repository, read on every scrape.
+func (m *Metrics) RegisterRepositorySize(entity string, size func() int) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Name:        "memory_repository_entries",
+		Help:        "Entities held by in-memory repositories.",
+		ConstLabels: prometheus.Labels{"entity": entity},
+	}, func() float64 {
+		return float64(size())
+	}))
+}
+
+// ObserveRepositoryCall records the latency of a repository call.
+func (m *Metrics) ObserveRepositoryCall(repository, method string, duration time.Duration, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	m.repositoryDuration.WithLabelValues(repository, method, outcome).Observe(duration.Seconds())
+}
+
+// ObserveTimelineFanIn records how much a timeline build aggregated.
+func (m *Metrics) ObserveTimelineFanIn(followees, tweets int) {
+	m.timelineFollowees.Observe(float64(followees))
+	m.timelineTweets.Observe(float64(tweets))
+}
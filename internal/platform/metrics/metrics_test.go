@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ualaTwitter/internal/platform/errors/usecase"
+)
+
+func TestHTTPHandler_LabelsByRouteTemplate(t *testing.T) {
+	m := New()
+	r := mux.NewRouter()
+	sub := r.PathPrefix("/v1").Subrouter()
+	sub.HandleFunc("/tweets/{id}/like", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods(http.MethodPost)
+	sub.HandleFunc("/timeline", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("[]"))
+	}).Methods(http.MethodGet)
+	handler := m.HTTPHandler(r)
+
+	for _, path := range []string{"/v1/tweets/t1/like", "/v1/tweets/t2/like"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, path, nil))
+	}
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/timeline", nil))
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("/v1/tweets/{id}/like", http.MethodPost, "204")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("/v1/timeline", http.MethodGet, "200")))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.httpDuration))
+}
+
+func TestHTTPHandler_UnmatchedRoute(t *testing.T) {
+	m := New()
+	r := mux.NewRouter()
+	r.HandleFunc("/timeline", func(w http.ResponseWriter, r *http.Request) {}).Methods(http.MethodGet)
+	handler := m.HTTPHandler(r)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/nope", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/timeline", nil))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.httpRequests.WithLabelValues(unmatchedRoute, http.MethodGet, "404")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.httpRequests.WithLabelValues(unmatchedRoute, http.MethodDelete, "405")))
+}
+
+func TestObserveUseCase_CountsErrorsByType(t *testing.T) {
+	m := New()
+	calls := 0
+	execute := ObserveUseCase(m, "post_tweet", func(_ context.Context, content string) (string, error) {
+		calls++
+		switch content {
+		case "":
+			return "", usecase.InvalidParam("empty")
+		case "boom":
+			return "", errors.New("boom")
+		default:
+			return "tw_1", nil
+		}
+	})
+
+	id, err := execute.Execute(context.Background(), "hi")
+	require.NoError(t, err)
+	assert.Equal(t, "tw_1", id)
+	_, _ = execute.Execute(context.Background(), "")
+	_, _ = execute.Execute(context.Background(), "")
+	_, _ = execute.Execute(context.Background(), "boom")
+
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.useCaseErrors.WithLabelValues("post_tweet", usecase.TypeInvalidParam)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.useCaseErrors.WithLabelValues("post_tweet", usecase.TypeUnknown)))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.useCaseDuration))
+}
+
+func TestObserveCommand(t *testing.T) {
+	m := New()
+	execute := ObserveCommand(m, "like_tweet", func(_ context.Context, _ string) error {
+		return usecase.Forbidden("already liked")
+	})
+
+	assert.Error(t, execute.Execute(context.Background(), "t1"))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.useCaseErrors.WithLabelValues("like_tweet", usecase.TypeForbidden)))
+}
+
+func TestHandler_ExposesMetrics(t *testing.T) {
+	m := New()
+	users := 3
+	m.RegisterRepositorySize("users", func() int { return users })
+	m.ObserveRepositoryCall("tweets", "Save", time.Millisecond, nil)
+	m.ObserveRepositoryCall("tweets", "GetByID", time.Millisecond, errors.New("not found"))
+	m.ObserveTimelineFanIn(4, 40)
+
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	for _, want := range []string{
+		`twitter_memory_repository_entries{entity="users"} 3`,
+		`twitter_repository_call_duration_seconds_count{method="Save",outcome="ok",repository="tweets"} 1`,
+		`twitter_repository_call_duration_seconds_count{method="GetByID",outcome="error",repository="tweets"} 1`,
+		`twitter_timeline_fanin_followees_sum 4`,
+		`twitter_timeline_fanin_tweets_sum 40`,
+		`go_goroutines`,
+	} {
+		assert.True(t, strings.Contains(body, want), "missing %s", want)
+	}
+}
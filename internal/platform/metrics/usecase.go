@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ualaTwitter/internal/platform/errors/usecase"
+)
+
+// UseCaseFunc adapts an observed use case back to the Execute method the
+// handlers depend on.
+type UseCaseFunc[I, O any] func(ctx context.Context, input I) (O, error)
+
+func (f UseCaseFunc[I, O]) Execute(ctx context.Context, input I) (O, error) {
+	return f(ctx, input)
+}
+
+// CommandFunc is UseCaseFunc for use cases that only return an error.
+type CommandFunc[I any] func(ctx context.Context, input I) error
+
+func (f CommandFunc[I]) Execute(ctx context.Context, input I) error {
+	return f(ctx, input)
+}
+
+// ObserveUseCase wraps a use case Execute method with latency and error
+// metrics: ObserveUseCase(m, "post_tweet", service.Execute).
+func ObserveUseCase[I, O any](m *Metrics, name string, execute func(context.Context, I) (O, error)) UseCaseFunc[I, O] {
+	return func(ctx context.Context, input I) (O, error) {
+		start := time.Now()
+		out, err := execute(ctx, input)
+		m.observeUseCase(name, start, err)
+		return out, err
+	}
+}
+
+// ObserveCommand is ObserveUseCase for use cases that only return an error.
+func ObserveCommand[I any](m *Metrics, name string, execute func(context.Context, I) error) CommandFunc[I] {
+	return func(ctx context.Context, input I) error {
+		start := time.Now()
+		err := execute(ctx, input)
+		m.observeUseCase(name, start, err)
+		return err
+	}
+}
+
+func (m *Metrics) observeUseCase(name string, start time.Time, err error) {
+	m.useCaseDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+	if err == nil {
+		return
+	}
+
+	errType := usecase.TypeUnknown
+	var ue *usecase.UseCaseError
+	if errors.As(err, &ue) && ue.Type != "" {
+		errType = ue.Type
+	}
+	m.useCaseErrors.WithLabelValues(name, errType).Inc()
+}
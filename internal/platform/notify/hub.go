@@ -0,0 +1,104 @@
+// Package notify tells in-process watchers that a user's timeline changed.
+package notify
+
+import (
+	"context"
+	"sync"
+)
+
+// Hub fans timeline change signals out to subscribers. Signals carry no
+// payload and coalesce: a subscriber that has not consumed the previous
+// signal receives only one, so slow watchers never block writers.
+type Hub struct {
+	mu     sync.Mutex
+	subs   map[string]map[*subscription]struct{}
+	closed bool
+}
+
+type subscription struct {
+	ch chan struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{subs: make(map[string]map[*subscription]struct{})}
+}
+
+// Subscribe returns a channel signalled whenever userID's timeline changes
+// and a cancel func that must be called to release it. The channel is
+// closed when the hub is.
+func (h *Hub) Subscribe(userID string) (<-chan struct{}, func()) {
+	sub := &subscription{ch: make(chan struct{}, 1)}
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	if h.subs[userID] == nil {
+		h.subs[userID] = make(map[*subscription]struct{})
+	}
+	h.subs[userID][sub] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			delete(h.subs[userID], sub)
+			if len(h.subs[userID]) == 0 {
+				delete(h.subs, userID)
+			}
+		})
+	}
+	return sub.ch, cancel
+}
+
+// Invalidate signals the subscribers of userIDs. It satisfies
+// cache.Invalidator so it can be notified wherever the timeline cache is.
+func (h *Hub) Invalidate(_ context.Context, userIDs ...string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, userID := range userIDs {
+		for sub := range h.subs[userID] {
+			select {
+			case sub.ch <- struct{}{}:
+			default:
+			}
+		}
+	}
+	return nil
+}
+
+// Close closes every subscription channel so watchers return, and makes
+// later subscriptions start closed. It is called on shutdown, since open
+// watch streams would otherwise keep the server from stopping.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for _, subs := range h.subs {
+		for sub := range subs {
+			close(sub.ch)
+		}
+	}
+	h.subs = make(map[string]map[*subscription]struct{})
+}
+
+// Subscribers returns the number of open subscriptions.
+func (h *Hub) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	n := 0
+	for _, subs := range h.subs {
+		n += len(subs)
+	}
+	return n
+}
@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("signals subscribers of the user", func(t *testing.T) {
+		h := NewHub()
+		alice, cancelAlice := h.Subscribe("alice")
+		defer cancelAlice()
+		bob, cancelBob := h.Subscribe("bob")
+		defer cancelBob()
+
+		assert.NoError(t, h.Invalidate(ctx, "alice"))
+
+		assert.Len(t, alice, 1)
+		assert.Empty(t, bob)
+	})
+
+	t.Run("signals coalesce", func(t *testing.T) {
+		h := NewHub()
+		ch, cancel := h.Subscribe("alice")
+		defer cancel()
+
+		_ = h.Invalidate(ctx, "alice")
+		_ = h.Invalidate(ctx, "alice", "alice")
+
+		assert.Len(t, ch, 1)
+	})
+
+	t.Run("cancel releases the subscription", func(t *testing.T) {
+		h := NewHub()
+		ch, cancel := h.Subscribe("alice")
+		_, other := h.Subscribe("alice")
+		assert.Equal(t, 2, h.Subscribers())
+
+		cancel()
+		cancel()
+		_ = h.Invalidate(ctx, "alice")
+
+		assert.Empty(t, ch)
+		assert.Equal(t, 1, h.Subscribers())
+		other()
+		assert.Zero(t, h.Subscribers())
+	})
+
+	t.Run("close ends every subscription", func(t *testing.T) {
+		h := NewHub()
+		ch, cancel := h.Subscribe("alice")
+		defer cancel()
+
+		h.Close()
+		h.Close()
+
+		_, open := <-ch
+		assert.False(t, open)
+		assert.Zero(t, h.Subscribers())
+		assert.NoError(t, h.Invalidate(ctx, "alice"))
+
+		late, cancelLate := h.Subscribe("alice")
+		defer cancelLate()
+		_, open = <-late
+		assert.False(t, open)
+	})
+}
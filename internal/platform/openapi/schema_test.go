@@ -0,0 +1,54 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sampleAuthor struct {
+	ID string `json:"id"`
+}
+
+type sampleDTO struct {
+	ID        string        `json:"id"`
+	Likes     int           `json:"likes"`
+	Liked     bool          `json:"liked_by_me"`
+	Tags      []string      `json:"tags"`
+	Author    *sampleAuthor `json:"author,omitempty"`
+	SeenAt    *string       `json:"seen_at"`
+	CreatedAt time.Time     `json:"created_at"`
+	Score     float64       `json:"score,omitempty"`
+	Hidden    string        `json:"-"`
+	internal  string
+}
+
+func TestSchemaOf(t *testing.T) {
+	s := SchemaOf(sampleDTO{})
+
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, []string{"id", "likes", "liked_by_me", "tags", "created_at"}, s.Required)
+	assert.Len(t, s.Properties, 8)
+
+	assert.Equal(t, &Schema{Type: "string"}, s.Properties["id"])
+	assert.Equal(t, &Schema{Type: "integer"}, s.Properties["likes"])
+	assert.Equal(t, &Schema{Type: "boolean"}, s.Properties["liked_by_me"])
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Type: "string"}}, s.Properties["tags"])
+	assert.Equal(t, &Schema{Type: []string{"string", "null"}}, s.Properties["seen_at"])
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, s.Properties["created_at"])
+	assert.Equal(t, &Schema{Type: "number"}, s.Properties["score"])
+	assert.Equal(t, []string{"object", "null"}, s.Properties["author"].Type)
+	assert.Contains(t, s.Properties["author"].Properties, "id")
+	assert.NotContains(t, s.Properties, "Hidden")
+	assert.NotContains(t, s.Properties, "internal")
+}
+
+func TestSchemaOf_Encodes(t *testing.T) {
+	raw, err := json.Marshal(Ref("Problem"))
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"$ref":"#/components/schemas/Problem"}`, string(raw))
+}
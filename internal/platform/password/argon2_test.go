@@ -0,0 +1,62 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testHasher() *Argon2Hasher {
+	return NewArgon2Hasher(Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 8, KeyLength: 16})
+}
+
+func TestArgon2Hasher(t *testing.T) {
+	h := testHasher()
+
+	t.Run("hash round trips", func(t *testing.T) {
+		encoded, err := h.Hash("correcthorse42")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=64,t=1,p=1$"))
+
+		ok, err := h.Verify("correcthorse42", encoded)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = h.Verify("wronghorse42", encoded)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("same password gets a different salt", func(t *testing.T) {
+		a, _ := h.Hash("correcthorse42")
+		b, _ := h.Hash("correcthorse42")
+		assert.NotEqual(t, a, b)
+	})
+
+	t.Run("verify uses the parameters stored in the hash", func(t *testing.T) {
+		encoded, err := h.Hash("correcthorse42")
+		require.NoError(t, err)
+
+		stronger := NewArgon2Hasher(DefaultParams())
+		ok, err := stronger.Verify("correcthorse42", encoded)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("malformed hashes are rejected", func(t *testing.T) {
+		for _, encoded := range []string{
+			"",
+			"plaintext",
+			"$bcrypt$v=19$m=64,t=1,p=1$c2FsdA$a2V5",
+			"$argon2id$v=18$m=64,t=1,p=1$c2FsdA$a2V5",
+			"$argon2id$v=19$m=x,t=1,p=1$c2FsdA$a2V5",
+			"$argon2id$v=19$m=64,t=1,p=1$!!$a2V5",
+			"$argon2id$v=19$m=64,t=1,p=1$c2FsdA$",
+		} {
+			_, err := h.Verify("pw", encoded)
+			assert.ErrorIs(t, err, ErrInvalidHash, encoded)
+		}
+	})
+}
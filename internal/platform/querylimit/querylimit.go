@@ -0,0 +1,18 @@
+// Package querylimit holds the bounds on GraphQL queries, so config can set
+// them without importing the handler that enforces them.
+package querylimit
+
+// Limits bound what one query may ask for, so a single request cannot walk
+// the whole follow graph. Zero limits are not enforced.
+type Limits struct {
+	// MaxDepth is the deepest field nesting allowed; { me { name } } is 2.
+	MaxDepth int
+	// MaxComplexity bounds the estimated number of resolved fields. Every
+	// field costs 1 and list fields multiply the cost of their selection by
+	// their limit argument.
+	MaxComplexity int
+}
+
+// Default admits a full timeline page with authors and their latest tweets,
+// but not the followers of followers.
+var Default = Limits{MaxDepth: 6, MaxComplexity: 2000}
@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	limit := Limit{Requests: 3, Window: 3 * time.Second}
+
+	newStore := func() *MemoryStore {
+		s := NewMemoryStore()
+		s.now = func() time.Time { return now }
+		return s
+	}
+
+	t.Run("allows a burst then rejects", func(t *testing.T) {
+		s := newStore()
+
+		for i := 2; i >= 0; i-- {
+			r, err := s.Take(ctx, "k", limit)
+			require.NoError(t, err)
+			assert.True(t, r.Allowed)
+			assert.Equal(t, 3, r.Limit)
+			assert.Equal(t, i, r.Remaining)
+		}
+
+		r, err := s.Take(ctx, "k", limit)
+		require.NoError(t, err)
+		assert.False(t, r.Allowed)
+		assert.Equal(t, time.Second, r.RetryAfter)
+		assert.Equal(t, 3*time.Second, r.ResetAfter)
+	})
+
+	t.Run("tokens refill over time", func(t *testing.T) {
+		s := newStore()
+		for i := 0; i < 3; i++ {
+			_, _ = s.Take(ctx, "k", limit)
+		}
+
+		s.now = func() time.Time { return now.Add(time.Second) }
+		r, _ := s.Take(ctx, "k", limit)
+		assert.True(t, r.Allowed)
+
+		r, _ = s.Take(ctx, "k", limit)
+		assert.False(t, r.Allowed)
+	})
+
+	t.Run("keys are independent", func(t *testing.T) {
+		s := newStore()
+		for i := 0; i < 3; i++ {
+			_, _ = s.Take(ctx, "a", limit)
+		}
+
+		r, _ := s.Take(ctx, "b", limit)
+		assert.True(t, r.Allowed)
+	})
+
+	t.Run("burst above the average rate", func(t *testing.T) {
+		s := newStore()
+		bursty := Limit{Requests: 1, Window: time.Second, Burst: 5}
+
+		allowed := 0
+		for i := 0; i < 10; i++ {
+			if r, _ := s.Take(ctx, "k", bursty); r.Allowed {
+				allowed++
+			}
+		}
+		assert.Equal(t, 5, allowed)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		_, err := newStore().Take(ctx, "k", Limit{})
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+	})
+
+	t.Run("idle buckets are swept", func(t *testing.T) {
+		s := newStore()
+		for i := 0; i < sweepEvery-1; i++ {
+			_, _ = s.Take(ctx, fmt.Sprint("k", i), limit)
+		}
+		assert.Equal(t, sweepEvery-1, s.Len())
+
+		s.now = func() time.Time { return now.Add(time.Hour) }
+		_, _ = s.Take(ctx, "fresh", limit)
+
+		assert.Equal(t, 1, s.Len())
+	})
+}
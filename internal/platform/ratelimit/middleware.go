@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/httphelper"
+	"ualaTwitter/internal/platform/logger"
+)
+
+// Limiter applies named budgets, one per route, on top of a Store.
+type Limiter struct {
+	store  Store
+	limits map[string]Limit
+}
+
+func NewLimiter(store Store, limits map[string]Limit) *Limiter {
+	return &Limiter{
+		store:  store,
+		limits: limits,
+	}
+}
+
+// For returns the middleware enforcing the budget called name. Routes
+// without a configured budget are not limited.
+func (l *Limiter) For(name string) func(http.Handler) http.Handler {
+	limit, ok := l.limits[name]
+	if !ok {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	return Middleware(l.store, name, limit)
+}
+
+// Take spends one request of the budget called name for caller, a
+// CallerKey, for transports other than HTTP. ok is false when the budget is
+// not configured, and nothing is spent.
+func (l *Limiter) Take(ctx context.Context, name, caller string) (result Result, ok bool, err error) {
+	limit, ok := l.limits[name]
+	if !ok {
+		return Result{}, false, nil
+	}
+	result, err = l.store.Take(ctx, name+":"+caller, limit)
+	return result, true, err
+}
+
+// Middleware limits requests per authenticated user, or per client IP when
+// the request is anonymous. Every response carries X-RateLimit-* headers;
+// rejected ones get 429 and Retry-After. If the store fails the request is
+// let through.
+func Middleware(store Store, name string, limit Limit) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := name + ":" + CallerKey(r.Context(), r.RemoteAddr)
+
+			result, err := store.Take(r.Context(), key, limit)
+			if err != nil {
+				logger.FromContext(r.Context()).Warn("rate limit store failed, allowing request", zap.String("key", key), zap.Error(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+			h.Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			h.Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+			if !result.Allowed {
+				h.Set("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+				httphelper.RenderError(w, http.StatusTooManyRequests, "rate limit exceeded")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CallerKey identifies the caller: the authenticated user, or else the IP
+// of remoteAddr. The IP comes from the connection, not from forwarding
+// headers, which clients can forge. Every transport keys the same way, so
+// they share the budgets.
+func CallerKey(ctx context.Context, remoteAddr string) string {
+	if userID, ok := auth.UserIDFromContext(ctx); ok {
+		return "user:" + userID
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	return "ip:" + host
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
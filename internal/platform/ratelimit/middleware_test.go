@@ -0,0 +1,122 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"ualaTwitter/internal/platform/auth"
+	"ualaTwitter/internal/platform/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(_ context.Context, _ string, _ Limit) (Result, error) {
+	return Result{}, errors.New("store down")
+}
+
+func TestMiddleware(t *testing.T) {
+	limit := Limit{Requests: 2, Window: time.Minute}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	send := func(h http.Handler, userID, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/tweets", nil)
+		req.RemoteAddr = remoteAddr
+		if userID != "" {
+			req = req.WithContext(auth.WithUserID(req.Context(), userID))
+		}
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("rejects once the user's budget is spent", func(t *testing.T) {
+		h := Middleware(NewMemoryStore(), "post_tweet", limit)(ok)
+
+		first := send(h, "usr_1", "10.0.0.1:1234")
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, "2", first.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "1", first.Header().Get("X-RateLimit-Remaining"))
+		assert.Equal(t, "30", first.Header().Get("X-RateLimit-Reset"))
+
+		assert.Equal(t, http.StatusOK, send(h, "usr_1", "10.0.0.2:1234").Code)
+
+		rejected := send(h, "usr_1", "10.0.0.3:1234")
+		assert.Equal(t, http.StatusTooManyRequests, rejected.Code)
+		assert.Equal(t, "30", rejected.Header().Get("Retry-After"))
+		assert.Equal(t, "0", rejected.Header().Get("X-RateLimit-Remaining"))
+
+		assert.Equal(t, http.StatusOK, send(h, "usr_2", "10.0.0.3:1234").Code)
+	})
+
+	t.Run("anonymous requests are keyed by IP", func(t *testing.T) {
+		h := Middleware(NewMemoryStore(), "issue_token", limit)(ok)
+
+		send(h, "", "10.0.0.1:1111")
+		send(h, "", "10.0.0.1:2222")
+		assert.Equal(t, http.StatusTooManyRequests, send(h, "", "10.0.0.1:3333").Code)
+		assert.Equal(t, http.StatusOK, send(h, "", "10.0.0.2:1111").Code)
+	})
+
+	t.Run("routes have separate budgets", func(t *testing.T) {
+		store := NewMemoryStore()
+		limiter := NewLimiter(store, map[string]Limit{"post_tweet": limit, "like_tweet": limit})
+
+		posts := limiter.For("post_tweet")(ok)
+		likes := limiter.For("like_tweet")(ok)
+		send(posts, "usr_1", "")
+		send(posts, "usr_1", "")
+
+		assert.Equal(t, http.StatusTooManyRequests, send(posts, "usr_1", "").Code)
+		assert.Equal(t, http.StatusOK, send(likes, "usr_1", "").Code)
+	})
+
+	t.Run("unconfigured routes are not limited", func(t *testing.T) {
+		h := NewLimiter(NewMemoryStore(), nil).For("follow_user")(ok)
+
+		rr := send(h, "usr_1", "")
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get("X-RateLimit-Limit"))
+	})
+
+	t.Run("store failure lets the request through", func(t *testing.T) {
+		h := Middleware(failingStore{}, "post_tweet", limit)(ok)
+
+		assert.Equal(t, http.StatusOK, send(h, "usr_1", "").Code)
+	})
+}
+
+func TestLimiter_Take(t *testing.T) {
+	ctx := context.Background()
+	limiter := NewLimiter(NewMemoryStore(), map[string]Limit{"post_tweet": {Requests: 1, Window: time.Minute}})
+
+	t.Run("shares the HTTP bucket of the caller", func(t *testing.T) {
+		h := limiter.For("post_tweet")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		req := httptest.NewRequest(http.MethodPost, "/tweets", nil)
+		h.ServeHTTP(httptest.NewRecorder(), req.WithContext(auth.WithUserID(ctx, "usr_1")))
+
+		userCtx := auth.WithUserID(ctx, "usr_1")
+		result, ok, err := limiter.Take(userCtx, "post_tweet", CallerKey(userCtx, "10.0.0.9:1"))
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.False(t, result.Allowed)
+	})
+
+	t.Run("unknown budgets are not limited", func(t *testing.T) {
+		_, ok, err := limiter.Take(ctx, "like_tweet", CallerKey(ctx, "10.0.0.9:1"))
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,101 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidLimit = errors.New("invalid rate limit")
+
+// Budget names shared by the routes that spend them and the config that
+// sizes them.
+const (
+	LimitPostTweet  = "post_tweet"
+	LimitLikeTweet  = "like_tweet"
+	LimitFollowUser = "follow_user"
+	LimitIssueToken = "issue_token"
+	LimitCreateUser = "create_user"
+)
+
+// Limit is a token bucket budget: Requests per Window on average, with
+// bursts of up to Burst requests. A zero Burst means Requests.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+	Burst    int
+}
+
+func (l Limit) capacity() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return float64(l.Requests)
+}
+
+// ratePerSecond is how many tokens the bucket regains per second.
+func (l Limit) ratePerSecond() float64 {
+	return float64(l.Requests) / l.Window.Seconds()
+}
+
+func (l Limit) valid() bool {
+	return l.Requests > 0 && l.Window > 0 && l.Burst >= 0
+}
+
+// Result describes the bucket after a Take.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is how long until the next request would be allowed; zero
+	// when Allowed.
+	RetryAfter time.Duration
+	// ResetAfter is how long until the bucket is full again.
+	ResetAfter time.Duration
+}
+
+// Store keeps the buckets. Implementations must make Take atomic per key;
+// sharing one Store across replicas shares the limits.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// ParseLimit reads "<requests>/<window>[,burst=<n>]", e.g. "30/1m" or
+// "10/1s,burst=20".
+func ParseLimit(spec string) (Limit, error) {
+	budget, options, _ := strings.Cut(strings.TrimSpace(spec), ",")
+
+	requests, window, found := strings.Cut(budget, "/")
+	if !found {
+		return Limit{}, fmt.Errorf("%w: expected <requests>/<window>, got %q", ErrInvalidLimit, spec)
+	}
+
+	var (
+		l   Limit
+		err error
+	)
+	if l.Requests, err = strconv.Atoi(strings.TrimSpace(requests)); err != nil {
+		return Limit{}, fmt.Errorf("%w: bad request count in %q", ErrInvalidLimit, spec)
+	}
+	if l.Window, err = time.ParseDuration(strings.TrimSpace(window)); err != nil {
+		return Limit{}, fmt.Errorf("%w: bad window in %q", ErrInvalidLimit, spec)
+	}
+
+	if options != "" {
+		burst, ok := strings.CutPrefix(strings.TrimSpace(options), "burst=")
+		if !ok {
+			return Limit{}, fmt.Errorf("%w: unknown option in %q", ErrInvalidLimit, spec)
+		}
+		if l.Burst, err = strconv.Atoi(burst); err != nil {
+			return Limit{}, fmt.Errorf("%w: bad burst in %q", ErrInvalidLimit, spec)
+		}
+	}
+
+	if !l.valid() {
+		return Limit{}, fmt.Errorf("%w: %q must allow at least one request per positive window", ErrInvalidLimit, spec)
+	}
+	return l, nil
+}
@@ -0,0 +1,40 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected Limit
+		wantErr  bool
+	}{
+		{spec: "30/1m", expected: Limit{Requests: 30, Window: time.Minute}},
+		{spec: " 10 / 1s ", expected: Limit{Requests: 10, Window: time.Second}},
+		{spec: "10/1s,burst=20", expected: Limit{Requests: 10, Window: time.Second, Burst: 20}},
+		{spec: "30", wantErr: true},
+		{spec: "x/1m", wantErr: true},
+		{spec: "30/forever", wantErr: true},
+		{spec: "0/1m", wantErr: true},
+		{spec: "30/0s", wantErr: true},
+		{spec: "30/1m,burst=x", wantErr: true},
+		{spec: "30/1m,jitter=1", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.spec, func(t *testing.T) {
+			l, err := ParseLimit(tc.spec)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidLimit)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, l)
+		})
+	}
+}
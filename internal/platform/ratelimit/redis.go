@@ -0,0 +1,97 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix keeps buckets apart from other data in a shared Redis.
+const redisKeyPrefix = "ratelimit:"
+
+// takeToken refills and spends from the bucket in KEYS[1] in one step, so
+// replicas sharing the Redis never both spend the last token. ARGV is the
+// capacity, the refill rate in tokens per millisecond, the caller's clock in
+// milliseconds and the key's TTL in milliseconds. It returns whether the
+// token was taken and the tokens left, as a string since Lua numbers would be
+// truncated to integers.
+var takeToken = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'last')
+local tokens = tonumber(bucket[1]) or capacity
+local last = tonumber(bucket[2]) or now
+
+if now > last then
+	tokens = math.min(capacity, tokens + (now - last) * rate)
+	last = now
+end
+tokens = math.min(capacity, tokens)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'last', last)
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+return {allowed, tostring(tokens)}
+`)
+
+// RedisStore keeps buckets in Redis, so every replica pointed at the same
+// server shares the limits. A bucket expires once it would have refilled.
+type RedisStore struct {
+	client *redis.Client
+	now    func() time.Time
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	if !limit.valid() {
+		return Result{}, ErrInvalidLimit
+	}
+
+	capacity := limit.capacity()
+	rate := limit.ratePerSecond()
+	ttl := int64(math.Ceil(capacity / rate * 1000))
+
+	reply, err := takeToken.Run(ctx, s.client, []string{redisKeyPrefix + key},
+		capacity, rate/1000, s.now().UnixMilli(), max(ttl, 1)).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	allowed, _ := reply[0].(int64)
+	left, _ := reply[1].(string)
+	tokens, err := strconv.ParseFloat(left, 64)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{Limit: int(capacity), Allowed: allowed == 1}
+	if !result.Allowed {
+		result.RetryAfter = secondsToDuration((1 - tokens) / rate)
+	}
+	result.Remaining = int(math.Floor(tokens))
+	result.ResetAfter = secondsToDuration((capacity - tokens) / rate)
+	return result, nil
+}
+
+func (s *RedisStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
+
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisStore_Take(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	limit := Limit{Requests: 3, Window: 3 * time.Second}
+	server := miniredis.RunT(t)
+
+	newStore := func() *RedisStore {
+		server.FlushAll()
+		s := NewRedisStore(redis.NewClient(&redis.Options{Addr: server.Addr()}))
+		t.Cleanup(func() { _ = s.Close() })
+		s.now = func() time.Time { return now }
+		return s
+	}
+
+	t.Run("allows a burst then rejects", func(t *testing.T) {
+		s := newStore()
+
+		for i := 2; i >= 0; i-- {
+			r, err := s.Take(ctx, "k", limit)
+			require.NoError(t, err)
+			assert.True(t, r.Allowed)
+			assert.Equal(t, 3, r.Limit)
+			assert.Equal(t, i, r.Remaining)
+		}
+
+		r, err := s.Take(ctx, "k", limit)
+		require.NoError(t, err)
+		assert.False(t, r.Allowed)
+		assert.Equal(t, time.Second, r.RetryAfter)
+		assert.Equal(t, 3*time.Second, r.ResetAfter)
+		assert.Equal(t, 3*time.Second, server.TTL("ratelimit:k"))
+	})
+
+	t.Run("tokens refill over time", func(t *testing.T) {
+		s := newStore()
+		for i := 0; i < 3; i++ {
+			_, _ = s.Take(ctx, "k", limit)
+		}
+
+		s.now = func() time.Time { return now.Add(time.Second) }
+		r, _ := s.Take(ctx, "k", limit)
+		assert.True(t, r.Allowed)
+
+		r, _ = s.Take(ctx, "k", limit)
+		assert.False(t, r.Allowed)
+	})
+
+	t.Run("replicas share the bucket", func(t *testing.T) {
+		a, b := newStore(), newStore()
+		for i := 0; i < 3; i++ {
+			_, _ = a.Take(ctx, "k", limit)
+		}
+
+		r, err := b.Take(ctx, "k", limit)
+		require.NoError(t, err)
+		assert.False(t, r.Allowed)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		_, err := newStore().Take(ctx, "k", Limit{})
+		assert.ErrorIs(t, err, ErrInvalidLimit)
+	})
+
+	t.Run("unreachable server fails", func(t *testing.T) {
+		s := NewRedisStore(redis.NewClient(&redis.Options{Addr: "127.0.0.1:1"}))
+		t.Cleanup(func() { _ = s.Close() })
+		_, err := s.Take(ctx, "k", limit)
+		assert.Error(t, err)
+	})
+}
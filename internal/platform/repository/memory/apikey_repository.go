@@ -0,0 +1,89 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+	"ualaTwitter/internal/domain/apikey"
+)
+
+type InMemoryAPIKeyRepository struct {
+	mu     sync.RWMutex
+	byID   map[string]apikey.APIKey
+	byHash map[string]string
+}
+
+func NewInMemoryAPIKeyRepository() *InMemoryAPIKeyRepository {
+	return &InMemoryAPIKeyRepository{
+		byID:   make(map[string]apikey.APIKey),
+		byHash: make(map[string]string),
+	}
+}
+
+func (r *InMemoryAPIKeyRepository) Create(ctx context.Context, key apikey.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.byID[key.ID] = key
+	r.byHash[key.Hash] = key.ID
+	return nil
+}
+
+func (r *InMemoryAPIKeyRepository) GetByHash(ctx context.Context, hash string) (apikey.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.byHash[hash]
+	if !ok {
+		return apikey.APIKey{}, apikey.ErrNotFound
+	}
+	return r.byID[id], nil
+}
+
+// ListByUser returns the user's keys, newest first.
+func (r *InMemoryAPIKeyRepository) ListByUser(ctx context.Context, userID string) ([]apikey.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]apikey.APIKey, 0)
+	for _, k := range r.byID {
+		if k.UserID == userID {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+// Revoke marks the key as revoked. Keys owned by another user are reported
+// as not found.
+func (r *InMemoryAPIKeyRepository) Revoke(ctx context.Context, userID, keyID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k, ok := r.byID[keyID]
+	if !ok || k.UserID != userID {
+		return apikey.ErrNotFound
+	}
+	if !k.IsRevoked() {
+		k.RevokedAt = at
+		r.byID[keyID] = k
+	}
+	return nil
+}
+
+func (r *InMemoryAPIKeyRepository) TouchLastUsed(ctx context.Context, keyID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k, ok := r.byID[keyID]
+	if !ok {
+		return apikey.ErrNotFound
+	}
+	k.LastUsedAt = at
+	r.byID[keyID] = k
+	return nil
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"ualaTwitter/internal/domain/apikey"
+)
+
+func TestInMemoryAPIKeyRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryAPIKeyRepository()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	older := apikey.APIKey{ID: "k1", UserID: "usr1", Hash: "h1", CreatedAt: now}
+	newer := apikey.APIKey{ID: "k2", UserID: "usr1", Hash: "h2", CreatedAt: now.Add(time.Hour)}
+	other := apikey.APIKey{ID: "k3", UserID: "usr2", Hash: "h3", CreatedAt: now}
+	for _, k := range []apikey.APIKey{older, newer, other} {
+		require.NoError(t, repo.Create(ctx, k))
+	}
+
+	t.Run("GetByHash finds the key", func(t *testing.T) {
+		got, err := repo.GetByHash(ctx, "h2")
+		assert.NoError(t, err)
+		assert.Equal(t, "k2", got.ID)
+
+		_, err = repo.GetByHash(ctx, "nope")
+		assert.ErrorIs(t, err, apikey.ErrNotFound)
+	})
+
+	t.Run("ListByUser returns own keys newest first", func(t *testing.T) {
+		keys, err := repo.ListByUser(ctx, "usr1")
+		assert.NoError(t, err)
+		require.Len(t, keys, 2)
+		assert.Equal(t, "k2", keys[0].ID)
+		assert.Equal(t, "k1", keys[1].ID)
+
+		keys, err = repo.ListByUser(ctx, "nobody")
+		assert.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+
+	t.Run("Revoke only applies to the owner's keys", func(t *testing.T) {
+		assert.ErrorIs(t, repo.Revoke(ctx, "usr2", "k1", now), apikey.ErrNotFound)
+		assert.NoError(t, repo.Revoke(ctx, "usr1", "k1", now))
+
+		got, _ := repo.GetByHash(ctx, "h1")
+		assert.True(t, got.IsRevoked())
+
+		assert.NoError(t, repo.Revoke(ctx, "usr1", "k1", now.Add(time.Hour)))
+		got, _ = repo.GetByHash(ctx, "h1")
+		assert.Equal(t, now, got.RevokedAt)
+	})
+
+	t.Run("TouchLastUsed records the timestamp", func(t *testing.T) {
+		assert.NoError(t, repo.TouchLastUsed(ctx, "k3", now))
+		got, _ := repo.GetByHash(ctx, "h3")
+		assert.Equal(t, now, got.LastUsedAt)
+
+		assert.ErrorIs(t, repo.TouchLastUsed(ctx, "nope", now), apikey.ErrNotFound)
+	})
+}
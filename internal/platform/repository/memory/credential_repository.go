@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+	"ualaTwitter/internal/domain/credential"
+)
+
+type InMemoryCredentialRepository struct {
+	mu          sync.RWMutex
+	credentials map[string]credential.Credential
+}
+
+func NewInMemoryCredentialRepository() *InMemoryCredentialRepository {
+	return &InMemoryCredentialRepository{
+		credentials: make(map[string]credential.Credential),
+	}
+}
+
+func (r *InMemoryCredentialRepository) Create(ctx context.Context, c credential.Credential) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.credentials[c.UserID]; exists {
+		return credential.ErrAlreadyExists
+	}
+	r.credentials[c.UserID] = c
+	return nil
+}
+
+func (r *InMemoryCredentialRepository) GetByUserID(ctx context.Context, userID string) (credential.Credential, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, exists := r.credentials[userID]
+	if !exists {
+		return credential.Credential{}, credential.ErrNotFound
+	}
+	return c, nil
+}
+
+func (r *InMemoryCredentialRepository) RecordFailure(ctx context.Context, userID string, now time.Time, maxAttempts int, lockout time.Duration) (credential.Credential, error) {
+	var updated credential.Credential
+	err := r.modify(userID, func(c *credential.Credential) {
+		c.RegisterFailure(now, maxAttempts, lockout)
+		updated = *c
+	})
+	return updated, err
+}
+
+func (r *InMemoryCredentialRepository) ResetFailures(ctx context.Context, userID string, now time.Time) error {
+	return r.modify(userID, func(c *credential.Credential) {
+		if !c.IsLocked(now) {
+			c.RegisterSuccess(now)
+		}
+	})
+}
+
+func (r *InMemoryCredentialRepository) ChangePassword(ctx context.Context, userID, hash string, now time.Time) error {
+	return r.modify(userID, func(c *credential.Credential) {
+		c.ChangePassword(hash, now)
+	})
+}
+
+// modify applies change to the stored credential under the write lock.
+func (r *InMemoryCredentialRepository) modify(userID string, change func(c *credential.Credential)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, exists := r.credentials[userID]
+	if !exists {
+		return credential.ErrNotFound
+	}
+	change(&c)
+	r.credentials[userID] = c
+	return nil
+}
@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"ualaTwitter/internal/domain/credential"
+)
+
+func TestInMemoryCredentialRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryCredentialRepository()
+
+	t.Run("Create and GetByUserID", func(t *testing.T) {
+		c := credential.Credential{UserID: "usr1", PasswordHash: "hash"}
+		assert.NoError(t, repo.Create(ctx, c))
+
+		got, err := repo.GetByUserID(ctx, "usr1")
+		assert.NoError(t, err)
+		assert.Equal(t, c, got)
+	})
+
+	t.Run("Create rejects duplicates", func(t *testing.T) {
+		err := repo.Create(ctx, credential.Credential{UserID: "usr1"})
+		assert.ErrorIs(t, err, credential.ErrAlreadyExists)
+	})
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("RecordFailure counts failures and locks", func(t *testing.T) {
+		got, err := repo.RecordFailure(ctx, "usr1", now, 2, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got.FailedAttempts)
+
+		got, err = repo.RecordFailure(ctx, "usr1", now, 2, time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, got.IsLocked(now))
+
+		stored, _ := repo.GetByUserID(ctx, "usr1")
+		assert.Equal(t, got, stored)
+	})
+
+	t.Run("ResetFailures keeps a lock in force", func(t *testing.T) {
+		assert.NoError(t, repo.ResetFailures(ctx, "usr1", now))
+		stored, _ := repo.GetByUserID(ctx, "usr1")
+		assert.True(t, stored.IsLocked(now))
+
+		assert.NoError(t, repo.ResetFailures(ctx, "usr1", now.Add(time.Minute)))
+		stored, _ = repo.GetByUserID(ctx, "usr1")
+		assert.False(t, stored.IsLocked(now))
+	})
+
+	t.Run("ChangePassword replaces the hash and bumps the session version", func(t *testing.T) {
+		assert.NoError(t, repo.ChangePassword(ctx, "usr1", "new", now))
+
+		got, err := repo.GetByUserID(ctx, "usr1")
+		assert.NoError(t, err)
+		assert.Equal(t, "new", got.PasswordHash)
+		assert.Equal(t, int64(1), got.SessionVersion)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := repo.GetByUserID(ctx, "ghost")
+		assert.ErrorIs(t, err, credential.ErrNotFound)
+		_, err = repo.RecordFailure(ctx, "ghost", now, 2, time.Minute)
+		assert.ErrorIs(t, err, credential.ErrNotFound)
+		assert.ErrorIs(t, repo.ResetFailures(ctx, "ghost", now), credential.ErrNotFound)
+		assert.ErrorIs(t, repo.ChangePassword(ctx, "ghost", "new", now), credential.ErrNotFound)
+	})
+}
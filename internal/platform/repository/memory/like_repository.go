@@ -35,3 +35,89 @@ func (r *InMemoryLikeRepository) HasLiked(ctx context.Context, userID, tweetID s
 	_, ok := r.likes[userID][tweetID]
 	return ok, nil
 }
+
+// HasLikedMany reports, for every tweet in tweetIDs, whether userID liked it.
+func (r *InMemoryLikeRepository) HasLikedMany(ctx context.Context, userID string, tweetIDs []string) (map[string]bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	liked := make(map[string]bool, len(tweetIDs))
+	for _, tid := range tweetIDs {
+		_, liked[tid] = r.likes[userID][tid]
+	}
+
+	return liked, nil
+}
+
+func (r *InMemoryLikeRepository) FindTweetsLikedBy(ctx context.Context, userID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tweetIDs := make([]string, 0, len(r.likes[userID]))
+	for tid := range r.likes[userID] {
+		tweetIDs = append(tweetIDs, tid)
+	}
+
+	return tweetIDs, nil
+}
+
+// Count returns the number of stored likes.
+func (r *InMemoryLikeRepository) Count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	likes := 0
+	for _, tweets := range r.likes {
+		likes += len(tweets)
+	}
+	return likes
+}
+
+// CountByTweet returns the number of likes of every liked tweet.
+func (r *InMemoryLikeRepository) CountByTweet(ctx context.Context) (map[string]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, tweets := range r.likes {
+		for tid := range tweets {
+			counts[tid]++
+		}
+	}
+	return counts, nil
+}
+
+// DeleteForTweets removes every like of the given tweets and returns how
+// many there were.
+func (r *InMemoryLikeRepository) DeleteForTweets(ctx context.Context, tweetIDs []string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for userID, tweets := range r.likes {
+		for _, tid := range tweetIDs {
+			if _, ok := tweets[tid]; ok {
+				delete(tweets, tid)
+				deleted++
+			}
+		}
+		if len(tweets) == 0 {
+			delete(r.likes, userID)
+		}
+	}
+	return deleted, nil
+}
+
+// AllLikes returns the liked tweets of every user who liked something.
+func (r *InMemoryLikeRepository) AllLikes(ctx context.Context) (map[string][]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	likes := make(map[string][]string, len(r.likes))
+	for userID, tweets := range r.likes {
+		for tid := range tweets {
+			likes[userID] = append(likes[userID], tid)
+		}
+	}
+	return likes, nil
+}
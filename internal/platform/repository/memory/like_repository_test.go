@@ -64,4 +64,71 @@ func TestInMemoryLikeRepository(t *testing.T) {
 		assert.NoError(t, err)
 		assert.False(t, liked)
 	})
+
+	t.Run("FindTweetsLikedBy returns every tweet liked by the user", func(t *testing.T) {
+		userID := "userY"
+		assert.NoError(t, repo.Like(ctx, userID, "tweetC"))
+		assert.NoError(t, repo.Like(ctx, userID, "tweetD"))
+
+		tweetIDs, err := repo.FindTweetsLikedBy(ctx, userID)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"tweetC", "tweetD"}, tweetIDs)
+	})
+
+	t.Run("FindTweetsLikedBy returns empty for unknown user", func(t *testing.T) {
+		tweetIDs, err := repo.FindTweetsLikedBy(ctx, "ghost")
+		assert.NoError(t, err)
+		assert.Empty(t, tweetIDs)
+	})
+
+	t.Run("HasLikedMany reports each tweet in one call", func(t *testing.T) {
+		userID := "userZ"
+		assert.NoError(t, repo.Like(ctx, userID, "tweetE"))
+		assert.NoError(t, repo.Like(ctx, "someoneElse", "tweetF"))
+
+		liked, err := repo.HasLikedMany(ctx, userID, []string{"tweetE", "tweetF", "tweetG"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]bool{"tweetE": true, "tweetF": false, "tweetG": false}, liked)
+	})
+
+	t.Run("Count returns the number of likes", func(t *testing.T) {
+		repo := NewInMemoryLikeRepository()
+		assert.NoError(t, repo.Like(ctx, "u1", "t1"))
+		assert.NoError(t, repo.Like(ctx, "u1", "t1"))
+		assert.NoError(t, repo.Like(ctx, "u2", "t1"))
+
+		assert.Equal(t, 2, repo.Count())
+	})
+
+	t.Run("CountByTweet and DeleteForTweets", func(t *testing.T) {
+		repo := NewInMemoryLikeRepository()
+		assert.NoError(t, repo.Like(ctx, "u1", "t1"))
+		assert.NoError(t, repo.Like(ctx, "u2", "t1"))
+		assert.NoError(t, repo.Like(ctx, "u2", "t2"))
+		assert.NoError(t, repo.Like(ctx, "u3", "t3"))
+
+		counts, err := repo.CountByTweet(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"t1": 2, "t2": 1, "t3": 1}, counts)
+
+		deleted, err := repo.DeleteForTweets(ctx, []string{"t1", "t3"})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, deleted)
+		assert.Equal(t, 1, repo.Count())
+		liked, _ := repo.HasLiked(ctx, "u2", "t2")
+		assert.True(t, liked)
+	})
+
+	t.Run("AllLikes lists the likes of every user", func(t *testing.T) {
+		repo := NewInMemoryLikeRepository()
+		assert.NoError(t, repo.Like(ctx, "u1", "t1"))
+		assert.NoError(t, repo.Like(ctx, "u1", "t2"))
+		assert.NoError(t, repo.Like(ctx, "u2", "t1"))
+
+		likes, err := repo.AllLikes(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, likes, 2)
+		assert.ElementsMatch(t, []string{"t1", "t2"}, likes["u1"])
+		assert.Equal(t, []string{"t1"}, likes["u2"])
+	})
 }
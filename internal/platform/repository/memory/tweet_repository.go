@@ -38,11 +38,25 @@ func (r *InMemoryTweetRepository) GetByID(ctx context.Context, id string) (tweet
 
 	t, ok := r.byID[id]
 	if !ok {
-		return tweet.Tweet{}, fmt.Errorf("tweet not found")
+		return tweet.Tweet{}, fmt.Errorf("tweet %s: %w", id, tweet.ErrNotFound)
 	}
 	return t, nil
 }
 
+// GetByIDs returns the tweets that exist among ids, skipping unknown ones.
+func (r *InMemoryTweetRepository) GetByIDs(ctx context.Context, ids []string) ([]tweet.Tweet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tweets := make([]tweet.Tweet, 0, len(ids))
+	for _, id := range ids {
+		if t, ok := r.byID[id]; ok {
+			tweets = append(tweets, t)
+		}
+	}
+	return tweets, nil
+}
+
 func (r *InMemoryTweetRepository) FindTweetsAuthoredBy(ctx context.Context, userID string) ([]tweet.Tweet, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -60,7 +74,7 @@ func (r *InMemoryTweetRepository) IncrementLikes(ctx context.Context, tweetID st
 
 	t, ok := r.byID[tweetID]
 	if !ok {
-		return fmt.Errorf("tweet not found")
+		return fmt.Errorf("tweet %s: %w", tweetID, tweet.ErrNotFound)
 	}
 	t.Likes++
 	r.byID[tweetID] = t
@@ -75,3 +89,67 @@ func (r *InMemoryTweetRepository) IncrementLikes(ctx context.Context, tweetID st
 
 	return nil
 }
+
+// Count returns the number of stored tweets.
+func (r *InMemoryTweetRepository) Count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.byID)
+}
+
+// All returns every stored tweet, in no particular order.
+func (r *InMemoryTweetRepository) All(ctx context.Context) ([]tweet.Tweet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tweets := make([]tweet.Tweet, 0, len(r.byID))
+	for _, t := range r.byID {
+		tweets = append(tweets, t)
+	}
+	return tweets, nil
+}
+
+// SetLikesIf overwrites the like counter of a tweet if it still holds
+// expected. It reports false, changing nothing, when a like landed since the
+// caller read the counter.
+func (r *InMemoryTweetRepository) SetLikesIf(ctx context.Context, tweetID string, expected, likes int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.byID[tweetID]
+	if !ok {
+		return false, fmt.Errorf("tweet %s: %w", tweetID, tweet.ErrNotFound)
+	}
+	if t.Likes != expected {
+		return false, nil
+	}
+	t.Likes = likes
+	r.byID[tweetID] = t
+
+	userTweets := r.byUser[t.UserID]
+	for i, tw := range userTweets {
+		if tw.ID == tweetID {
+			userTweets[i] = t
+			break
+		}
+	}
+
+	return true, nil
+}
+
+// DeleteAuthoredBy removes every tweet of userID and returns their IDs.
+func (r *InMemoryTweetRepository) DeleteAuthoredBy(ctx context.Context, userID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tweets := r.byUser[userID]
+	ids := make([]string, len(tweets))
+	for i, t := range tweets {
+		ids[i] = t.ID
+		delete(r.byID, t.ID)
+	}
+	delete(r.byUser, userID)
+
+	return ids, nil
+}
@@ -37,6 +37,18 @@ func TestInMemoryTweetRepository(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("GetByIDs returns existing tweets and skips unknown ones", func(t *testing.T) {
+		repo := NewInMemoryTweetRepository()
+		tw1 := makeMockTweet(userID, "First", time.Now(), 0)
+		tw2 := makeMockTweet(userID, "Second", time.Now(), 0)
+		assert.NoError(t, repo.Save(ctx, tw1))
+		assert.NoError(t, repo.Save(ctx, tw2))
+
+		tweets, err := repo.GetByIDs(ctx, []string{tw1.ID, "ghost", tw2.ID})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []tweet.Tweet{tw1, tw2}, tweets)
+	})
+
 	t.Run("FindTweetsAuthoredBy returns all tweets for user", func(t *testing.T) {
 		repo := NewInMemoryTweetRepository()
 		tw1 := makeMockTweet(userID, "First", time.Now(), 0)
@@ -80,4 +92,65 @@ func TestInMemoryTweetRepository(t *testing.T) {
 		err := repo.IncrementLikes(ctx, "no-such-tweet")
 		assert.Error(t, err)
 	})
+
+	t.Run("Count returns the number of tweets", func(t *testing.T) {
+		repo := NewInMemoryTweetRepository()
+		assert.NoError(t, repo.Save(ctx, makeMockTweet(userID, "one", time.Now(), 0)))
+		assert.NoError(t, repo.Save(ctx, makeMockTweet(userID, "two", time.Now(), 0)))
+
+		assert.Equal(t, 2, repo.Count())
+	})
+
+	t.Run("SetLikesIf overwrites the like count in all views", func(t *testing.T) {
+		repo := NewInMemoryTweetRepository()
+		tw := makeMockTweet(userID, "Recount me", time.Now(), 7)
+		assert.NoError(t, repo.Save(ctx, tw))
+
+		ok, err := repo.SetLikesIf(ctx, tw.ID, 7, 2)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		got, _ := repo.GetByID(ctx, tw.ID)
+		assert.Equal(t, 2, got.Likes)
+		userTweets, _ := repo.FindTweetsAuthoredBy(ctx, userID)
+		assert.Equal(t, 2, userTweets[0].Likes)
+
+		_, err = repo.SetLikesIf(ctx, "no-such-tweet", 0, 1)
+		assert.ErrorIs(t, err, tweet.ErrNotFound)
+	})
+
+	t.Run("SetLikesIf keeps a counter that moved", func(t *testing.T) {
+		repo := NewInMemoryTweetRepository()
+		tw := makeMockTweet(userID, "Liked meanwhile", time.Now(), 3)
+		assert.NoError(t, repo.Save(ctx, tw))
+		assert.NoError(t, repo.IncrementLikes(ctx, tw.ID))
+
+		ok, err := repo.SetLikesIf(ctx, tw.ID, 3, 1)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		got, _ := repo.GetByID(ctx, tw.ID)
+		assert.Equal(t, 4, got.Likes)
+	})
+
+	t.Run("All and DeleteAuthoredBy", func(t *testing.T) {
+		repo := NewInMemoryTweetRepository()
+		keep := makeMockTweet("usr2", "Keep", time.Now(), 0)
+		assert.NoError(t, repo.Save(ctx, makeMockTweet(userID, "one", time.Now(), 0)))
+		assert.NoError(t, repo.Save(ctx, makeMockTweet(userID, "two", time.Now(), 0)))
+		assert.NoError(t, repo.Save(ctx, keep))
+
+		all, err := repo.All(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, all, 3)
+
+		deleted, err := repo.DeleteAuthoredBy(ctx, userID)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{userID + "-one", userID + "-two"}, deleted)
+		assert.Equal(t, 1, repo.Count())
+		_, err = repo.FindTweetsAuthoredBy(ctx, userID)
+		assert.Error(t, err)
+
+		deleted, err = repo.DeleteAuthoredBy(ctx, "ghost")
+		assert.NoError(t, err)
+		assert.Empty(t, deleted)
+	})
 }
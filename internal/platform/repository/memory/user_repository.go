@@ -7,15 +7,17 @@ import (
 )
 
 type InMemoryUserRepository struct {
-	mu      sync.RWMutex
-	follows map[string]map[string]struct{}
-	users   map[string]user.User
+	mu        sync.RWMutex
+	follows   map[string]map[string]struct{}
+	followers map[string]map[string]struct{}
+	users     map[string]user.User
 }
 
 func NewInMemoryUserRepository() *InMemoryUserRepository {
 	return &InMemoryUserRepository{
-		follows: make(map[string]map[string]struct{}),
-		users:   make(map[string]user.User),
+		follows:   make(map[string]map[string]struct{}),
+		followers: make(map[string]map[string]struct{}),
+		users:     make(map[string]user.User),
 	}
 }
 
@@ -41,6 +43,20 @@ func (r *InMemoryUserRepository) GetByID(ctx context.Context, id string) (user.U
 	return u, nil
 }
 
+// GetByIDs returns the users that exist among ids, skipping unknown ones.
+func (r *InMemoryUserRepository) GetByIDs(ctx context.Context, ids []string) ([]user.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]user.User, 0, len(ids))
+	for _, id := range ids {
+		if u, exists := r.users[id]; exists {
+			users = append(users, u)
+		}
+	}
+	return users, nil
+}
+
 func (r *InMemoryUserRepository) Follow(ctx context.Context, followerID, followeeID string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -50,6 +66,12 @@ func (r *InMemoryUserRepository) Follow(ctx context.Context, followerID, followe
 	}
 
 	r.follows[followerID][followeeID] = struct{}{}
+
+	if r.followers[followeeID] == nil {
+		r.followers[followeeID] = make(map[string]struct{})
+	}
+
+	r.followers[followeeID][followerID] = struct{}{}
 	return nil
 }
 
@@ -65,3 +87,62 @@ func (r *InMemoryUserRepository) GetUsersFollowedBy(ctx context.Context, userID
 
 	return followees, nil
 }
+
+func (r *InMemoryUserRepository) GetFollowersOf(ctx context.Context, userID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	followers := make([]string, 0, len(r.followers[userID]))
+
+	for fid := range r.followers[userID] {
+		followers = append(followers, fid)
+	}
+
+	return followers, nil
+}
+
+// Count returns the number of stored users.
+func (r *InMemoryUserRepository) Count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.users)
+}
+
+// FollowEdges returns the number of follower→followee pairs.
+func (r *InMemoryUserRepository) FollowEdges() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	edges := 0
+	for _, followees := range r.follows {
+		edges += len(followees)
+	}
+	return edges
+}
+
+// All returns every stored user, in no particular order.
+func (r *InMemoryUserRepository) All(ctx context.Context) ([]user.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]user.User, 0, len(r.users))
+	for _, u := range r.users {
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+// AllFollows returns the followees of every user who follows someone.
+func (r *InMemoryUserRepository) AllFollows(ctx context.Context) (map[string][]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	follows := make(map[string][]string, len(r.follows))
+	for followerID, followees := range r.follows {
+		for fid := range followees {
+			follows[followerID] = append(follows[followerID], fid)
+		}
+	}
+	return follows, nil
+}
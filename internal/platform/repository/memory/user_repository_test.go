@@ -42,6 +42,18 @@ func TestInMemoryUserRepository(t *testing.T) {
 		assert.ErrorIs(t, err, user.ErrUserNotFound)
 	})
 
+	t.Run("GetByIDs returns existing users and skips unknown ones", func(t *testing.T) {
+		repo := NewInMemoryUserRepository()
+		u1 := makeMockUser("usr_a", "A", "1111111")
+		u2 := makeMockUser("usr_b", "B", "2222222")
+		assert.NoError(t, repo.Create(ctx, u1))
+		assert.NoError(t, repo.Create(ctx, u2))
+
+		users, err := repo.GetByIDs(ctx, []string{u1.ID, "ghost", u2.ID})
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []user.User{u1, u2}, users)
+	})
+
 	t.Run("Follow adds followee, GetUsersFollowedBy returns them", func(t *testing.T) {
 		repo = NewInMemoryUserRepository()
 		follower := makeMockUser("follower", "Follower", "111")
@@ -83,4 +95,64 @@ func TestInMemoryUserRepository(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Len(t, followees, 0)
 	})
+
+	t.Run("GetFollowersOf returns every follower of a user", func(t *testing.T) {
+		repo = NewInMemoryUserRepository()
+		followee := makeMockUser("star", "Star", "777")
+		fan1 := makeMockUser("fan1", "Fan 1", "888")
+		fan2 := makeMockUser("fan2", "Fan 2", "999")
+
+		repo.Create(ctx, followee)
+		repo.Create(ctx, fan1)
+		repo.Create(ctx, fan2)
+
+		assert.NoError(t, repo.Follow(ctx, fan1.ID, followee.ID))
+		assert.NoError(t, repo.Follow(ctx, fan2.ID, followee.ID))
+
+		followers, err := repo.GetFollowersOf(ctx, followee.ID)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{fan1.ID, fan2.ID}, followers)
+
+		none, err := repo.GetFollowersOf(ctx, fan1.ID)
+		assert.NoError(t, err)
+		assert.Len(t, none, 0)
+	})
+
+	t.Run("Count and FollowEdges report sizes", func(t *testing.T) {
+		repo := NewInMemoryUserRepository()
+		a := makeMockUser("a", "A", "1111111")
+		b := makeMockUser("b", "B", "2222222")
+		assert.NoError(t, repo.Create(ctx, a))
+		assert.NoError(t, repo.Create(ctx, b))
+
+		assert.NoError(t, repo.Follow(ctx, a.ID, b.ID))
+		assert.NoError(t, repo.Follow(ctx, a.ID, b.ID))
+		assert.NoError(t, repo.Follow(ctx, b.ID, a.ID))
+
+		assert.Equal(t, 2, repo.Count())
+		assert.Equal(t, 2, repo.FollowEdges())
+	})
+
+	t.Run("All and AllFollows list everything", func(t *testing.T) {
+		repo := NewInMemoryUserRepository()
+		a := makeMockUser("a", "A", "1111111")
+		b := makeMockUser("b", "B", "2222222")
+		c := makeMockUser("c", "C", "3333333")
+		for _, u := range []user.User{a, b, c} {
+			assert.NoError(t, repo.Create(ctx, u))
+		}
+		assert.NoError(t, repo.Follow(ctx, a.ID, b.ID))
+		assert.NoError(t, repo.Follow(ctx, a.ID, c.ID))
+		assert.NoError(t, repo.Follow(ctx, b.ID, a.ID))
+
+		users, err := repo.All(ctx)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []user.User{a, b, c}, users)
+
+		follows, err := repo.AllFollows(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, follows, 2)
+		assert.ElementsMatch(t, []string{b.ID, c.ID}, follows[a.ID])
+		assert.Equal(t, []string{a.ID}, follows[b.ID])
+	})
 }
@@ -0,0 +1,48 @@
+package observed
+
+import (
+	"context"
+	"time"
+
+	"ualaTwitter/internal/domain/apikey"
+)
+
+type APIKeyRepository struct {
+	next     apikey.Repository
+	observer Observer
+	name     string
+}
+
+func NewAPIKeyRepository(next apikey.Repository, observer Observer, name string) *APIKeyRepository {
+	return &APIKeyRepository{next: next, observer: observer, name: name}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key apikey.APIKey) (err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "Create")
+	defer func() { c.end(err) }()
+	return r.next.Create(ctx, key)
+}
+
+func (r *APIKeyRepository) GetByHash(ctx context.Context, hash string) (_ apikey.APIKey, err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "GetByHash")
+	defer func() { c.end(err) }()
+	return r.next.GetByHash(ctx, hash)
+}
+
+func (r *APIKeyRepository) ListByUser(ctx context.Context, userID string) (_ []apikey.APIKey, err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "ListByUser")
+	defer func() { c.end(err) }()
+	return r.next.ListByUser(ctx, userID)
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, userID, keyID string, at time.Time) (err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "Revoke")
+	defer func() { c.end(err) }()
+	return r.next.Revoke(ctx, userID, keyID, at)
+}
+
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, keyID string, at time.Time) (err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "TouchLastUsed")
+	defer func() { c.end(err) }()
+	return r.next.TouchLastUsed(ctx, keyID, at)
+}
@@ -0,0 +1,48 @@
+package observed
+
+import (
+	"context"
+	"time"
+
+	"ualaTwitter/internal/domain/credential"
+)
+
+type CredentialRepository struct {
+	next     credential.Repository
+	observer Observer
+	name     string
+}
+
+func NewCredentialRepository(next credential.Repository, observer Observer, name string) *CredentialRepository {
+	return &CredentialRepository{next: next, observer: observer, name: name}
+}
+
+func (r *CredentialRepository) Create(ctx context.Context, cred credential.Credential) (err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "Create")
+	defer func() { c.end(err) }()
+	return r.next.Create(ctx, cred)
+}
+
+func (r *CredentialRepository) GetByUserID(ctx context.Context, userID string) (_ credential.Credential, err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "GetByUserID")
+	defer func() { c.end(err) }()
+	return r.next.GetByUserID(ctx, userID)
+}
+
+func (r *CredentialRepository) RecordFailure(ctx context.Context, userID string, now time.Time, maxAttempts int, lockout time.Duration) (_ credential.Credential, err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "RecordFailure")
+	defer func() { c.end(err) }()
+	return r.next.RecordFailure(ctx, userID, now, maxAttempts, lockout)
+}
+
+func (r *CredentialRepository) ResetFailures(ctx context.Context, userID string, now time.Time) (err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "ResetFailures")
+	defer func() { c.end(err) }()
+	return r.next.ResetFailures(ctx, userID, now)
+}
+
+func (r *CredentialRepository) ChangePassword(ctx context.Context, userID, hash string, now time.Time) (err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "ChangePassword")
+	defer func() { c.end(err) }()
+	return r.next.ChangePassword(ctx, userID, hash, now)
+}
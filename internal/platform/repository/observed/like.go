@@ -0,0 +1,41 @@
+package observed
+
+import (
+	"context"
+
+	"ualaTwitter/internal/domain/like"
+)
+
+type LikeRepository struct {
+	next     like.Repository
+	observer Observer
+	name     string
+}
+
+func NewLikeRepository(next like.Repository, observer Observer, name string) *LikeRepository {
+	return &LikeRepository{next: next, observer: observer, name: name}
+}
+
+func (r *LikeRepository) HasLiked(ctx context.Context, userID, tweetID string) (_ bool, err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "HasLiked")
+	defer func() { c.end(err) }()
+	return r.next.HasLiked(ctx, userID, tweetID)
+}
+
+func (r *LikeRepository) HasLikedMany(ctx context.Context, userID string, tweetIDs []string) (_ map[string]bool, err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "HasLikedMany")
+	defer func() { c.end(err) }()
+	return r.next.HasLikedMany(ctx, userID, tweetIDs)
+}
+
+func (r *LikeRepository) Like(ctx context.Context, userID, tweetID string) (err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "Like")
+	defer func() { c.end(err) }()
+	return r.next.Like(ctx, userID, tweetID)
+}
+
+func (r *LikeRepository) FindTweetsLikedBy(ctx context.Context, userID string) (_ []string, err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "FindTweetsLikedBy")
+	defer func() { c.end(err) }()
+	return r.next.FindTweetsLikedBy(ctx, userID)
+}
@@ -0,0 +1,90 @@
+package observed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/repository/memory"
+)
+
+type recordedCall struct {
+	repository string
+	method     string
+	err        error
+}
+
+type recorder struct {
+	calls []recordedCall
+}
+
+func (r *recorder) ObserveRepositoryCall(repository, method string, duration time.Duration, err error) {
+	r.calls = append(r.calls, recordedCall{repository: repository, method: method, err: err})
+}
+
+func TestUserRepository_ReportsCalls(t *testing.T) {
+	rec := &recorder{}
+	repo := NewUserRepository(memory.NewInMemoryUserRepository(), rec, "users")
+	ctx := context.Background()
+
+	assert.NoError(t, repo.Create(ctx, user.User{ID: "usr_1", Name: "Ana"}))
+	u, err := repo.GetByID(ctx, "usr_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Ana", u.Name)
+	_, err = repo.GetByID(ctx, "usr_2")
+	assert.True(t, errors.Is(err, user.ErrUserNotFound))
+
+	assert.Equal(t, []recordedCall{
+		{repository: "users", method: "Create"},
+		{repository: "users", method: "GetByID"},
+		{repository: "users", method: "GetByID", err: user.ErrUserNotFound},
+	}, rec.calls)
+}
+
+func TestTweetRepository_TracesCalls(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	repo := NewTweetRepository(memory.NewInMemoryTweetRepository(), &recorder{}, "tweets")
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+
+	_, err := repo.GetByID(ctx, "missing")
+	parent.End()
+
+	assert.Error(t, err)
+	ended := spans.Ended()
+	if assert.Len(t, ended, 2) {
+		assert.Equal(t, "repository.tweets.GetByID", ended[0].Name())
+		assert.Equal(t, codes.Error, ended[0].Status().Code)
+		assert.Equal(t, parent.SpanContext().SpanID(), ended[0].Parent().SpanID())
+	}
+}
+
+func TestTweetRepository_ParentsNestedSpans(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	inner := NewTweetRepository(memory.NewInMemoryTweetRepository(), &recorder{}, "inner")
+	outer := NewTweetRepository(inner, &recorder{}, "outer")
+
+	_, _ = outer.GetByID(context.Background(), "missing")
+
+	ended := spans.Ended()
+	if assert.Len(t, ended, 2) {
+		assert.Equal(t, "repository.inner.GetByID", ended[0].Name())
+		assert.Equal(t, "repository.outer.GetByID", ended[1].Name())
+		assert.Equal(t, ended[1].SpanContext().SpanID(), ended[0].Parent().SpanID())
+	}
+}
@@ -0,0 +1,38 @@
+// Package observed decorates repositories so every call reports its latency
+// and outcome and is traced, whatever the backend.
+package observed
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"ualaTwitter/internal/platform/tracing"
+)
+
+// Observer receives one call per repository method invocation.
+type Observer interface {
+	ObserveRepositoryCall(repository, method string, duration time.Duration, err error)
+}
+
+// call times and traces one repository method.
+type call struct {
+	observer   Observer
+	repository string
+	method     string
+	start      time.Time
+	span       trace.Span
+}
+
+// begin starts the call's span and returns its context, which the wrapped
+// call must run under so the spans it starts are children of this one.
+func begin(ctx context.Context, observer Observer, repository, method string) (context.Context, call) {
+	ctx, span := tracing.Start(ctx, "repository."+repository+"."+method)
+	return ctx, call{observer: observer, repository: repository, method: method, start: time.Now(), span: span}
+}
+
+func (c call) end(err error) {
+	c.observer.ObserveRepositoryCall(c.repository, c.method, time.Since(c.start), err)
+	tracing.End(c.span, err)
+}
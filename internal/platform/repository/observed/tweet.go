@@ -0,0 +1,47 @@
+package observed
+
+import (
+	"context"
+
+	"ualaTwitter/internal/domain/tweet"
+)
+
+type TweetRepository struct {
+	next     tweet.Repository
+	observer Observer
+	name     string
+}
+
+func NewTweetRepository(next tweet.Repository, observer Observer, name string) *TweetRepository {
+	return &TweetRepository{next: next, observer: observer, name: name}
+}
+
+func (r *TweetRepository) Save(ctx context.Context, t tweet.Tweet) (err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "Save")
+	defer func() { c.end(err) }()
+	return r.next.Save(ctx, t)
+}
+
+func (r *TweetRepository) GetByID(ctx context.Context, id string) (_ tweet.Tweet, err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "GetByID")
+	defer func() { c.end(err) }()
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *TweetRepository) GetByIDs(ctx context.Context, ids []string) (_ []tweet.Tweet, err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "GetByIDs")
+	defer func() { c.end(err) }()
+	return r.next.GetByIDs(ctx, ids)
+}
+
+func (r *TweetRepository) FindTweetsAuthoredBy(ctx context.Context, userID string) (_ []tweet.Tweet, err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "FindTweetsAuthoredBy")
+	defer func() { c.end(err) }()
+	return r.next.FindTweetsAuthoredBy(ctx, userID)
+}
+
+func (r *TweetRepository) IncrementLikes(ctx context.Context, tweetID string) (err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "IncrementLikes")
+	defer func() { c.end(err) }()
+	return r.next.IncrementLikes(ctx, tweetID)
+}
@@ -0,0 +1,83 @@
+package observed
+
+import (
+	"context"
+
+	"ualaTwitter/internal/domain/user"
+)
+
+type UserRepository struct {
+	next     user.InMemoryRepository
+	observer Observer
+	name     string
+}
+
+// NewUserRepository observes next under the repository label name.
+func NewUserRepository(next user.InMemoryRepository, observer Observer, name string) *UserRepository {
+	return &UserRepository{next: next, observer: observer, name: name}
+}
+
+func (r *UserRepository) Create(ctx context.Context, u user.User) (err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "Create")
+	defer func() { c.end(err) }()
+	return r.next.Create(ctx, u)
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id string) (_ user.User, err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "GetByID")
+	defer func() { c.end(err) }()
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *UserRepository) GetByIDs(ctx context.Context, ids []string) (_ []user.User, err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "GetByIDs")
+	defer func() { c.end(err) }()
+	return r.next.GetByIDs(ctx, ids)
+}
+
+func (r *UserRepository) Follow(ctx context.Context, followerID, followeeID string) (err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "Follow")
+	defer func() { c.end(err) }()
+	return r.next.Follow(ctx, followerID, followeeID)
+}
+
+func (r *UserRepository) GetUsersFollowedBy(ctx context.Context, userID string) (_ []string, err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "GetUsersFollowedBy")
+	defer func() { c.end(err) }()
+	return r.next.GetUsersFollowedBy(ctx, userID)
+}
+
+func (r *UserRepository) GetFollowersOf(ctx context.Context, userID string) (_ []string, err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "GetFollowersOf")
+	defer func() { c.end(err) }()
+	return r.next.GetFollowersOf(ctx, userID)
+}
+
+// UserStore observes the Postgres user repository.
+type UserStore struct {
+	next     user.PostgresRepository
+	observer Observer
+	name     string
+}
+
+func NewUserStore(next user.PostgresRepository, observer Observer, name string) *UserStore {
+	return &UserStore{next: next, observer: observer, name: name}
+}
+
+func (r *UserStore) Create(ctx context.Context, u user.User) (err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "Create")
+	defer func() { c.end(err) }()
+	return r.next.Create(ctx, u)
+}
+
+func (r *UserStore) GetByID(ctx context.Context, id string) (_ user.User, err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "GetByID")
+	defer func() { c.end(err) }()
+	return r.next.GetByID(ctx, id)
+}
+
+func (r *UserStore) Delete(ctx context.Context, id string) (err error) {
+	ctx, c := begin(ctx, r.observer, r.name, "Delete")
+	defer func() { c.end(err) }()
+	return r.next.Delete(ctx, id)
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"ualaTwitter/internal/domain/apikey"
+)
+
+const apiKeyColumns = `id, user_id, name, prefix, key_hash, scopes, created_at, last_used_at, revoked_at`
+
+type APIKeyRepository struct {
+	conn DB
+}
+
+func NewPostgresAPIKeyRepository(conn DB) *APIKeyRepository {
+	return &APIKeyRepository{conn: conn}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, key apikey.APIKey) error {
+	_, err := r.conn.Exec(ctx, `INSERT INTO api_keys (`+apiKeyColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		key.ID, key.UserID, key.Name, key.Prefix, key.Hash, key.Scopes, key.CreatedAt,
+		nullableTime(key.LastUsedAt), nullableTime(key.RevokedAt))
+	return err
+}
+
+func (r *APIKeyRepository) GetByHash(ctx context.Context, hash string) (apikey.APIKey, error) {
+	key, err := scanAPIKey(r.conn.QueryRow(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE key_hash = $1`, hash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apikey.APIKey{}, apikey.ErrNotFound
+	}
+	return key, err
+}
+
+// ListByUser returns the user's keys, newest first.
+func (r *APIKeyRepository) ListByUser(ctx context.Context, userID string) ([]apikey.APIKey, error) {
+	rows, err := r.conn.Query(ctx, `SELECT `+apiKeyColumns+` FROM api_keys
+		WHERE user_id = $1 ORDER BY created_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (apikey.APIKey, error) {
+		return scanAPIKey(row)
+	})
+}
+
+// Revoke marks the key as revoked, keeping the first revocation time. Keys
+// owned by another user are reported as not found.
+func (r *APIKeyRepository) Revoke(ctx context.Context, userID, keyID string, at time.Time) error {
+	tag, err := r.conn.Exec(ctx, `UPDATE api_keys SET revoked_at = coalesce(revoked_at, $3)
+		WHERE id = $2 AND user_id = $1`, userID, keyID, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apikey.ErrNotFound
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, keyID string, at time.Time) error {
+	tag, err := r.conn.Exec(ctx, `UPDATE api_keys SET last_used_at = $2 WHERE id = $1`, keyID, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apikey.ErrNotFound
+	}
+	return nil
+}
+
+func scanAPIKey(row pgx.Row) (apikey.APIKey, error) {
+	var (
+		key                 apikey.APIKey
+		lastUsed, revokedAt *time.Time
+	)
+	err := row.Scan(&key.ID, &key.UserID, &key.Name, &key.Prefix, &key.Hash, &key.Scopes, &key.CreatedAt, &lastUsed, &revokedAt)
+	if err != nil {
+		return apikey.APIKey{}, err
+	}
+
+	key.CreatedAt = key.CreatedAt.UTC()
+	if lastUsed != nil {
+		key.LastUsedAt = lastUsed.UTC()
+	}
+	if revokedAt != nil {
+		key.RevokedAt = revokedAt.UTC()
+	}
+	return key, nil
+}
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"ualaTwitter/internal/domain/apikey"
+	"ualaTwitter/internal/domain/user"
+)
+
+func TestPostgresAPIKeyRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := setupTestDB()
+	users := NewPostgresUserRepository(conn)
+	repo := NewPostgresAPIKeyRepository(conn)
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Cleanup(func() {
+		_, _ = conn.Exec(ctx, "DELETE FROM users")
+		conn.Close()
+	})
+
+	require.NoError(t, users.Create(ctx, user.User{ID: "usr_key1", Name: "Owner", Document: "5555555"}))
+	require.NoError(t, users.Create(ctx, user.User{ID: "usr_key2", Name: "Other", Document: "6666666"}))
+
+	older := apikey.APIKey{ID: "k1", UserID: "usr_key1", Name: "bot", Prefix: "uak_aaaaaa", Hash: "h1",
+		Scopes: []string{apikey.ScopeTweetsWrite}, CreatedAt: now}
+	newer := apikey.APIKey{ID: "k2", UserID: "usr_key1", Name: "reader", Prefix: "uak_bbbbbb", Hash: "h2",
+		Scopes: []string{apikey.ScopeTimelineRead, apikey.ScopeLikesWrite}, CreatedAt: now.Add(time.Hour)}
+	for _, k := range []apikey.APIKey{older, newer} {
+		require.NoError(t, repo.Create(ctx, k))
+	}
+
+	t.Run("GetByHash finds the key", func(t *testing.T) {
+		got, err := repo.GetByHash(ctx, "h2")
+		require.NoError(t, err)
+		assert.Equal(t, newer, got)
+
+		_, err = repo.GetByHash(ctx, "nope")
+		assert.ErrorIs(t, err, apikey.ErrNotFound)
+	})
+
+	t.Run("ListByUser returns the newest first", func(t *testing.T) {
+		keys, err := repo.ListByUser(ctx, "usr_key1")
+		require.NoError(t, err)
+		assert.Equal(t, []apikey.APIKey{newer, older}, keys)
+
+		keys, err = repo.ListByUser(ctx, "usr_key2")
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+
+	t.Run("Revoke keeps the first revocation and hides other users' keys", func(t *testing.T) {
+		assert.ErrorIs(t, repo.Revoke(ctx, "usr_key2", "k1", now), apikey.ErrNotFound)
+
+		require.NoError(t, repo.Revoke(ctx, "usr_key1", "k1", now))
+		require.NoError(t, repo.Revoke(ctx, "usr_key1", "k1", now.Add(time.Hour)))
+
+		got, err := repo.GetByHash(ctx, "h1")
+		require.NoError(t, err)
+		assert.Equal(t, now, got.RevokedAt)
+	})
+
+	t.Run("TouchLastUsed records the time", func(t *testing.T) {
+		require.NoError(t, repo.TouchLastUsed(ctx, "k2", now.Add(2*time.Hour)))
+
+		got, err := repo.GetByHash(ctx, "h2")
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(2*time.Hour), got.LastUsedAt)
+		assert.ErrorIs(t, repo.TouchLastUsed(ctx, "ghost", now), apikey.ErrNotFound)
+	})
+}
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"ualaTwitter/internal/domain/credential"
+)
+
+const uniqueViolation = "23505"
+
+type CredentialRepository struct {
+	conn DB
+}
+
+func NewPostgresCredentialRepository(conn DB) *CredentialRepository {
+	return &CredentialRepository{conn: conn}
+}
+
+func (r *CredentialRepository) Create(ctx context.Context, c credential.Credential) error {
+	_, err := r.conn.Exec(ctx, `INSERT INTO credentials (user_id, password_hash, failed_attempts, locked_until, session_version, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		c.UserID, c.PasswordHash, c.FailedAttempts, nullableTime(c.LockedUntil), c.SessionVersion, c.UpdatedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return credential.ErrAlreadyExists
+	}
+	return err
+}
+
+const credentialColumns = `user_id, password_hash, failed_attempts, locked_until, session_version, updated_at`
+
+func (r *CredentialRepository) GetByUserID(ctx context.Context, userID string) (credential.Credential, error) {
+	return scanCredential(r.conn.QueryRow(ctx, `SELECT `+credentialColumns+`
+		FROM credentials WHERE user_id = $1`, userID))
+}
+
+// RecordFailure mirrors Credential.RegisterFailure in one statement, so
+// parallel failures each count and none of them rewrites the hash or the
+// session version.
+func (r *CredentialRepository) RecordFailure(ctx context.Context, userID string, now time.Time, maxAttempts int, lockout time.Duration) (credential.Credential, error) {
+	return scanCredential(r.conn.QueryRow(ctx, `UPDATE credentials SET
+			failed_attempts = CASE WHEN failed_attempts + 1 >= $2 THEN 0 ELSE failed_attempts + 1 END,
+			locked_until = CASE WHEN failed_attempts + 1 >= $2 THEN $3 ELSE locked_until END,
+			updated_at = $4
+		WHERE user_id = $1
+		RETURNING `+credentialColumns,
+		userID, maxAttempts, now.Add(lockout), now))
+}
+
+// ResetFailures clears failures after a successful login, leaving a lock
+// set by a concurrent failure in force.
+func (r *CredentialRepository) ResetFailures(ctx context.Context, userID string, now time.Time) error {
+	tag, err := r.conn.Exec(ctx, `UPDATE credentials SET failed_attempts = 0, locked_until = NULL, updated_at = $2
+		WHERE user_id = $1 AND (locked_until IS NULL OR locked_until <= $2)`,
+		userID, now)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return r.exists(ctx, userID)
+	}
+	return nil
+}
+
+func (r *CredentialRepository) ChangePassword(ctx context.Context, userID, hash string, now time.Time) error {
+	tag, err := r.conn.Exec(ctx, `UPDATE credentials
+		SET password_hash = $2, session_version = session_version + 1, failed_attempts = 0, locked_until = NULL, updated_at = $3
+		WHERE user_id = $1`,
+		userID, hash, now)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return credential.ErrNotFound
+	}
+	return nil
+}
+
+// exists tells a locked credential, which a reset leaves alone, from a
+// missing one.
+func (r *CredentialRepository) exists(ctx context.Context, userID string) error {
+	_, err := r.GetByUserID(ctx, userID)
+	return err
+}
+
+func scanCredential(row pgx.Row) (credential.Credential, error) {
+	var (
+		c           credential.Credential
+		lockedUntil *time.Time
+	)
+	err := row.Scan(&c.UserID, &c.PasswordHash, &c.FailedAttempts, &lockedUntil, &c.SessionVersion, &c.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return credential.Credential{}, credential.ErrNotFound
+	}
+	if err != nil {
+		return credential.Credential{}, err
+	}
+
+	if lockedUntil != nil {
+		c.LockedUntil = *lockedUntil
+	}
+	return c, nil
+}
+
+// nullableTime stores a zero time as NULL.
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
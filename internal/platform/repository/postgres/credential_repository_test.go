@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"ualaTwitter/internal/domain/credential"
+	"ualaTwitter/internal/domain/user"
+)
+
+func TestPostgresCredentialRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := setupTestDB()
+	users := NewPostgresUserRepository(conn)
+	repo := NewPostgresCredentialRepository(conn)
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Cleanup(func() {
+		_, _ = conn.Exec(ctx, "DELETE FROM users")
+		conn.Close()
+	})
+
+	require.NoError(t, users.Create(ctx, user.User{ID: "usr_cred1", Name: "Cred", Document: "4444444"}))
+	require.NoError(t, repo.Create(ctx, credential.Credential{UserID: "usr_cred1", PasswordHash: "old", UpdatedAt: now}))
+
+	t.Run("RecordFailure counts each failure and locks at the limit", func(t *testing.T) {
+		got, err := repo.RecordFailure(ctx, "usr_cred1", now, 2, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, 1, got.FailedAttempts)
+
+		got, err = repo.RecordFailure(ctx, "usr_cred1", now, 2, time.Minute)
+		require.NoError(t, err)
+		assert.Zero(t, got.FailedAttempts)
+		assert.True(t, got.IsLocked(now))
+		assert.Equal(t, "old", got.PasswordHash)
+	})
+
+	t.Run("ResetFailures leaves a lock in force", func(t *testing.T) {
+		require.NoError(t, repo.ResetFailures(ctx, "usr_cred1", now))
+		got, err := repo.GetByUserID(ctx, "usr_cred1")
+		require.NoError(t, err)
+		assert.True(t, got.IsLocked(now))
+
+		require.NoError(t, repo.ResetFailures(ctx, "usr_cred1", now.Add(time.Minute)))
+		got, err = repo.GetByUserID(ctx, "usr_cred1")
+		require.NoError(t, err)
+		assert.False(t, got.IsLocked(now))
+	})
+
+	t.Run("failures racing a password change keep the new password", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = repo.RecordFailure(ctx, "usr_cred1", now, 10, time.Minute)
+			}()
+		}
+		require.NoError(t, repo.ChangePassword(ctx, "usr_cred1", "new", now))
+		wg.Wait()
+
+		got, err := repo.GetByUserID(ctx, "usr_cred1")
+		require.NoError(t, err)
+		assert.Equal(t, "new", got.PasswordHash)
+		assert.Equal(t, int64(1), got.SessionVersion)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := repo.RecordFailure(ctx, "ghost", now, 2, time.Minute)
+		assert.ErrorIs(t, err, credential.ErrNotFound)
+		assert.ErrorIs(t, repo.ResetFailures(ctx, "ghost", now), credential.ErrNotFound)
+		assert.ErrorIs(t, repo.ChangePassword(ctx, "ghost", "new", now), credential.ErrNotFound)
+	})
+}
@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DB is what the repositories need from Postgres. Requests use it
+// concurrently (every authenticated one reads credentials), so pass a
+// *pgxpool.Pool: a single *pgx.Conn fails with "conn busy" under load.
+type DB interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// requiredTables are the tables created by init.sql, in creation order.
+var requiredTables = []string{"users", "credentials", "api_keys"}
+
+// CheckSchema reports an error naming the tables of init.sql missing from
+// the current schema, so an unmigrated database is caught before the first
+// request fails on it.
+func CheckSchema(ctx context.Context, db DB) error {
+	var missing []string
+	err := db.QueryRow(ctx, `SELECT coalesce(array_agg(t), '{}') FROM unnest($1::text[]) AS t
+		WHERE to_regclass(t) IS NULL`, requiredTables).Scan(&missing)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing tables: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// CountRows returns the number of rows of every table of init.sql.
+func CountRows(ctx context.Context, db DB) (map[string]int, error) {
+	counts := make(map[string]int, len(requiredTables))
+	for _, table := range requiredTables {
+		var n int
+		query := "SELECT count(*) FROM " + pgx.Identifier{table}.Sanitize()
+		if err := db.QueryRow(ctx, query).Scan(&n); err != nil {
+			return nil, fmt.Errorf("count %s: %w", table, err)
+		}
+		counts[table] = n
+	}
+	return counts, nil
+}
@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDB answers QueryRow with a fixed list of missing tables, or with the
+// row count of the table the query names.
+type fakeDB struct {
+	missing []string
+	counts  map[string]int
+	err     error
+}
+
+func (f *fakeDB) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+
+func (f *fakeDB) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeDB) QueryRow(_ context.Context, sql string, _ ...any) pgx.Row {
+	return fakeRow{db: f, sql: sql}
+}
+
+type fakeRow struct {
+	db  *fakeDB
+	sql string
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.db.err != nil {
+		return r.db.err
+	}
+	switch dest := dest[0].(type) {
+	case *[]string:
+		*dest = r.db.missing
+	case *int:
+		for table, n := range r.db.counts {
+			if strings.HasSuffix(r.sql, `"`+table+`"`) {
+				*dest = n
+			}
+		}
+	}
+	return nil
+}
+
+func TestCheckSchema(t *testing.T) {
+	ctx := context.Background()
+
+	assert.NoError(t, CheckSchema(ctx, &fakeDB{missing: []string{}}))
+	assert.EqualError(t, CheckSchema(ctx, &fakeDB{missing: []string{"users", "credentials"}}), "missing tables: users, credentials")
+
+	boom := errors.New("connection refused")
+	assert.ErrorIs(t, CheckSchema(ctx, &fakeDB{err: boom}), boom)
+}
+
+func TestCountRows(t *testing.T) {
+	ctx := context.Background()
+
+	counts, err := CountRows(ctx, &fakeDB{counts: map[string]int{"users": 3, "credentials": 2, "api_keys": 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"users": 3, "credentials": 2, "api_keys": 1}, counts)
+
+	boom := errors.New("connection refused")
+	_, err = CountRows(ctx, &fakeDB{err: boom})
+	assert.ErrorIs(t, err, boom)
+	assert.ErrorContains(t, err, "count users")
+}
@@ -2,26 +2,62 @@ package postgres
 
 import (
 	"context"
+	"errors"
+	"time"
+
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"ualaTwitter/internal/domain/user"
 )
 
 type UserRepository struct {
-	conn *pgx.Conn
+	conn DB
 }
 
-func NewPostgresUserRepository(conn *pgx.Conn) *UserRepository {
+func NewPostgresUserRepository(conn DB) *UserRepository {
 	return &UserRepository{conn: conn}
 }
 
+// Create stores u; a zero CreatedAt is left to the database clock.
 func (r *UserRepository) Create(ctx context.Context, u user.User) error {
-	_, err := r.conn.Exec(ctx, `INSERT INTO users (id, name, document) VALUES ($1, $2, $3)`,
-		u.ID, u.Name, u.Document)
+	var createdAt *time.Time
+	if !u.CreatedAt.IsZero() {
+		createdAt = &u.CreatedAt
+	}
+	_, err := r.conn.Exec(ctx, `INSERT INTO users (id, name, document, created_at) VALUES ($1, $2, $3, coalesce($4, now()))`,
+		u.ID, u.Name, u.Document, createdAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return user.ErrUserAlreadyExists
+	}
 	return err
 }
 
 func (r *UserRepository) GetByID(ctx context.Context, id string) (user.User, error) {
 	var u user.User
 	err := r.conn.QueryRow(ctx, `SELECT id, name FROM users WHERE id = $1`, id).Scan(&u.ID, &u.Name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return user.User{}, user.ErrUserNotFound
+	}
 	return u, err
 }
+
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.conn.Exec(ctx, `DELETE FROM users WHERE id = $1`, id)
+	return err
+}
+
+// All returns every user ordered by ID.
+func (r *UserRepository) All(ctx context.Context) ([]user.User, error) {
+	rows, err := r.conn.Query(ctx, `SELECT id, name, document, created_at FROM users ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (user.User, error) {
+		var u user.User
+		err := row.Scan(&u.ID, &u.Name, &u.Document, &u.CreatedAt)
+		u.CreatedAt = u.CreatedAt.UTC()
+		return u, err
+	})
+}
@@ -3,20 +3,30 @@ package postgres
 import (
 	"context"
 	"testing"
+	"time"
 	"ualaTwitter/cmd/api/config"
 
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/assert"
 	"ualaTwitter/internal/domain/user"
 )
 
-func setupTestDB() *pgx.Conn {
-	conn, err := pgx.Connect(context.Background(), config.Load().PostgresDSN)
+// setupTestDB connects a pool, as the API does, so tests may run queries
+// concurrently.
+func setupTestDB() *pgxpool.Pool {
+	cfg, err := config.Load(nil)
 	if err != nil {
+		panic("Invalid test config: " + err.Error())
+	}
+	pool, err := pgxpool.New(context.Background(), cfg.PostgresDSN)
+	if err != nil {
+		panic("Failed to connect to test DB: " + err.Error())
+	}
+	if err := pool.Ping(context.Background()); err != nil {
 		panic("Failed to connect to test DB: " + err.Error())
 	}
-	_, _ = conn.Exec(context.Background(), "DELETE FROM users")
-	return conn
+	_, _ = pool.Exec(context.Background(), "DELETE FROM users")
+	return pool
 }
 
 func TestPostgresUserRepository(t *testing.T) {
@@ -26,6 +36,7 @@ func TestPostgresUserRepository(t *testing.T) {
 
 	t.Cleanup(func() {
 		_, _ = conn.Exec(ctx, "DELETE FROM users")
+		conn.Close()
 	})
 
 	t.Run("Create and GetByID happy path", func(t *testing.T) {
@@ -40,13 +51,32 @@ func TestPostgresUserRepository(t *testing.T) {
 
 	t.Run("GetByID returns error for missing user", func(t *testing.T) {
 		_, err := repo.GetByID(ctx, "no_such_id")
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, user.ErrUserNotFound)
 	})
 
 	t.Run("Create returns error for duplicate ID", func(t *testing.T) {
 		u := user.User{ID: "usr_test2", Name: "Test Duplicate", Document: "222"}
 		assert.NoError(t, repo.Create(ctx, u))
 		err := repo.Create(ctx, u)
+		assert.ErrorIs(t, err, user.ErrUserAlreadyExists)
+	})
+
+	t.Run("Delete removes the user", func(t *testing.T) {
+		u := user.User{ID: "usr_test4", Name: "Removed", Document: "4444"}
+		assert.NoError(t, repo.Create(ctx, u))
+
+		assert.NoError(t, repo.Delete(ctx, u.ID))
+		_, err := repo.GetByID(ctx, u.ID)
 		assert.Error(t, err)
 	})
+
+	t.Run("All keeps the creation time", func(t *testing.T) {
+		createdAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+		u := user.User{ID: "usr_test3", Name: "Imported", Document: "3333333", CreatedAt: createdAt}
+		assert.NoError(t, repo.Create(ctx, u))
+
+		users, err := repo.All(ctx)
+		assert.NoError(t, err)
+		assert.Contains(t, users, u)
+	})
 }
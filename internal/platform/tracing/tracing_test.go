@@ -0,0 +1,146 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"ualaTwitter/internal/platform/errors/usecase"
+)
+
+const (
+	parentTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	traceparent   = "00-" + parentTraceID + "-00f067aa0ba902b7-01"
+)
+
+// recordSpans installs an in-memory tracer provider for the test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func attr(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	recorder := recordSpans(t)
+
+	var inner trace.SpanContext
+	r := mux.NewRouter()
+	r.Use(HTTPMiddleware)
+	r.HandleFunc("/v1/tweets/{id}/like", func(w http.ResponseWriter, r *http.Request) {
+		_, span := Start(r.Context(), "child")
+		inner = span.SpanContext()
+		span.End()
+		w.WriteHeader(http.StatusInternalServerError)
+	}).Methods(http.MethodPost)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/tweets/t1/like", nil)
+	req.Header.Set("traceparent", traceparent)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	server := spans[1]
+	assert.Equal(t, "POST /v1/tweets/{id}/like", server.Name())
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+	assert.Equal(t, parentTraceID, server.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+	assert.Equal(t, int64(500), attr(server, "http.response.status_code").AsInt64())
+	assert.Equal(t, codes.Error, server.Status().Code)
+	assert.Equal(t, server.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, parentTraceID, inner.TraceID().String())
+}
+
+func TestUseCase(t *testing.T) {
+	recorder := recordSpans(t)
+
+	execute := UseCase("post_tweet", func(ctx context.Context, content string) (string, error) {
+		if content == "" {
+			return "", usecase.InvalidParam("empty")
+		}
+		return "tw_1", nil
+	})
+	command := Command("like_tweet", func(ctx context.Context, _ string) error {
+		return errors.New("boom")
+	})
+
+	_, err := execute(context.Background(), "hi")
+	require.NoError(t, err)
+	_, err = execute(context.Background(), "")
+	require.Error(t, err)
+	require.Error(t, command(context.Background(), "t1"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "usecase.post_tweet", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, usecase.TypeInvalidParam, attr(spans[1], "usecase.error_type").AsString())
+	assert.Equal(t, "usecase.like_tweet", spans[2].Name())
+	assert.Equal(t, usecase.TypeUnknown, attr(spans[2], "usecase.error_type").AsString())
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	recorder := recordSpans(t)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", traceparent))
+	info := &grpc.UnaryServerInfo{FullMethod: "/twitter.v1.TweetService/PostTweet"}
+
+	_, err := UnaryServerInterceptor()(ctx, nil, info, func(ctx context.Context, _ any) (any, error) {
+		return nil, errors.New("boom")
+	})
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, info.FullMethod, spans[0].Name())
+	assert.Equal(t, parentTraceID, spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func TestNewExporter(t *testing.T) {
+	ctx := context.Background()
+
+	exporter, err := newExporter(ctx, Config{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, exporter)
+
+	var out bytes.Buffer
+	exporter, err = newExporter(ctx, Config{Exporter: ExporterStdout}, &out)
+	require.NoError(t, err)
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	_, span := provider.Tracer("test").Start(ctx, "exported")
+	span.End()
+	require.NoError(t, provider.Shutdown(ctx))
+	assert.Contains(t, out.String(), `"Name":"exported"`)
+
+	_, err = newExporter(ctx, Config{Exporter: "zipkin"}, nil)
+	assert.Error(t, err)
+}
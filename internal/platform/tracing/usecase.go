@@ -0,0 +1,46 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"ualaTwitter/internal/platform/errors/usecase"
+)
+
+// UseCase wraps a use case Execute method in a span named after it:
+// UseCase("post_tweet", service.Execute).
+func UseCase[I, O any](name string, execute func(context.Context, I) (O, error)) func(context.Context, I) (O, error) {
+	return func(ctx context.Context, input I) (O, error) {
+		ctx, span := Start(ctx, "usecase."+name)
+		out, err := execute(ctx, input)
+		endUseCase(span, err)
+		return out, err
+	}
+}
+
+// Command is UseCase for use cases that only return an error.
+func Command[I any](name string, execute func(context.Context, I) error) func(context.Context, I) error {
+	return func(ctx context.Context, input I) error {
+		ctx, span := Start(ctx, "usecase."+name)
+		err := execute(ctx, input)
+		endUseCase(span, err)
+		return err
+	}
+}
+
+// endUseCase tags failed spans with the UseCaseError type, the same label
+// the metrics use.
+func endUseCase(span trace.Span, err error) {
+	if err != nil {
+		errType := usecase.TypeUnknown
+		var ue *usecase.UseCaseError
+		if errors.As(err, &ue) && ue.Type != "" {
+			errType = ue.Type
+		}
+		span.SetAttributes(attribute.String("usecase.error_type", errType))
+	}
+	End(span, err)
+}
@@ -0,0 +1,69 @@
+package mocks
+
+import (
+	"context"
+	"time"
+	"ualaTwitter/internal/domain/apikey"
+)
+
+type FakeAPIKeyRepo struct {
+	Keys      map[string]apikey.APIKey
+	CreateErr error
+	ListErr   error
+	TouchErr  error
+	Touched   []string
+}
+
+func (f *FakeAPIKeyRepo) Create(_ context.Context, key apikey.APIKey) error {
+	if f.CreateErr != nil {
+		return f.CreateErr
+	}
+	if f.Keys == nil {
+		f.Keys = make(map[string]apikey.APIKey)
+	}
+	f.Keys[key.ID] = key
+	return nil
+}
+
+func (f *FakeAPIKeyRepo) GetByHash(_ context.Context, hash string) (apikey.APIKey, error) {
+	for _, k := range f.Keys {
+		if k.Hash == hash {
+			return k, nil
+		}
+	}
+	return apikey.APIKey{}, apikey.ErrNotFound
+}
+
+func (f *FakeAPIKeyRepo) ListByUser(_ context.Context, userID string) ([]apikey.APIKey, error) {
+	if f.ListErr != nil {
+		return nil, f.ListErr
+	}
+	var keys []apikey.APIKey
+	for _, k := range f.Keys {
+		if k.UserID == userID {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+func (f *FakeAPIKeyRepo) Revoke(_ context.Context, userID, keyID string, at time.Time) error {
+	k, ok := f.Keys[keyID]
+	if !ok || k.UserID != userID {
+		return apikey.ErrNotFound
+	}
+	k.RevokedAt = at
+	f.Keys[keyID] = k
+	return nil
+}
+
+func (f *FakeAPIKeyRepo) TouchLastUsed(_ context.Context, keyID string, at time.Time) error {
+	f.Touched = append(f.Touched, keyID)
+	if f.TouchErr != nil {
+		return f.TouchErr
+	}
+	k := f.Keys[keyID]
+	k.LastUsedAt = at
+	f.Keys[keyID] = k
+	return nil
+}
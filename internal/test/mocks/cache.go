@@ -0,0 +1,15 @@
+package mocks
+
+import (
+	"context"
+)
+
+type FakeInvalidator struct {
+	Invalidated []string
+	Err         error
+}
+
+func (f *FakeInvalidator) Invalidate(_ context.Context, userIDs ...string) error {
+	f.Invalidated = append(f.Invalidated, userIDs...)
+	return f.Err
+}
@@ -0,0 +1,93 @@
+package mocks
+
+import (
+	"context"
+	"strings"
+	"time"
+	"ualaTwitter/internal/domain/credential"
+)
+
+type FakeCredentialRepo struct {
+	Credentials map[string]credential.Credential
+	CreateErr   error
+	UpdateErr   error
+}
+
+func (f *FakeCredentialRepo) Create(_ context.Context, c credential.Credential) error {
+	if f.CreateErr != nil {
+		return f.CreateErr
+	}
+	if f.Credentials == nil {
+		f.Credentials = make(map[string]credential.Credential)
+	}
+	f.Credentials[c.UserID] = c
+	return nil
+}
+
+func (f *FakeCredentialRepo) GetByUserID(_ context.Context, userID string) (credential.Credential, error) {
+	c, ok := f.Credentials[userID]
+	if !ok {
+		return credential.Credential{}, credential.ErrNotFound
+	}
+	return c, nil
+}
+
+func (f *FakeCredentialRepo) RecordFailure(_ context.Context, userID string, now time.Time, maxAttempts int, lockout time.Duration) (credential.Credential, error) {
+	var updated credential.Credential
+	err := f.modify(userID, func(c *credential.Credential) {
+		c.RegisterFailure(now, maxAttempts, lockout)
+		updated = *c
+	})
+	return updated, err
+}
+
+func (f *FakeCredentialRepo) ResetFailures(_ context.Context, userID string, now time.Time) error {
+	return f.modify(userID, func(c *credential.Credential) {
+		if !c.IsLocked(now) {
+			c.RegisterSuccess(now)
+		}
+	})
+}
+
+func (f *FakeCredentialRepo) ChangePassword(_ context.Context, userID, hash string, now time.Time) error {
+	return f.modify(userID, func(c *credential.Credential) {
+		c.ChangePassword(hash, now)
+	})
+}
+
+// modify applies change to the stored credential; UpdateErr fails every
+// write.
+func (f *FakeCredentialRepo) modify(userID string, change func(c *credential.Credential)) error {
+	if f.UpdateErr != nil {
+		return f.UpdateErr
+	}
+	c, ok := f.Credentials[userID]
+	if !ok {
+		return credential.ErrNotFound
+	}
+	change(&c)
+	f.Credentials[userID] = c
+	return nil
+}
+
+// FakeHasher "hashes" by prefixing, so tests can build stored hashes by hand.
+// OnVerify, when set, runs before each check, e.g. to change the stored
+// credential as a concurrent request would.
+type FakeHasher struct {
+	HashErr  error
+	OnVerify func()
+}
+
+func (f *FakeHasher) Hash(password string) (string, error) {
+	if f.HashErr != nil {
+		return "", f.HashErr
+	}
+	return "hashed:" + password, nil
+}
+
+func (f *FakeHasher) Verify(password, encoded string) (bool, error) {
+	if f.OnVerify != nil {
+		f.OnVerify()
+	}
+	return strings.TrimPrefix(encoded, "hashed:") == password, nil
+}
@@ -10,14 +10,62 @@ type FakeLikeRepo struct {
 	LikeErr      error
 	LikedTweetID string
 	LikedUserID  string
+	LikedByUser  map[string][]string
+	FindLikedErr error
+	ManyErr      error
+	ManyCalls    int
+	Counts       map[string]int
+	CountErr     error
+	DeleteErr    error
+	Deleted      []string
 }
 
-func (f *FakeLikeRepo) HasLiked(_ context.Context, tweetID, userID string) (bool, error) {
+func (f *FakeLikeRepo) HasLiked(_ context.Context, userID, tweetID string) (bool, error) {
 	return f.AlreadyLiked, f.HasLikedErr
 }
 
-func (f *FakeLikeRepo) Like(_ context.Context, tweetID, userID string) error {
+func (f *FakeLikeRepo) HasLikedMany(_ context.Context, userID string, tweetIDs []string) (map[string]bool, error) {
+	f.ManyCalls++
+	if f.ManyErr != nil {
+		return nil, f.ManyErr
+	}
+	liked := make(map[string]bool, len(tweetIDs))
+	for _, tid := range tweetIDs {
+		liked[tid] = false
+	}
+	for _, tid := range f.LikedByUser[userID] {
+		if _, ok := liked[tid]; ok {
+			liked[tid] = true
+		}
+	}
+	return liked, nil
+}
+
+func (f *FakeLikeRepo) Like(_ context.Context, userID, tweetID string) error {
 	f.LikedTweetID = tweetID
 	f.LikedUserID = userID
 	return f.LikeErr
 }
+
+func (f *FakeLikeRepo) FindTweetsLikedBy(_ context.Context, userID string) ([]string, error) {
+	if f.FindLikedErr != nil {
+		return nil, f.FindLikedErr
+	}
+	return f.LikedByUser[userID], nil
+}
+
+func (f *FakeLikeRepo) CountByTweet(_ context.Context) (map[string]int, error) {
+	return f.Counts, f.CountErr
+}
+
+func (f *FakeLikeRepo) DeleteForTweets(_ context.Context, tweetIDs []string) (int, error) {
+	if f.DeleteErr != nil {
+		return 0, f.DeleteErr
+	}
+	f.Deleted = append(f.Deleted, tweetIDs...)
+	deleted := 0
+	for _, tid := range tweetIDs {
+		deleted += f.Counts[tid]
+	}
+	return deleted, nil
+}
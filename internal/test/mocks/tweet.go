@@ -12,6 +12,16 @@ type FakeTweetRepo struct {
 	IncrementLikesErr error
 	TweetsByUser      map[string][]tweet.Tweet
 	TweetFetchErr     map[string]error
+	TweetsByID        map[string]tweet.Tweet
+	AllErr            error
+	SetLikesErr       error
+	LikesSet          map[string]int
+	// ConcurrentLikes moves counters between All and SetLikesIf, as likes
+	// landing during a recount would.
+	ConcurrentLikes map[string]int
+	DeleteErr       error
+	GetByIDsErr     error
+	GetByIDsCalls   [][]string
 }
 
 func (f *FakeTweetRepo) Save(_ context.Context, t tweet.Tweet) error {
@@ -36,11 +46,69 @@ func (f *FakeTweetRepo) FindTweetsAuthoredBy(_ context.Context, userID string) (
 	return nil, nil
 }
 
-func (f *FakeTweetRepo) GetByID(_ context.Context, _ string) (tweet.Tweet, error) {
+func (f *FakeTweetRepo) GetByID(_ context.Context, id string) (tweet.Tweet, error) {
+	if f.TweetsByID != nil {
+		t, ok := f.TweetsByID[id]
+		if !ok {
+			return tweet.Tweet{}, tweet.ErrNotFound
+		}
+		return t, nil
+	}
 	return tweet.Tweet{}, nil
 }
 
+func (f *FakeTweetRepo) GetByIDs(_ context.Context, ids []string) ([]tweet.Tweet, error) {
+	f.GetByIDsCalls = append(f.GetByIDsCalls, ids)
+	if f.GetByIDsErr != nil {
+		return nil, f.GetByIDsErr
+	}
+	tweets := make([]tweet.Tweet, 0, len(ids))
+	for _, id := range ids {
+		if t, ok := f.TweetsByID[id]; ok {
+			tweets = append(tweets, t)
+		}
+	}
+	return tweets, nil
+}
+
 func (f *FakeTweetRepo) IncrementLikes(_ context.Context, tweetID string) error {
 	f.LastLikedTweetID = tweetID
 	return f.IncrementLikesErr
 }
+
+func (f *FakeTweetRepo) All(_ context.Context) ([]tweet.Tweet, error) {
+	if f.AllErr != nil {
+		return nil, f.AllErr
+	}
+	tweets := make([]tweet.Tweet, 0, len(f.TweetsByID))
+	for _, t := range f.TweetsByID {
+		tweets = append(tweets, t)
+	}
+	return tweets, nil
+}
+
+func (f *FakeTweetRepo) SetLikesIf(_ context.Context, tweetID string, expected, likes int) (bool, error) {
+	if f.SetLikesErr != nil {
+		return false, f.SetLikesErr
+	}
+	if f.TweetsByID[tweetID].Likes+f.ConcurrentLikes[tweetID] != expected {
+		return false, nil
+	}
+	if f.LikesSet == nil {
+		f.LikesSet = make(map[string]int)
+	}
+	f.LikesSet[tweetID] = likes
+	return true, nil
+}
+
+func (f *FakeTweetRepo) DeleteAuthoredBy(_ context.Context, userID string) ([]string, error) {
+	if f.DeleteErr != nil {
+		return nil, f.DeleteErr
+	}
+	ids := make([]string, 0, len(f.TweetsByUser[userID]))
+	for _, t := range f.TweetsByUser[userID] {
+		ids = append(ids, t.ID)
+	}
+	delete(f.TweetsByUser, userID)
+	return ids, nil
+}
@@ -6,13 +6,22 @@ import (
 )
 
 type FakeUserRepo struct {
-	Users     map[string]*user.User
-	Followees map[string][]string
-	FollowErr error
-	CreateErr error
+	Users         map[string]*user.User
+	Followees     map[string][]string
+	GetByIDErr    error
+	FollowErr     error
+	CreateErr     error
+	FollowersErr  error
+	GetByIDsErr   error
+	GetByIDsCalls [][]string
+	DeleteErr     error
+	Deleted       []string
 }
 
 func (f *FakeUserRepo) GetByID(_ context.Context, id string) (user.User, error) {
+	if f.GetByIDErr != nil {
+		return user.User{}, f.GetByIDErr
+	}
 	u, ok := f.Users[id]
 	if !ok {
 		return user.User{}, user.ErrUserNotFound
@@ -20,6 +29,20 @@ func (f *FakeUserRepo) GetByID(_ context.Context, id string) (user.User, error)
 	return *u, nil
 }
 
+func (f *FakeUserRepo) GetByIDs(_ context.Context, ids []string) ([]user.User, error) {
+	f.GetByIDsCalls = append(f.GetByIDsCalls, ids)
+	if f.GetByIDsErr != nil {
+		return nil, f.GetByIDsErr
+	}
+	users := make([]user.User, 0, len(ids))
+	for _, id := range ids {
+		if u, ok := f.Users[id]; ok {
+			users = append(users, *u)
+		}
+	}
+	return users, nil
+}
+
 func (f *FakeUserRepo) Create(_ context.Context, u user.User) error {
 	if f.CreateErr != nil {
 		return f.CreateErr
@@ -28,6 +51,15 @@ func (f *FakeUserRepo) Create(_ context.Context, u user.User) error {
 	return nil
 }
 
+func (f *FakeUserRepo) Delete(_ context.Context, id string) error {
+	if f.DeleteErr != nil {
+		return f.DeleteErr
+	}
+	f.Deleted = append(f.Deleted, id)
+	delete(f.Users, id)
+	return nil
+}
+
 func (f *FakeUserRepo) Follow(_ context.Context, followerID, followeeID string) error {
 	if f.FollowErr != nil {
 		return f.FollowErr
@@ -43,3 +75,19 @@ func (f *FakeUserRepo) GetUsersFollowedBy(_ context.Context, userID string) ([]s
 	}
 	return followees, nil
 }
+
+func (f *FakeUserRepo) GetFollowersOf(_ context.Context, userID string) ([]string, error) {
+	if f.FollowersErr != nil {
+		return nil, f.FollowersErr
+	}
+	var followers []string
+	for followerID, followees := range f.Followees {
+		for _, followeeID := range followees {
+			if followeeID == userID {
+				followers = append(followers, followerID)
+				break
+			}
+		}
+	}
+	return followers, nil
+}
@@ -0,0 +1,5 @@
+package authenticate_api_key
+
+type Input struct {
+	Key string
+}
@@ -0,0 +1,7 @@
+package authenticate_api_key
+
+type Output struct {
+	KeyID  string
+	UserID string
+	Scopes []string
+}
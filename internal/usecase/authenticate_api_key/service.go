@@ -0,0 +1,57 @@
+package authenticate_api_key
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"ualaTwitter/internal/domain/apikey"
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/platform/logger"
+)
+
+// lastUsedResolution limits last-used writes to one per key per interval, so
+// a busy bot does not turn every request into a write.
+const lastUsedResolution = time.Minute
+
+var ErrInvalidKey = errors.New("invalid api key")
+
+type AuthenticateAPIKeyService struct {
+	KeyRepo apikey.Repository
+	Now     func() time.Time
+}
+
+func NewAuthenticateAPIKeyService(keyRepo apikey.Repository) *AuthenticateAPIKeyService {
+	return &AuthenticateAPIKeyService{
+		KeyRepo: keyRepo,
+		Now:     time.Now,
+	}
+}
+
+func (s *AuthenticateAPIKeyService) Execute(ctx context.Context, input Input) (Output, error) {
+	if !apikey.LooksLikeKey(input.Key) {
+		return Output{}, usecase.Unauthorized("invalid api key", ErrInvalidKey)
+	}
+
+	key, err := s.KeyRepo.GetByHash(ctx, apikey.Hash(input.Key))
+	if err != nil {
+		if errors.Is(err, apikey.ErrNotFound) {
+			return Output{}, usecase.Unauthorized("invalid api key", ErrInvalidKey)
+		}
+		return Output{}, usecase.InternalServerError("failed to look up api key", err)
+	}
+
+	if key.IsRevoked() {
+		return Output{}, usecase.Unauthorized("api key has been revoked", apikey.ErrRevoked)
+	}
+
+	now := s.Now().UTC()
+	if now.Sub(key.LastUsedAt) >= lastUsedResolution {
+		if err := s.KeyRepo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			logger.FromContext(ctx).Warn("failed to record api key usage", zap.String("key_id", key.ID), zap.Error(err))
+		}
+	}
+
+	return Output{KeyID: key.ID, UserID: key.UserID, Scopes: key.Scopes}, nil
+}
@@ -0,0 +1,86 @@
+package authenticate_api_key
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"ualaTwitter/internal/domain/apikey"
+	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+func TestAuthenticateAPIKeyService_Execute(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	key, raw, err := apikey.New("usr_1", "bot", []string{apikey.ScopeTweetsWrite}, now)
+	require.NoError(t, err)
+	revoked, revokedRaw, err := apikey.New("usr_1", "old bot", []string{apikey.ScopeTweetsWrite}, now)
+	require.NoError(t, err)
+	revoked.RevokedAt = now
+
+	newService := func(repo *mocks.FakeAPIKeyRepo) *AuthenticateAPIKeyService {
+		s := NewAuthenticateAPIKeyService(repo)
+		s.Now = func() time.Time { return now }
+		return s
+	}
+
+	t.Run("valid key returns owner and scopes and records usage", func(t *testing.T) {
+		repo := &mocks.FakeAPIKeyRepo{Keys: map[string]apikey.APIKey{key.ID: key}}
+
+		out, err := newService(repo).Execute(ctx, Input{Key: raw})
+
+		require.NoError(t, err)
+		assert.Equal(t, Output{KeyID: key.ID, UserID: "usr_1", Scopes: []string{apikey.ScopeTweetsWrite}}, out)
+		assert.Equal(t, now, repo.Keys[key.ID].LastUsedAt)
+	})
+
+	t.Run("recent usage is not written again", func(t *testing.T) {
+		recent := key
+		recent.LastUsedAt = now.Add(-10 * time.Second)
+		repo := &mocks.FakeAPIKeyRepo{Keys: map[string]apikey.APIKey{key.ID: recent}}
+
+		_, err := newService(repo).Execute(ctx, Input{Key: raw})
+
+		require.NoError(t, err)
+		assert.Empty(t, repo.Touched)
+	})
+
+	t.Run("usage write failure does not reject the request", func(t *testing.T) {
+		repo := &mocks.FakeAPIKeyRepo{Keys: map[string]apikey.APIKey{key.ID: key}, TouchErr: errors.New("db down")}
+
+		_, err := newService(repo).Execute(ctx, Input{Key: raw})
+
+		assert.NoError(t, err)
+	})
+
+	tests := []struct {
+		name      string
+		raw       string
+		expectErr string
+	}{
+		{name: "not a key", raw: "eyJhbGciOi", expectErr: "unauthorized: invalid api key"},
+		{name: "unknown key", raw: "uak_doesnotexist", expectErr: "unauthorized: invalid api key"},
+		{name: "revoked key", raw: revokedRaw, expectErr: "unauthorized: api key has been revoked"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &mocks.FakeAPIKeyRepo{Keys: map[string]apikey.APIKey{key.ID: key, revoked.ID: revoked}}
+
+			_, err := newService(repo).Execute(ctx, Input{Key: tc.raw})
+
+			assert.ErrorContains(t, err, tc.expectErr)
+		})
+	}
+}
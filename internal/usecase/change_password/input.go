@@ -0,0 +1,7 @@
+package change_password
+
+type Input struct {
+	UserID          string
+	CurrentPassword string
+	NewPassword     string
+}
@@ -0,0 +1,71 @@
+package change_password
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ualaTwitter/internal/domain/credential"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/errors/usecase"
+)
+
+var ErrWrongPassword = errors.New("current password is incorrect")
+
+type ChangePasswordService struct {
+	Credentials credential.Repository
+	UserRepo    user.InMemoryRepository
+	Hasher      credential.Hasher
+	Now         func() time.Time
+}
+
+func NewChangePasswordService(credentials credential.Repository, userRepo user.InMemoryRepository, hasher credential.Hasher) *ChangePasswordService {
+	return &ChangePasswordService{
+		Credentials: credentials,
+		UserRepo:    userRepo,
+		Hasher:      hasher,
+		Now:         time.Now,
+	}
+}
+
+// Execute replaces the user's password and bumps the session version, which
+// revokes every token issued before the change.
+func (s *ChangePasswordService) Execute(ctx context.Context, input Input) error {
+	if input.UserID == "" || input.CurrentPassword == "" || input.NewPassword == "" {
+		return usecase.InvalidParam("user ID, current and new password must not be empty")
+	}
+
+	cred, err := s.Credentials.GetByUserID(ctx, input.UserID)
+	if err != nil {
+		if errors.Is(err, credential.ErrNotFound) {
+			return usecase.NotFound("credentials not found", err)
+		}
+		return usecase.InternalServerError("failed to load credentials", err)
+	}
+
+	ok, err := s.Hasher.Verify(input.CurrentPassword, cred.PasswordHash)
+	if err != nil {
+		return usecase.InternalServerError("failed to verify password", err)
+	}
+	if !ok {
+		return usecase.Unauthorized("current password is incorrect", ErrWrongPassword)
+	}
+
+	var document string
+	if u, err := s.UserRepo.GetByID(ctx, input.UserID); err == nil {
+		document = u.Document
+	}
+	if err := user.ValidatePassword(input.NewPassword, document); err != nil {
+		return usecase.InvalidParam(err.Error(), err)
+	}
+
+	hash, err := s.Hasher.Hash(input.NewPassword)
+	if err != nil {
+		return usecase.InternalServerError("failed to hash password", err)
+	}
+
+	if err := s.Credentials.ChangePassword(ctx, cred.UserID, hash, s.Now().UTC()); err != nil {
+		return usecase.InternalServerError("failed to update credentials", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package change_password
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ualaTwitter/internal/domain/credential"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangePasswordService_Execute(t *testing.T) {
+	ctx := context.Background()
+	stored := credential.Credential{UserID: "usr_12345678", PasswordHash: "hashed:correcthorse42", SessionVersion: 1}
+
+	tests := []struct {
+		name      string
+		input     Input
+		hashErr   error
+		updateErr error
+		expectErr string
+	}{
+		{
+			name:  "changes password and revokes sessions",
+			input: Input{UserID: stored.UserID, CurrentPassword: "correcthorse42", NewPassword: "batterystaple77"},
+		},
+		{
+			name:      "empty fields",
+			input:     Input{UserID: stored.UserID},
+			expectErr: "must not be empty",
+		},
+		{
+			name:      "unknown user",
+			input:     Input{UserID: "usr_ghost", CurrentPassword: "correcthorse42", NewPassword: "batterystaple77"},
+			expectErr: "credentials not found",
+		},
+		{
+			name:      "wrong current password",
+			input:     Input{UserID: stored.UserID, CurrentPassword: "guess", NewPassword: "batterystaple77"},
+			expectErr: "current password is incorrect",
+		},
+		{
+			name:      "new password containing the document",
+			input:     Input{UserID: stored.UserID, CurrentPassword: "correcthorse42", NewPassword: "pass12345678"},
+			expectErr: "password must be",
+		},
+		{
+			name:      "hash failure",
+			input:     Input{UserID: stored.UserID, CurrentPassword: "correcthorse42", NewPassword: "batterystaple77"},
+			hashErr:   errors.New("rng failure"),
+			expectErr: "failed to hash password",
+		},
+		{
+			name:      "update failure",
+			input:     Input{UserID: stored.UserID, CurrentPassword: "correcthorse42", NewPassword: "batterystaple77"},
+			updateErr: errors.New("db down"),
+			expectErr: "failed to update credentials",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			creds := &mocks.FakeCredentialRepo{
+				Credentials: map[string]credential.Credential{stored.UserID: stored},
+				UpdateErr:   tc.updateErr,
+			}
+			users := &mocks.FakeUserRepo{Users: map[string]*user.User{
+				stored.UserID: {ID: stored.UserID, Name: "Alice", Document: "12345678"},
+			}}
+
+			service := NewChangePasswordService(creds, users, &mocks.FakeHasher{HashErr: tc.hashErr})
+			err := service.Execute(ctx, tc.input)
+
+			if tc.expectErr != "" {
+				assert.ErrorContains(t, err, tc.expectErr)
+				assert.Equal(t, stored, creds.Credentials[stored.UserID])
+				return
+			}
+			assert.NoError(t, err)
+			updated := creds.Credentials[stored.UserID]
+			assert.Equal(t, "hashed:batterystaple77", updated.PasswordHash)
+			assert.Equal(t, stored.SessionVersion+1, updated.SessionVersion)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package create_api_key
+
+type Input struct {
+	UserID string
+	Name   string
+	Scopes []string
+}
@@ -0,0 +1,12 @@
+package create_api_key
+
+import "time"
+
+type Output struct {
+	ID        string
+	Key       string
+	Prefix    string
+	Name      string
+	Scopes    []string
+	CreatedAt time.Time
+}
@@ -0,0 +1,69 @@
+package create_api_key
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ualaTwitter/internal/domain/apikey"
+	"ualaTwitter/internal/platform/errors/usecase"
+)
+
+const MaxActiveKeysPerUser = 10
+
+var ErrTooManyKeys = errors.New("too many active api keys")
+
+type CreateAPIKeyService struct {
+	KeyRepo apikey.Repository
+	Now     func() time.Time
+}
+
+func NewCreateAPIKeyService(keyRepo apikey.Repository) *CreateAPIKeyService {
+	return &CreateAPIKeyService{
+		KeyRepo: keyRepo,
+		Now:     time.Now,
+	}
+}
+
+// Execute creates a key and returns its raw value, which is not retrievable
+// afterwards.
+func (s *CreateAPIKeyService) Execute(ctx context.Context, input Input) (Output, error) {
+	if input.UserID == "" {
+		return Output{}, usecase.InvalidParam("user ID must not be empty")
+	}
+
+	existing, err := s.KeyRepo.ListByUser(ctx, input.UserID)
+	if err != nil {
+		return Output{}, usecase.InternalServerError("failed to list api keys", err)
+	}
+	active := 0
+	for _, k := range existing {
+		if !k.IsRevoked() {
+			active++
+		}
+	}
+	if active >= MaxActiveKeysPerUser {
+		return Output{}, usecase.Conflict("too many active api keys, revoke one first", ErrTooManyKeys)
+	}
+
+	key, raw, err := apikey.New(input.UserID, input.Name, input.Scopes, s.Now().UTC())
+	if err != nil {
+		if errors.Is(err, apikey.ErrInvalidName) || errors.Is(err, apikey.ErrInvalidScope) || errors.Is(err, apikey.ErrNoScopes) {
+			return Output{}, usecase.InvalidParam(err.Error(), err)
+		}
+		return Output{}, usecase.InternalServerError("failed to generate api key", err)
+	}
+
+	if err := s.KeyRepo.Create(ctx, key); err != nil {
+		return Output{}, usecase.InternalServerError("failed to store api key", err)
+	}
+
+	return Output{
+		ID:        key.ID,
+		Key:       raw,
+		Prefix:    key.Prefix,
+		Name:      key.Name,
+		Scopes:    key.Scopes,
+		CreatedAt: key.CreatedAt,
+	}, nil
+}
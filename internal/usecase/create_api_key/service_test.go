@@ -0,0 +1,90 @@
+package create_api_key
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"ualaTwitter/internal/domain/apikey"
+	"ualaTwitter/internal/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAPIKeyService_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		input     Input
+		existing  int
+		revoked   int
+		createErr error
+		listErr   error
+		expectErr string
+	}{
+		{
+			name:  "creates a key",
+			input: Input{UserID: "usr_1", Name: "bot", Scopes: []string{apikey.ScopeTweetsWrite}},
+		},
+		{
+			name:    "revoked keys do not count towards the limit",
+			input:   Input{UserID: "usr_1", Name: "bot", Scopes: []string{apikey.ScopeTweetsWrite}},
+			revoked: MaxActiveKeysPerUser,
+		},
+		{
+			name:      "missing user",
+			input:     Input{Name: "bot", Scopes: []string{apikey.ScopeTweetsWrite}},
+			expectErr: "invalid_param",
+		},
+		{
+			name:      "unknown scope",
+			input:     Input{UserID: "usr_1", Name: "bot", Scopes: []string{"admin"}},
+			expectErr: "invalid_param: unknown api key scope",
+		},
+		{
+			name:      "too many active keys",
+			input:     Input{UserID: "usr_1", Name: "bot", Scopes: []string{apikey.ScopeTweetsWrite}},
+			existing:  MaxActiveKeysPerUser,
+			expectErr: "conflict",
+		},
+		{
+			name:      "list failure",
+			input:     Input{UserID: "usr_1", Name: "bot", Scopes: []string{apikey.ScopeTweetsWrite}},
+			listErr:   errors.New("db down"),
+			expectErr: "failed to list api keys",
+		},
+		{
+			name:      "store failure",
+			input:     Input{UserID: "usr_1", Name: "bot", Scopes: []string{apikey.ScopeTweetsWrite}},
+			createErr: errors.New("db down"),
+			expectErr: "failed to store api key",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &mocks.FakeAPIKeyRepo{Keys: map[string]apikey.APIKey{}, CreateErr: tc.createErr, ListErr: tc.listErr}
+			for i := 0; i < tc.existing; i++ {
+				repo.Keys[fmt.Sprint("active", i)] = apikey.APIKey{UserID: "usr_1"}
+			}
+			for i := 0; i < tc.revoked; i++ {
+				repo.Keys[fmt.Sprint("revoked", i)] = apikey.APIKey{UserID: "usr_1", RevokedAt: time.Now()}
+			}
+
+			out, err := NewCreateAPIKeyService(repo).Execute(ctx, tc.input)
+
+			if tc.expectErr != "" {
+				assert.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			stored := repo.Keys[out.ID]
+			assert.Equal(t, apikey.Hash(out.Key), stored.Hash)
+			assert.Equal(t, tc.input.Scopes, out.Scopes)
+		})
+	}
+}
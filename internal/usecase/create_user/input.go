@@ -3,4 +3,5 @@ package create_user
 type Input struct {
 	Name     string
 	Document string
+	Password string
 }
@@ -2,21 +2,29 @@ package create_user
 
 import (
 	"context"
+	"errors"
 	"go.uber.org/zap"
+	"time"
+	"ualaTwitter/internal/domain/credential"
 	"ualaTwitter/internal/domain/user"
 	"ualaTwitter/internal/platform/errors/usecase"
 	"ualaTwitter/internal/platform/logger"
 )
 
 type CreateUserService struct {
-	pgRepo     user.PostgresRepository
-	memoryRepo user.InMemoryRepository
+	pgRepo      user.PostgresRepository
+	memoryRepo  user.InMemoryRepository
+	credentials credential.Repository
+	hasher      credential.Hasher
 }
 
-func NewCreateUserService(pgRepo user.PostgresRepository, memoryRepo user.InMemoryRepository) *CreateUserService {
+func NewCreateUserService(pgRepo user.PostgresRepository, memoryRepo user.InMemoryRepository, credentials credential.Repository, hasher credential.Hasher) *CreateUserService {
 	return &CreateUserService{
-		pgRepo:     pgRepo,
-		memoryRepo: memoryRepo}
+		pgRepo:      pgRepo,
+		memoryRepo:  memoryRepo,
+		credentials: credentials,
+		hasher:      hasher,
+	}
 }
 
 func (s *CreateUserService) Execute(ctx context.Context, input Input) (Output, error) {
@@ -25,27 +33,57 @@ func (s *CreateUserService) Execute(ctx context.Context, input Input) (Output, e
 		return Output{}, usecase.InvalidParam("invalid user name", err)
 	}
 
+	if err := user.ValidatePassword(input.Password, input.Document); err != nil {
+		return Output{}, usecase.InvalidParam(err.Error(), err)
+	}
+
 	if _, err := s.memoryRepo.GetByID(ctx, newUser.ID); err == nil {
-		logger.Log.Info("user already exists in memory", zap.String("user_id", newUser.ID))
+		logger.FromContext(ctx).Info("user already exists in memory", zap.String("user_id", newUser.ID))
 		return Output{}, usecase.Conflict("user already exists", user.ErrUserAlreadyExists)
 	}
 
 	existingUser, err := s.pgRepo.GetByID(ctx, newUser.ID)
-	if err == nil {
-		logger.Log.Info("user already exists in postgres", zap.String("user_id", existingUser.ID))
+	switch {
+	case err == nil:
+		logger.FromContext(ctx).Info("user already exists in postgres", zap.String("user_id", existingUser.ID))
 
 		if err := s.saveUserInMemory(ctx, existingUser); err != nil {
-			logger.Log.Warn("failed to cache existing user in memory", zap.Error(err))
+			logger.FromContext(ctx).Warn("failed to cache existing user in memory", zap.Error(err))
 		}
 		return Output{}, usecase.Conflict("user already exists", user.ErrUserAlreadyExists)
+	case !errors.Is(err, user.ErrUserNotFound):
+		return Output{}, usecase.InternalServerError("failed to look up user", err)
+	}
+
+	hash, err := s.hasher.Hash(input.Password)
+	if err != nil {
+		return Output{}, usecase.InternalServerError("failed to hash password", err)
 	}
 
+	newUser.CreatedAt = time.Now().UTC()
 	if err := s.pgRepo.Create(ctx, newUser); err != nil {
+		// A concurrent registration of the same document won the insert.
+		if errors.Is(err, user.ErrUserAlreadyExists) {
+			return Output{}, usecase.Conflict("user already exists", err)
+		}
 		return Output{}, usecase.InternalServerError("failed to persist user", err)
 	}
 
+	cred := credential.Credential{UserID: newUser.ID, PasswordHash: hash, UpdatedAt: time.Now().UTC()}
+	if err := s.credentials.Create(ctx, cred); err != nil {
+		// A user without credentials could never log in nor register
+		// again, so the row goes too, even if the request was cancelled.
+		if delErr := s.pgRepo.Delete(context.WithoutCancel(ctx), newUser.ID); delErr != nil {
+			logger.FromContext(ctx).Error("failed to remove user left without credentials",
+				zap.String("user_id", newUser.ID),
+				zap.Error(delErr),
+			)
+		}
+		return Output{}, usecase.InternalServerError("failed to persist credentials", err)
+	}
+
 	if err := s.saveUserInMemory(ctx, newUser); err != nil {
-		logger.Log.Warn("failed to store user in memory",
+		logger.FromContext(ctx).Warn("failed to store user in memory",
 			zap.String("user_id", newUser.ID),
 			zap.Error(err),
 		)
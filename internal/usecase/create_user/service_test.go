@@ -20,6 +20,7 @@ func TestCreateUserService_Execute(t *testing.T) {
 	ctx := context.Background()
 	validName := "Alice"
 	validDoc := "12345678"
+	validPassword := "correcthorse42"
 	userID := "usr_" + validDoc
 	validUser := user.User{ID: userID, Name: validName, Document: validDoc}
 
@@ -29,46 +30,86 @@ func TestCreateUserService_Execute(t *testing.T) {
 		memRepoUser       *user.User
 		memRepoErr        error
 		pgRepoUser        *user.User
+		pgRepoGetErr      error
 		pgRepoCreateErr   error
+		pgRepoDeleteErr   error
+		credCreateErr     error
+		hashErr           error
 		expectErrContains string
 		expectUserID      string
 	}{
 		{
 			name:         "successfully creates user",
-			input:        Input{Name: validName, Document: validDoc},
+			input:        Input{Name: validName, Document: validDoc, Password: validPassword},
 			memRepoUser:  nil,
 			pgRepoUser:   nil,
 			expectUserID: userID,
 		},
 		{
 			name:              "invalid user name",
-			input:             Input{Name: "", Document: validDoc},
+			input:             Input{Name: "", Document: validDoc, Password: validPassword},
 			expectErrContains: "invalid user name",
 		},
 		{
 			name:              "user already exists in memory",
-			input:             Input{Name: validName, Document: validDoc},
+			input:             Input{Name: validName, Document: validDoc, Password: validPassword},
 			memRepoUser:       &validUser,
 			expectErrContains: "already exists",
 		},
 		{
 			name:              "user exists in Postgres, not in memory (rehydrates)",
-			input:             Input{Name: validName, Document: validDoc},
+			input:             Input{Name: validName, Document: validDoc, Password: validPassword},
 			memRepoUser:       nil,
 			pgRepoUser:        &validUser,
 			expectErrContains: "already exists",
 		},
 		{
 			name:              "Postgres create error",
-			input:             Input{Name: validName, Document: validDoc},
+			input:             Input{Name: validName, Document: validDoc, Password: validPassword},
 			memRepoUser:       nil,
 			pgRepoUser:        nil,
 			pgRepoCreateErr:   errors.New("db write failed"),
 			expectErrContains: "failed to persist user",
 		},
+		{
+			name:              "concurrent registration wins the Postgres insert",
+			input:             Input{Name: validName, Document: validDoc, Password: validPassword},
+			pgRepoCreateErr:   user.ErrUserAlreadyExists,
+			expectErrContains: "conflict: user already exists",
+		},
+		{
+			name:              "Postgres lookup fails",
+			input:             Input{Name: validName, Document: validDoc, Password: validPassword},
+			pgRepoGetErr:      errors.New("db down"),
+			expectErrContains: "internal_server_error: failed to look up user",
+		},
+		{
+			name:              "weak password",
+			input:             Input{Name: validName, Document: validDoc, Password: "short1"},
+			expectErrContains: "password must be",
+		},
+		{
+			name:              "hashing fails",
+			input:             Input{Name: validName, Document: validDoc, Password: validPassword},
+			hashErr:           errors.New("rng failure"),
+			expectErrContains: "failed to hash password",
+		},
+		{
+			name:              "credentials write fails",
+			input:             Input{Name: validName, Document: validDoc, Password: validPassword},
+			credCreateErr:     errors.New("db write failed"),
+			expectErrContains: "failed to persist credentials",
+		},
+		{
+			name:              "credentials write fails and the user cannot be removed",
+			input:             Input{Name: validName, Document: validDoc, Password: validPassword},
+			credCreateErr:     errors.New("db write failed"),
+			pgRepoDeleteErr:   errors.New("db down"),
+			expectErrContains: "failed to persist credentials",
+		},
 		{
 			name:         "memory write after Postgres create fails",
-			input:        Input{Name: validName, Document: validDoc},
+			input:        Input{Name: validName, Document: validDoc, Password: validPassword},
 			memRepoUser:  nil,
 			pgRepoUser:   nil,
 			memRepoErr:   errors.New("memory error"),
@@ -87,22 +128,30 @@ func TestCreateUserService_Execute(t *testing.T) {
 			}
 
 			fakePgRepo := &mocks.FakeUserRepo{
-				Users:     make(map[string]*user.User),
-				CreateErr: tc.pgRepoCreateErr,
+				Users:      make(map[string]*user.User),
+				GetByIDErr: tc.pgRepoGetErr,
+				CreateErr:  tc.pgRepoCreateErr,
+				DeleteErr:  tc.pgRepoDeleteErr,
 			}
 			if tc.pgRepoUser != nil {
 				fakePgRepo.Users[tc.pgRepoUser.ID] = tc.pgRepoUser
 			}
 
-			service := NewCreateUserService(fakePgRepo, fakeMemRepo)
+			credRepo := &mocks.FakeCredentialRepo{CreateErr: tc.credCreateErr}
+
+			service := NewCreateUserService(fakePgRepo, fakeMemRepo, credRepo, &mocks.FakeHasher{HashErr: tc.hashErr})
 			out, err := service.Execute(ctx, tc.input)
 
 			if tc.expectErrContains != "" {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tc.expectErrContains)
+				if tc.credCreateErr != nil && tc.pgRepoDeleteErr == nil {
+					assert.Equal(t, []string{userID}, fakePgRepo.Deleted, "the user row is removed")
+				}
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tc.expectUserID, out.ID)
+				assert.Equal(t, "hashed:"+validPassword, credRepo.Credentials[out.ID].PasswordHash)
 			}
 		})
 	}
@@ -0,0 +1,8 @@
+package export_dataset
+
+import "io"
+
+type Input struct {
+	// Writer receives the dataset as JSON Lines.
+	Writer io.Writer
+}
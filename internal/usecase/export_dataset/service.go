@@ -0,0 +1,28 @@
+package export_dataset
+
+import (
+	"context"
+
+	"ualaTwitter/internal/platform/dataset"
+	"ualaTwitter/internal/platform/errors/usecase"
+)
+
+type ExportDatasetService struct {
+	Source dataset.Source
+}
+
+func NewExportDatasetService(source dataset.Source) *ExportDatasetService {
+	return &ExportDatasetService{
+		Source: source,
+	}
+}
+
+// Execute writes every entity of the source and returns how many of each
+// type were written.
+func (s *ExportDatasetService) Execute(ctx context.Context, input Input) (dataset.Counts, error) {
+	counts, err := dataset.Export(ctx, s.Source, input.Writer)
+	if err != nil {
+		return nil, usecase.InternalServerError("failed to export dataset", err)
+	}
+	return counts, nil
+}
@@ -0,0 +1,46 @@
+package export_dataset
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/dataset"
+)
+
+type fakeSource struct {
+	users []user.User
+	err   error
+}
+
+func (f fakeSource) Users(context.Context) ([]user.User, error)        { return f.users, f.err }
+func (f fakeSource) Follows(context.Context) ([]dataset.Follow, error) { return nil, nil }
+func (f fakeSource) Tweets(context.Context) ([]tweet.Tweet, error)     { return nil, nil }
+func (f fakeSource) Likes(context.Context) ([]dataset.Like, error)     { return nil, nil }
+
+func TestExportDatasetService_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("writes the dataset", func(t *testing.T) {
+		var out bytes.Buffer
+		source := fakeSource{users: []user.User{{ID: "usr_1111111", Name: "Ana", Document: "1111111"}}}
+
+		counts, err := NewExportDatasetService(source).Execute(ctx, Input{Writer: &out})
+		require.NoError(t, err)
+		assert.Equal(t, dataset.Counts{dataset.TypeUser: 1}, counts)
+		assert.Equal(t, `{"type":"user","id":"usr_1111111","name":"Ana","document":"1111111"}`+"\n", out.String())
+	})
+
+	t.Run("source error writes nothing", func(t *testing.T) {
+		var out bytes.Buffer
+		_, err := NewExportDatasetService(fakeSource{err: errors.New("db down")}).Execute(ctx, Input{Writer: &out})
+		assert.ErrorContains(t, err, "failed to export dataset")
+		assert.Empty(t, out.String())
+	})
+}
@@ -3,17 +3,24 @@ package follow_user
 import (
 	"context"
 	"errors"
+
+	"go.uber.org/zap"
+
 	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/cache"
 	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/platform/logger"
 )
 
 type FollowUserService struct {
-	UserRepo user.InMemoryRepository
+	UserRepo  user.InMemoryRepository
+	Timelines cache.Invalidator
 }
 
-func NewFollowUserService(userRepo user.InMemoryRepository) *FollowUserService {
+func NewFollowUserService(userRepo user.InMemoryRepository, timelines cache.Invalidator) *FollowUserService {
 	return &FollowUserService{
-		UserRepo: userRepo,
+		UserRepo:  userRepo,
+		Timelines: timelines,
 	}
 }
 
@@ -47,5 +54,12 @@ func (s *FollowUserService) Execute(ctx context.Context, input Input) error {
 		}
 	}
 
+	if err := s.Timelines.Invalidate(ctx, input.FollowerID); err != nil {
+		logger.FromContext(ctx).Warn("failed to invalidate follower timeline",
+			zap.String("user_id", input.FollowerID),
+			zap.Error(err),
+		)
+	}
+
 	return nil
 }
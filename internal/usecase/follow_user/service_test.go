@@ -5,11 +5,17 @@ import (
 	"errors"
 	"testing"
 	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/logger"
 	"ualaTwitter/internal/test/mocks"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 )
 
+func init() {
+	logger.Log = zap.NewNop()
+}
+
 func TestFollowUserService_Execute(t *testing.T) {
 	ctx := context.Background()
 
@@ -78,15 +84,34 @@ func TestFollowUserService_Execute(t *testing.T) {
 				FollowErr: tc.followErr,
 			}
 
-			service := NewFollowUserService(repo)
+			invalidator := &mocks.FakeInvalidator{}
+			service := NewFollowUserService(repo, invalidator)
 			err := service.Execute(ctx, tc.input)
 
 			if tc.expectErr != "" {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tc.expectErr)
+				assert.Empty(t, invalidator.Invalidated)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, []string{tc.input.FollowerID}, invalidator.Invalidated)
 			}
 		})
 	}
 }
+
+func TestFollowUserService_InvalidationFailure(t *testing.T) {
+	repo := &mocks.FakeUserRepo{
+		Users: map[string]*user.User{
+			"follower": {ID: "follower"},
+			"followee": {ID: "followee"},
+		},
+		Followees: make(map[string][]string),
+	}
+	service := NewFollowUserService(repo, &mocks.FakeInvalidator{Err: errors.New("cache down")})
+
+	err := service.Execute(context.Background(), Input{FollowerID: "follower", FolloweeID: "followee"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"followee"}, repo.Followees["follower"])
+}
@@ -0,0 +1,5 @@
+package get_follow_graph
+
+type Input struct {
+	UserID string
+}
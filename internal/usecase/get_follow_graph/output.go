@@ -0,0 +1,8 @@
+package get_follow_graph
+
+// Output lists who the user follows and who follows them, sorted by ID.
+type Output struct {
+	UserID    string
+	Following []string
+	Followers []string
+}
@@ -0,0 +1,50 @@
+package get_follow_graph
+
+import (
+	"context"
+	"sort"
+
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/errors/usecase"
+)
+
+type GetFollowGraphService struct {
+	UserRepo user.InMemoryRepository
+}
+
+func NewGetFollowGraphService(userRepo user.InMemoryRepository) *GetFollowGraphService {
+	return &GetFollowGraphService{
+		UserRepo: userRepo,
+	}
+}
+
+func (s *GetFollowGraphService) Execute(ctx context.Context, input Input) (Output, error) {
+	if input.UserID == "" {
+		return Output{}, usecase.InvalidParam("user ID must not be empty")
+	}
+
+	if _, err := s.UserRepo.GetByID(ctx, input.UserID); err != nil {
+		return Output{}, usecase.NotFound("user not found", user.ErrUserNotFound)
+	}
+
+	following, err := s.UserRepo.GetUsersFollowedBy(ctx, input.UserID)
+	if err != nil {
+		return Output{}, usecase.InternalServerError("failed to fetch followees", err)
+	}
+	followers, err := s.UserRepo.GetFollowersOf(ctx, input.UserID)
+	if err != nil {
+		return Output{}, usecase.InternalServerError("failed to fetch followers", err)
+	}
+
+	return Output{
+		UserID:    input.UserID,
+		Following: sorted(following),
+		Followers: sorted(followers),
+	}, nil
+}
+
+func sorted(ids []string) []string {
+	out := append(make([]string, 0, len(ids)), ids...)
+	sort.Strings(out)
+	return out
+}
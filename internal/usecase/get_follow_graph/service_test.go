@@ -0,0 +1,64 @@
+package get_follow_graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/test/mocks"
+)
+
+func TestGetFollowGraphService_Execute(t *testing.T) {
+	ctx := context.Background()
+	users := map[string]*user.User{
+		"usr_1": {ID: "usr_1"},
+		"usr_2": {ID: "usr_2"},
+		"usr_3": {ID: "usr_3"},
+	}
+
+	t.Run("lists followees and followers", func(t *testing.T) {
+		repo := &mocks.FakeUserRepo{Users: users, Followees: map[string][]string{
+			"usr_1": {"usr_3", "usr_2"},
+			"usr_2": {"usr_1"},
+			"usr_3": {"usr_1"},
+		}}
+
+		out, err := NewGetFollowGraphService(repo).Execute(ctx, Input{UserID: "usr_1"})
+		require.NoError(t, err)
+		assert.Equal(t, Output{
+			UserID:    "usr_1",
+			Following: []string{"usr_2", "usr_3"},
+			Followers: []string{"usr_2", "usr_3"},
+		}, out)
+	})
+
+	t.Run("user without edges has empty lists", func(t *testing.T) {
+		repo := &mocks.FakeUserRepo{Users: users, Followees: map[string][]string{}}
+
+		out, err := NewGetFollowGraphService(repo).Execute(ctx, Input{UserID: "usr_2"})
+		require.NoError(t, err)
+		assert.NotNil(t, out.Following)
+		assert.Empty(t, out.Following)
+		assert.Empty(t, out.Followers)
+	})
+
+	t.Run("empty user ID", func(t *testing.T) {
+		_, err := NewGetFollowGraphService(&mocks.FakeUserRepo{}).Execute(ctx, Input{})
+		assert.ErrorContains(t, err, "must not be empty")
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := NewGetFollowGraphService(&mocks.FakeUserRepo{Users: users}).Execute(ctx, Input{UserID: "ghost"})
+		assert.ErrorContains(t, err, "user not found")
+	})
+
+	t.Run("follower lookup fails", func(t *testing.T) {
+		repo := &mocks.FakeUserRepo{Users: users, FollowersErr: errors.New("boom")}
+		_, err := NewGetFollowGraphService(repo).Execute(ctx, Input{UserID: "usr_1"})
+		assert.ErrorContains(t, err, "failed to fetch followers")
+	})
+}
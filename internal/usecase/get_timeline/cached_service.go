@@ -0,0 +1,140 @@
+package get_timeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"ualaTwitter/internal/platform/cache"
+	"ualaTwitter/internal/platform/logger"
+)
+
+const timelineCacheKeyPrefix = "timeline:"
+
+type timelineExecutor interface {
+	Execute(ctx context.Context, input Input) ([]TweetTimeline, error)
+}
+
+type CacheStats struct {
+	Hits          uint64
+	Misses        uint64
+	Invalidations uint64
+}
+
+// CachedGetTimelineService serves timeline pages from a cache.Store keyed by
+// user and cursor, falling back to the wrapped service on a miss. Cache
+// failures are logged and never fail the request.
+type CachedGetTimelineService struct {
+	next  timelineExecutor
+	store cache.Store
+	ttl   time.Duration
+	// Pagination normalizes cursors; it must match the wrapped service's.
+	Pagination Pagination
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+func NewCachedGetTimelineService(next timelineExecutor, store cache.Store, ttl time.Duration) *CachedGetTimelineService {
+	return &CachedGetTimelineService{
+		next:       next,
+		store:      store,
+		ttl:        ttl,
+		Pagination: DefaultPagination,
+	}
+}
+
+func (s *CachedGetTimelineService) Execute(ctx context.Context, input Input) ([]TweetTimeline, error) {
+	key, field := timelineCacheKey(input.UserID), s.cursor(input)
+
+	if cached, ok := s.lookup(ctx, key, field); ok {
+		s.hits.Add(1)
+		return cached, nil
+	}
+	s.misses.Add(1)
+
+	tweets, err := s.next.Execute(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	s.save(ctx, key, field, tweets)
+	return tweets, nil
+}
+
+// Invalidate drops every cached page of the given users' timelines.
+func (s *CachedGetTimelineService) Invalidate(ctx context.Context, userIDs ...string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		keys[i] = timelineCacheKey(id)
+	}
+
+	if err := s.store.Delete(ctx, keys...); err != nil {
+		return err
+	}
+	s.invalidations.Add(uint64(len(userIDs)))
+	return nil
+}
+
+func (s *CachedGetTimelineService) Stats() CacheStats {
+	return CacheStats{
+		Hits:          s.hits.Load(),
+		Misses:        s.misses.Load(),
+		Invalidations: s.invalidations.Load(),
+	}
+}
+
+func (s *CachedGetTimelineService) lookup(ctx context.Context, key, field string) ([]TweetTimeline, bool) {
+	raw, ok, err := s.store.Get(ctx, key, field)
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to read timeline cache", zap.String("key", key), zap.Error(err))
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+
+	var tweets []TweetTimeline
+	if err := json.Unmarshal(raw, &tweets); err != nil {
+		logger.FromContext(ctx).Warn("failed to decode cached timeline", zap.String("key", key), zap.Error(err))
+		return nil, false
+	}
+	return tweets, true
+}
+
+func (s *CachedGetTimelineService) save(ctx context.Context, key, field string, tweets []TweetTimeline) {
+	raw, err := json.Marshal(tweets)
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to encode timeline for cache", zap.String("key", key), zap.Error(err))
+		return
+	}
+
+	if err := s.store.Set(ctx, key, field, raw, s.ttl); err != nil {
+		logger.FromContext(ctx).Warn("failed to write timeline cache", zap.String("key", key), zap.Error(err))
+	}
+}
+
+func timelineCacheKey(userID string) string {
+	return timelineCacheKeyPrefix + userID
+}
+
+// cursor normalizes the request so that equivalent pages share an entry
+// (e.g. a zero limit and the default one). An empty mode is kept apart:
+// feature flags decide per user what it means.
+func (s *CachedGetTimelineService) cursor(input Input) string {
+	offset, limit := s.Pagination.normalize(input.Offset, input.Limit)
+	cursor := fmt.Sprintf("%s:%d:%d", input.Mode, offset, limit)
+	if input.ExpandAuthor {
+		cursor += ":author"
+	}
+	return cursor
+}
@@ -0,0 +1,135 @@
+package get_timeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"ualaTwitter/internal/platform/cache"
+	"ualaTwitter/internal/platform/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+type countingTimelineService struct {
+	Output []TweetTimeline
+	Err    error
+	Calls  int
+}
+
+func (f *countingTimelineService) Execute(_ context.Context, _ Input) ([]TweetTimeline, error) {
+	f.Calls++
+	return f.Output, f.Err
+}
+
+type failingStore struct{}
+
+func (failingStore) Get(_ context.Context, _, _ string) ([]byte, bool, error) {
+	return nil, false, errors.New("store down")
+}
+
+func (failingStore) Set(_ context.Context, _, _ string, _ []byte, _ time.Duration) error {
+	return errors.New("store down")
+}
+
+func (failingStore) Delete(_ context.Context, _ ...string) error {
+	return errors.New("store down")
+}
+
+func TestCachedGetTimelineService(t *testing.T) {
+	ctx := context.Background()
+	page := []TweetTimeline{{ID: "t1", UserID: "usr_1", Content: "hi", Likes: 2, CreatedAt: time.Now().UTC().Truncate(time.Second)}}
+
+	t.Run("second read of the same page is a hit", func(t *testing.T) {
+		next := &countingTimelineService{Output: page}
+		service := NewCachedGetTimelineService(next, cache.NewLRUStore(10), time.Minute)
+
+		first, err := service.Execute(ctx, Input{UserID: "viewer", Limit: 10})
+		require.NoError(t, err)
+		second, err := service.Execute(ctx, Input{UserID: "viewer", Limit: 10})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, next.Calls)
+		assert.Equal(t, first, second)
+		assert.Equal(t, CacheStats{Hits: 1, Misses: 1}, service.Stats())
+	})
+
+	t.Run("different cursors are cached separately", func(t *testing.T) {
+		next := &countingTimelineService{Output: page}
+		service := NewCachedGetTimelineService(next, cache.NewLRUStore(10), time.Minute)
+
+		_, _ = service.Execute(ctx, Input{UserID: "viewer", Limit: 10})
+		_, _ = service.Execute(ctx, Input{UserID: "viewer", Limit: 10, Offset: 10})
+		_, _ = service.Execute(ctx, Input{UserID: "viewer", Limit: 10, Mode: ModeRanked})
+		_, _ = service.Execute(ctx, Input{UserID: "viewer", Limit: 10, ExpandAuthor: true})
+
+		assert.Equal(t, 4, next.Calls)
+	})
+
+	t.Run("equivalent cursors share an entry", func(t *testing.T) {
+		next := &countingTimelineService{Output: page}
+		service := NewCachedGetTimelineService(next, cache.NewLRUStore(10), time.Minute)
+
+		_, _ = service.Execute(ctx, Input{UserID: "viewer", Mode: ModeChronological})
+		_, _ = service.Execute(ctx, Input{UserID: "viewer", Limit: DefaultPagination.DefaultLimit, Mode: ModeChronological})
+
+		assert.Equal(t, 1, next.Calls)
+	})
+
+	t.Run("default mode is cached apart from chronological", func(t *testing.T) {
+		next := &countingTimelineService{Output: page}
+		service := NewCachedGetTimelineService(next, cache.NewLRUStore(10), time.Minute)
+
+		_, _ = service.Execute(ctx, Input{UserID: "viewer"})
+		_, _ = service.Execute(ctx, Input{UserID: "viewer", Mode: ModeChronological})
+
+		assert.Equal(t, 2, next.Calls, "a feature flag may rank the default mode")
+	})
+
+	t.Run("invalidation drops every page of the user", func(t *testing.T) {
+		next := &countingTimelineService{Output: page}
+		service := NewCachedGetTimelineService(next, cache.NewLRUStore(10), time.Minute)
+
+		_, _ = service.Execute(ctx, Input{UserID: "viewer"})
+		_, _ = service.Execute(ctx, Input{UserID: "viewer", Offset: 10})
+		_, _ = service.Execute(ctx, Input{UserID: "other"})
+		require.NoError(t, service.Invalidate(ctx, "viewer"))
+		_, _ = service.Execute(ctx, Input{UserID: "viewer"})
+		_, _ = service.Execute(ctx, Input{UserID: "viewer", Offset: 10})
+		_, _ = service.Execute(ctx, Input{UserID: "other"})
+
+		assert.Equal(t, 5, next.Calls)
+		assert.Equal(t, CacheStats{Hits: 1, Misses: 5, Invalidations: 1}, service.Stats())
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		next := &countingTimelineService{Err: errors.New("boom")}
+		service := NewCachedGetTimelineService(next, cache.NewLRUStore(10), time.Minute)
+
+		_, err1 := service.Execute(ctx, Input{UserID: "viewer"})
+		_, err2 := service.Execute(ctx, Input{UserID: "viewer"})
+
+		assert.Error(t, err1)
+		assert.Error(t, err2)
+		assert.Equal(t, 2, next.Calls)
+	})
+
+	t.Run("store failures fall through to the service", func(t *testing.T) {
+		next := &countingTimelineService{Output: page}
+		service := NewCachedGetTimelineService(next, failingStore{}, time.Minute)
+
+		result, err := service.Execute(ctx, Input{UserID: "viewer"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, page, result)
+		assert.Error(t, service.Invalidate(ctx, "viewer"))
+	})
+}
@@ -1,7 +1,9 @@
 package get_timeline
 
 type Input struct {
-	UserID string
-	Limit  int
-	Offset int
+	UserID       string
+	Limit        int
+	Offset       int
+	Mode         string
+	ExpandAuthor bool
 }
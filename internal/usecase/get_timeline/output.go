@@ -8,4 +8,11 @@ type TweetTimeline struct {
 	Content   string
 	Likes     int
 	CreatedAt time.Time
+	Author    *Author
+	LikedByMe bool
+}
+
+type Author struct {
+	ID   string
+	Name string
 }
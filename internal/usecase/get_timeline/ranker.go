@@ -0,0 +1,197 @@
+package get_timeline
+
+import (
+	"container/heap"
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"ualaTwitter/internal/domain/like"
+	"ualaTwitter/internal/domain/tweet"
+)
+
+const (
+	ModeChronological = "chronological"
+	ModeRanked        = "ranked"
+)
+
+// Ranker orders the candidate tweets of a viewer's timeline. It is the
+// extension point for A/B testing different ranking strategies.
+type Ranker interface {
+	Rank(ctx context.Context, viewerID string, tweets []tweet.Tweet) ([]tweet.Tweet, error)
+}
+
+// AffinitySource reports how many times the viewer liked each author.
+type AffinitySource interface {
+	AuthorAffinity(ctx context.Context, viewerID string) (map[string]int, error)
+}
+
+type RankingWeights struct {
+	Recency          float64
+	RecencyHalfLife  time.Duration
+	LikeVelocity     float64
+	AuthorAffinity   float64
+	DiversityPenalty float64
+}
+
+func DefaultRankingWeights() RankingWeights {
+	return RankingWeights{
+		Recency:          1.0,
+		RecencyHalfLife:  6 * time.Hour,
+		LikeVelocity:     0.5,
+		AuthorAffinity:   0.3,
+		DiversityPenalty: 0.7,
+	}
+}
+
+// WeightedRanker scores each tweet by recency decay, like velocity and
+// author affinity, then spreads authors out by multiplying the score of
+// every further tweet from an already placed author by DiversityPenalty.
+type WeightedRanker struct {
+	Affinity AffinitySource
+	Weights  RankingWeights
+	Now      func() time.Time
+}
+
+func NewWeightedRanker(affinity AffinitySource, weights RankingWeights) *WeightedRanker {
+	return &WeightedRanker{
+		Affinity: affinity,
+		Weights:  weights,
+		Now:      time.Now,
+	}
+}
+
+func (r *WeightedRanker) Rank(ctx context.Context, viewerID string, tweets []tweet.Tweet) ([]tweet.Tweet, error) {
+	affinity, err := r.Affinity.AuthorAffinity(ctx, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := r.Now()
+	byAuthor := make(map[string][]scoredTweet)
+	for _, t := range tweets {
+		byAuthor[t.UserID] = append(byAuthor[t.UserID], scoredTweet{
+			tweet: t,
+			score: r.score(t, affinity[t.UserID], now),
+		})
+	}
+
+	queues := make(authorQueues, 0, len(byAuthor))
+	for _, scored := range byAuthor {
+		sort.SliceStable(scored, func(i, j int) bool {
+			return scored[i].score > scored[j].score
+		})
+		queues = append(queues, &authorQueue{tweets: scored, penalty: 1})
+	}
+	heap.Init(&queues)
+
+	ranked := make([]tweet.Tweet, 0, len(tweets))
+	for queues.Len() > 0 {
+		q := queues[0]
+		ranked = append(ranked, q.tweets[0].tweet)
+		q.tweets = q.tweets[1:]
+		if len(q.tweets) == 0 {
+			heap.Pop(&queues)
+			continue
+		}
+		q.penalty *= r.Weights.DiversityPenalty
+		heap.Fix(&queues, 0)
+	}
+
+	return ranked, nil
+}
+
+func (r *WeightedRanker) score(t tweet.Tweet, affinity int, now time.Time) float64 {
+	age := now.Sub(t.CreatedAt)
+	if age < 0 {
+		age = 0
+	}
+
+	recency := 1.0
+	if r.Weights.RecencyHalfLife > 0 {
+		recency = math.Pow(0.5, age.Hours()/r.Weights.RecencyHalfLife.Hours())
+	}
+
+	ageHours := math.Max(age.Hours(), 1)
+	velocity := math.Log1p(float64(t.Likes) / ageHours)
+
+	return r.Weights.Recency*recency +
+		r.Weights.LikeVelocity*velocity +
+		r.Weights.AuthorAffinity*math.Log1p(float64(affinity))
+}
+
+type scoredTweet struct {
+	tweet tweet.Tweet
+	score float64
+}
+
+type authorQueue struct {
+	tweets  []scoredTweet
+	penalty float64
+}
+
+func (q *authorQueue) head() float64 {
+	return q.tweets[0].score * q.penalty
+}
+
+// authorQueues is a max-heap of each author's best remaining tweet, with
+// the diversity penalty already applied.
+type authorQueues []*authorQueue
+
+func (h authorQueues) Len() int { return len(h) }
+
+func (h authorQueues) Less(i, j int) bool {
+	if h[i].head() == h[j].head() {
+		return h[i].tweets[0].tweet.CreatedAt.After(h[j].tweets[0].tweet.CreatedAt)
+	}
+	return h[i].head() > h[j].head()
+}
+
+func (h authorQueues) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *authorQueues) Push(x any) { *h = append(*h, x.(*authorQueue)) }
+
+func (h *authorQueues) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// LikeAffinitySource derives author affinity from the tweets the viewer liked.
+type LikeAffinitySource struct {
+	LikeRepo  like.Repository
+	TweetRepo tweet.Repository
+}
+
+func NewLikeAffinitySource(likeRepo like.Repository, tweetRepo tweet.Repository) *LikeAffinitySource {
+	return &LikeAffinitySource{
+		LikeRepo:  likeRepo,
+		TweetRepo: tweetRepo,
+	}
+}
+
+func (s *LikeAffinitySource) AuthorAffinity(ctx context.Context, viewerID string) (map[string]int, error) {
+	likedTweetIDs, err := s.LikeRepo.FindTweetsLikedBy(ctx, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	affinity := make(map[string]int)
+	if len(likedTweetIDs) == 0 {
+		return affinity, nil
+	}
+
+	// Liked tweets that no longer exist are skipped.
+	liked, err := s.TweetRepo.GetByIDs(ctx, likedTweetIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range liked {
+		affinity[t.UserID]++
+	}
+
+	return affinity, nil
+}
@@ -0,0 +1,156 @@
+package get_timeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAffinitySource struct {
+	Affinity map[string]int
+	Err      error
+}
+
+func (f *fakeAffinitySource) AuthorAffinity(_ context.Context, _ string) (map[string]int, error) {
+	return f.Affinity, f.Err
+}
+
+func TestWeightedRanker_Rank(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	ids := func(tweets []tweet.Tweet) []string {
+		out := make([]string, len(tweets))
+		for i, t := range tweets {
+			out[i] = t.ID
+		}
+		return out
+	}
+
+	newRanker := func(affinity map[string]int, weights RankingWeights) *WeightedRanker {
+		r := NewWeightedRanker(&fakeAffinitySource{Affinity: affinity}, weights)
+		r.Now = func() time.Time { return now }
+		return r
+	}
+
+	tests := []struct {
+		name     string
+		affinity map[string]int
+		weights  RankingWeights
+		tweets   []tweet.Tweet
+		expected []string
+	}{
+		{
+			name:    "recency decay favours newer tweets",
+			weights: RankingWeights{Recency: 1, RecencyHalfLife: time.Hour, DiversityPenalty: 1},
+			tweets: []tweet.Tweet{
+				{ID: "old", UserID: "a", CreatedAt: now.Add(-5 * time.Hour)},
+				{ID: "new", UserID: "b", CreatedAt: now.Add(-10 * time.Minute)},
+			},
+			expected: []string{"new", "old"},
+		},
+		{
+			name:    "like velocity lifts a popular older tweet",
+			weights: RankingWeights{Recency: 1, RecencyHalfLife: time.Hour, LikeVelocity: 1, DiversityPenalty: 1},
+			tweets: []tweet.Tweet{
+				{ID: "quiet", UserID: "a", CreatedAt: now.Add(-30 * time.Minute)},
+				{ID: "viral", UserID: "b", CreatedAt: now.Add(-2 * time.Hour), Likes: 500},
+			},
+			expected: []string{"viral", "quiet"},
+		},
+		{
+			name:     "author affinity lifts authors the viewer likes",
+			affinity: map[string]int{"fav": 20},
+			weights:  RankingWeights{Recency: 1, RecencyHalfLife: time.Hour, AuthorAffinity: 1, DiversityPenalty: 1},
+			tweets: []tweet.Tweet{
+				{ID: "stranger", UserID: "other", CreatedAt: now.Add(-10 * time.Minute)},
+				{ID: "friend", UserID: "fav", CreatedAt: now.Add(-3 * time.Hour)},
+			},
+			expected: []string{"friend", "stranger"},
+		},
+		{
+			name:    "diversity penalty interleaves a prolific author",
+			weights: RankingWeights{Recency: 1, RecencyHalfLife: 24 * time.Hour, DiversityPenalty: 0.5},
+			tweets: []tweet.Tweet{
+				{ID: "a1", UserID: "a", CreatedAt: now.Add(-1 * time.Minute)},
+				{ID: "a2", UserID: "a", CreatedAt: now.Add(-2 * time.Minute)},
+				{ID: "a3", UserID: "a", CreatedAt: now.Add(-3 * time.Minute)},
+				{ID: "b1", UserID: "b", CreatedAt: now.Add(-30 * time.Minute)},
+			},
+			expected: []string{"a1", "b1", "a2", "a3"},
+		},
+		{
+			name:     "no candidates",
+			weights:  DefaultRankingWeights(),
+			tweets:   nil,
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ranked, err := newRanker(tc.affinity, tc.weights).Rank(ctx, "viewer", tc.tweets)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ids(ranked))
+		})
+	}
+
+	t.Run("affinity error is propagated", func(t *testing.T) {
+		r := NewWeightedRanker(&fakeAffinitySource{Err: errors.New("boom")}, DefaultRankingWeights())
+
+		_, err := r.Rank(ctx, "viewer", []tweet.Tweet{{ID: "t1"}})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestLikeAffinitySource_AuthorAffinity(t *testing.T) {
+	ctx := context.Background()
+
+	tweetRepo := &mocks.FakeTweetRepo{
+		TweetsByID: map[string]tweet.Tweet{
+			"t1": {ID: "t1", UserID: "usr_1"},
+			"t2": {ID: "t2", UserID: "usr_1"},
+			"t3": {ID: "t3", UserID: "usr_2"},
+		},
+	}
+
+	t.Run("counts likes per author and skips missing tweets", func(t *testing.T) {
+		likeRepo := &mocks.FakeLikeRepo{
+			LikedByUser: map[string][]string{"viewer": {"t1", "t2", "t3", "gone"}},
+		}
+
+		affinity, err := NewLikeAffinitySource(likeRepo, tweetRepo).AuthorAffinity(ctx, "viewer")
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"usr_1": 2, "usr_2": 1}, affinity)
+		assert.Equal(t, [][]string{{"t1", "t2", "t3", "gone"}}, tweetRepo.GetByIDsCalls)
+	})
+
+	t.Run("tweet repository error is propagated", func(t *testing.T) {
+		likeRepo := &mocks.FakeLikeRepo{
+			LikedByUser: map[string][]string{"viewer": {"t1"}},
+		}
+		failing := &mocks.FakeTweetRepo{GetByIDsErr: errors.New("db error")}
+
+		_, err := NewLikeAffinitySource(likeRepo, failing).AuthorAffinity(ctx, "viewer")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("like repository error is propagated", func(t *testing.T) {
+		likeRepo := &mocks.FakeLikeRepo{FindLikedErr: errors.New("db error")}
+
+		_, err := NewLikeAffinitySource(likeRepo, tweetRepo).AuthorAffinity(ctx, "viewer")
+
+		assert.Error(t, err)
+	})
+}
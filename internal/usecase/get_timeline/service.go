@@ -5,31 +5,68 @@ import (
 	"errors"
 	"sort"
 
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sync/errgroup"
+	"ualaTwitter/internal/domain/like"
 	"ualaTwitter/internal/domain/tweet"
 	"ualaTwitter/internal/domain/user"
 	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/platform/tracing"
 )
 
-const (
-	defaultLimit = 10
-	maxLimit     = 100
-	maxOffset    = 1000
-)
+// Pagination bounds the timeline pages a request can ask for.
+type Pagination struct {
+	// DefaultLimit is used when no positive limit is asked for.
+	DefaultLimit int
+	MaxLimit     int
+	MaxOffset    int
+}
+
+var DefaultPagination = Pagination{DefaultLimit: 10, MaxLimit: 100, MaxOffset: 1000}
+
+// RankedTimelineFlag makes ranked the default mode for the users it is on
+// for.
+const RankedTimelineFlag = "ranked_timeline"
+
+// FeatureFlags decides per user which features are on.
+type FeatureFlags interface {
+	Enabled(ctx context.Context, name, userID string) bool
+}
+
+// FanInObserver is told how many followees and tweets each timeline build
+// aggregated, before pagination.
+type FanInObserver interface {
+	ObserveTimelineFanIn(followees, tweets int)
+}
 
 type GetTimelineService struct {
 	TweetRepo tweet.Repository
 	UserRepo  user.InMemoryRepository
+	LikeRepo  like.Repository
+	Ranker    Ranker
+	// FanIn and Flags are optional.
+	FanIn FanInObserver
+	Flags FeatureFlags
+	// Pagination must match that of a CachedGetTimelineService in front.
+	Pagination Pagination
 }
 
-func NewGetTimelineService(tweetRepo tweet.Repository, userRepo user.InMemoryRepository) *GetTimelineService {
+func NewGetTimelineService(tweetRepo tweet.Repository, userRepo user.InMemoryRepository, likeRepo like.Repository, ranker Ranker) *GetTimelineService {
 	return &GetTimelineService{
-		TweetRepo: tweetRepo,
-		UserRepo:  userRepo,
+		TweetRepo:  tweetRepo,
+		UserRepo:   userRepo,
+		LikeRepo:   likeRepo,
+		Ranker:     ranker,
+		Pagination: DefaultPagination,
 	}
 }
 
 func (s *GetTimelineService) Execute(ctx context.Context, input Input) ([]TweetTimeline, error) {
+	mode, err := s.resolveMode(ctx, input.UserID, input.Mode)
+	if err != nil {
+		return nil, err
+	}
+
 	if _, err := s.UserRepo.GetByID(ctx, input.UserID); err != nil {
 		return nil, usecase.NotFound(user.ErrUserNotFound.Error())
 	}
@@ -43,10 +80,29 @@ func (s *GetTimelineService) Execute(ctx context.Context, input Input) ([]TweetT
 	if err != nil {
 		return nil, err
 	}
+	if s.FanIn != nil {
+		s.FanIn.ObserveTimelineFanIn(len(followees), len(tweets))
+	}
+
+	ordered, err := s.orderTweets(ctx, input.UserID, mode, tweets)
+	if err != nil {
+		return nil, err
+	}
+
+	paginated := s.paginateTweets(ordered, input.Offset, input.Limit)
+	timeline := s.mapToTimelineResponse(paginated)
+
+	if err := s.attachViewerFlags(ctx, input.UserID, timeline); err != nil {
+		return nil, err
+	}
 
-	sorted := s.sortTweets(tweets)
-	paginated := s.paginateTweets(sorted, input.Offset, input.Limit)
-	return s.mapToTimelineResponse(paginated), nil
+	if input.ExpandAuthor {
+		if err := s.attachAuthors(ctx, timeline); err != nil {
+			return nil, err
+		}
+	}
+
+	return timeline, nil
 }
 
 func (s *GetTimelineService) getFollowees(ctx context.Context, userID string) ([]string, error) {
@@ -71,7 +127,10 @@ func (s *GetTimelineService) aggregateTweets(ctx context.Context, followees []st
 
 	for _, fid := range followees {
 		fid := fid
-		g.Go(func() error {
+		g.Go(func() (err error) {
+			ctx, span := tracing.Start(ctx, "get_timeline.fetch_followee_tweets", attribute.String("followee.id", fid))
+			defer func() { tracing.End(span, err) }()
+
 			tweets, err := s.TweetRepo.FindTweetsAuthoredBy(ctx, fid)
 			if err != nil {
 				if errors.Is(err, user.ErrUserNotFound) {
@@ -79,6 +138,7 @@ func (s *GetTimelineService) aggregateTweets(ctx context.Context, followees []st
 				}
 				return err
 			}
+			span.SetAttributes(attribute.Int("tweets", len(tweets)))
 			timelineTweetsMutex <- tweets
 			return nil
 		})
@@ -99,6 +159,39 @@ func (s *GetTimelineService) aggregateTweets(ctx context.Context, followees []st
 	return timeline, nil
 }
 
+// resolveMode picks the ordering; without one asked for, it is ranked for
+// the users in the RankedTimelineFlag rollout and chronological otherwise.
+func (s *GetTimelineService) resolveMode(ctx context.Context, userID, mode string) (string, error) {
+	switch mode {
+	case "":
+		if s.Ranker != nil && s.Flags != nil && s.Flags.Enabled(ctx, RankedTimelineFlag, userID) {
+			return ModeRanked, nil
+		}
+		return ModeChronological, nil
+	case ModeChronological:
+		return ModeChronological, nil
+	case ModeRanked:
+		if s.Ranker == nil {
+			return "", usecase.InvalidParam("ranked timeline is not available")
+		}
+		return ModeRanked, nil
+	default:
+		return "", usecase.InvalidParam("unknown timeline mode: " + mode)
+	}
+}
+
+func (s *GetTimelineService) orderTweets(ctx context.Context, userID, mode string, tweets []tweet.Tweet) ([]tweet.Tweet, error) {
+	if mode != ModeRanked {
+		return s.sortTweets(tweets), nil
+	}
+
+	ranked, err := s.Ranker.Rank(ctx, userID, tweets)
+	if err != nil {
+		return nil, usecase.InternalServerError("failed to rank timeline", err)
+	}
+	return ranked, nil
+}
+
 func (s *GetTimelineService) sortTweets(tweets []tweet.Tweet) []tweet.Tweet {
 	sort.Slice(tweets, func(i, j int) bool {
 		return tweets[i].CreatedAt.After(tweets[j].CreatedAt)
@@ -107,7 +200,7 @@ func (s *GetTimelineService) sortTweets(tweets []tweet.Tweet) []tweet.Tweet {
 }
 
 func (s *GetTimelineService) paginateTweets(tweets []tweet.Tweet, offset, limit int) []tweet.Tweet {
-	offset, limit = normalizePaginationParams(offset, limit)
+	offset, limit = s.Pagination.normalize(offset, limit)
 
 	if offset >= len(tweets) {
 		return []tweet.Tweet{}
@@ -135,18 +228,74 @@ func (s *GetTimelineService) mapToTimelineResponse(tweets []tweet.Tweet) []Tweet
 	return result
 }
 
-func normalizePaginationParams(offset, limit int) (int, int) {
+// attachViewerFlags marks the tweets of the page the viewer already liked,
+// using a single batched lookup.
+func (s *GetTimelineService) attachViewerFlags(ctx context.Context, viewerID string, timeline []TweetTimeline) error {
+	if len(timeline) == 0 {
+		return nil
+	}
+
+	tweetIDs := make([]string, len(timeline))
+	for i, t := range timeline {
+		tweetIDs[i] = t.ID
+	}
+
+	liked, err := s.LikeRepo.HasLikedMany(ctx, viewerID, tweetIDs)
+	if err != nil {
+		return usecase.InternalServerError("failed to fetch like status", err)
+	}
+
+	for i := range timeline {
+		timeline[i].LikedByMe = liked[timeline[i].ID]
+	}
+	return nil
+}
+
+// attachAuthors loads every distinct author of the page with a single
+// repository call. Authors that no longer exist are left nil.
+func (s *GetTimelineService) attachAuthors(ctx context.Context, timeline []TweetTimeline) error {
+	if len(timeline) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(timeline))
+	authorIDs := make([]string, 0, len(timeline))
+	for _, t := range timeline {
+		if _, ok := seen[t.UserID]; ok {
+			continue
+		}
+		seen[t.UserID] = struct{}{}
+		authorIDs = append(authorIDs, t.UserID)
+	}
+
+	authors, err := s.UserRepo.GetByIDs(ctx, authorIDs)
+	if err != nil {
+		return usecase.InternalServerError("failed to fetch tweet authors", err)
+	}
+
+	byID := make(map[string]*Author, len(authors))
+	for _, a := range authors {
+		byID[a.ID] = &Author{ID: a.ID, Name: a.Name}
+	}
+
+	for i := range timeline {
+		timeline[i].Author = byID[timeline[i].UserID]
+	}
+	return nil
+}
+
+func (p Pagination) normalize(offset, limit int) (int, int) {
 	if offset < 0 {
 		offset = 0
 	}
-	if offset > maxOffset {
-		offset = maxOffset
+	if offset > p.MaxOffset {
+		offset = p.MaxOffset
 	}
 	if limit <= 0 {
-		limit = defaultLimit
+		limit = p.DefaultLimit
 	}
-	if limit > maxLimit {
-		limit = maxLimit
+	if limit > p.MaxLimit {
+		limit = p.MaxLimit
 	}
 	return offset, limit
 }
@@ -11,6 +11,9 @@ import (
 	"ualaTwitter/internal/test/mocks"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestGetTimelineService_Execute(t *testing.T) {
@@ -141,7 +144,7 @@ func TestGetTimelineService_Execute(t *testing.T) {
 			tweetRepo.TweetsByUser = tc.tweetsByUser
 			tweetRepo.TweetFetchErr = tc.tweetErrors
 
-			service := NewGetTimelineService(tweetRepo, userRepo)
+			service := NewGetTimelineService(tweetRepo, userRepo, &mocks.FakeLikeRepo{}, nil)
 
 			input := Input{
 				UserID: "test_user",
@@ -170,3 +173,349 @@ func TestGetTimelineService_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTimelineService_Modes(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	older := tweet.Tweet{ID: "t1", UserID: "usr_1", Content: "old", CreatedAt: now.Add(-2 * time.Hour)}
+	newer := tweet.Tweet{ID: "t2", UserID: "usr_2", Content: "new", CreatedAt: now.Add(-1 * time.Minute)}
+
+	newService := func(ranker Ranker) *GetTimelineService {
+		userRepo := &mocks.FakeUserRepo{
+			Users:     map[string]*user.User{"test_user": {ID: "test_user"}},
+			Followees: map[string][]string{"test_user": {"usr_1", "usr_2"}},
+		}
+		tweetRepo := &mocks.FakeTweetRepo{
+			TweetsByUser: map[string][]tweet.Tweet{
+				"usr_1": {older},
+				"usr_2": {newer},
+			},
+		}
+		return NewGetTimelineService(tweetRepo, userRepo, &mocks.FakeLikeRepo{}, ranker)
+	}
+
+	t.Run("ranked mode delegates ordering to the ranker", func(t *testing.T) {
+		service := newService(&fakeRanker{order: []string{"t1", "t2"}})
+
+		result, err := service.Execute(ctx, Input{UserID: "test_user", Mode: ModeRanked})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"t1", "t2"}, []string{result[0].ID, result[1].ID})
+	})
+
+	t.Run("chronological mode ignores the ranker", func(t *testing.T) {
+		service := newService(&fakeRanker{order: []string{"t1", "t2"}})
+
+		result, err := service.Execute(ctx, Input{UserID: "test_user", Mode: ModeChronological})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"t2", "t1"}, []string{result[0].ID, result[1].ID})
+	})
+
+	t.Run("ranked mode without a ranker is rejected", func(t *testing.T) {
+		service := newService(nil)
+
+		_, err := service.Execute(ctx, Input{UserID: "test_user", Mode: ModeRanked})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "not available")
+	})
+
+	t.Run("unknown mode is rejected", func(t *testing.T) {
+		service := newService(nil)
+
+		_, err := service.Execute(ctx, Input{UserID: "test_user", Mode: "popular"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown timeline mode")
+	})
+
+	t.Run("ranker error is an internal error", func(t *testing.T) {
+		service := newService(&fakeRanker{err: errors.New("boom")})
+
+		_, err := service.Execute(ctx, Input{UserID: "test_user", Mode: ModeRanked})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to rank timeline")
+	})
+
+	t.Run("default mode is ranked for users in the rollout", func(t *testing.T) {
+		service := newService(&fakeRanker{order: []string{"t1", "t2"}})
+		service.Flags = fakeFlags{RankedTimelineFlag: {"test_user": true}}
+
+		result, err := service.Execute(ctx, Input{UserID: "test_user"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"t1", "t2"}, []string{result[0].ID, result[1].ID})
+	})
+
+	t.Run("default mode is chronological outside the rollout", func(t *testing.T) {
+		service := newService(&fakeRanker{order: []string{"t1", "t2"}})
+		service.Flags = fakeFlags{}
+
+		result, err := service.Execute(ctx, Input{UserID: "test_user"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"t2", "t1"}, []string{result[0].ID, result[1].ID})
+	})
+
+	t.Run("rollout without a ranker stays chronological", func(t *testing.T) {
+		service := newService(nil)
+		service.Flags = fakeFlags{RankedTimelineFlag: {"test_user": true}}
+
+		result, err := service.Execute(ctx, Input{UserID: "test_user"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"t2", "t1"}, []string{result[0].ID, result[1].ID})
+	})
+}
+
+// fakeFlags maps flag names to the users they are on for.
+type fakeFlags map[string]map[string]bool
+
+func (f fakeFlags) Enabled(_ context.Context, name, userID string) bool {
+	return f[name][userID]
+}
+
+type fakeRanker struct {
+	order []string
+	err   error
+}
+
+func (f *fakeRanker) Rank(_ context.Context, _ string, tweets []tweet.Tweet) ([]tweet.Tweet, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	byID := make(map[string]tweet.Tweet, len(tweets))
+	for _, t := range tweets {
+		byID[t.ID] = t
+	}
+	ranked := make([]tweet.Tweet, 0, len(f.order))
+	for _, id := range f.order {
+		ranked = append(ranked, byID[id])
+	}
+	return ranked, nil
+}
+
+func TestGetTimelineService_ExpandAuthor(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	newRepos := func() (*mocks.FakeUserRepo, *mocks.FakeTweetRepo) {
+		userRepo := &mocks.FakeUserRepo{
+			Users: map[string]*user.User{
+				"test_user": {ID: "test_user", Name: "Viewer"},
+				"usr_1":     {ID: "usr_1", Name: "Alice"},
+				"usr_2":     {ID: "usr_2", Name: "Bob"},
+			},
+			Followees: map[string][]string{"test_user": {"usr_1", "usr_2", "usr_gone"}},
+		}
+		tweetRepo := &mocks.FakeTweetRepo{
+			TweetsByUser: map[string][]tweet.Tweet{
+				"usr_1":    {{ID: "t1", UserID: "usr_1", CreatedAt: now.Add(-1 * time.Minute)}, {ID: "t2", UserID: "usr_1", CreatedAt: now.Add(-2 * time.Minute)}},
+				"usr_2":    {{ID: "t3", UserID: "usr_2", CreatedAt: now.Add(-3 * time.Minute)}},
+				"usr_gone": {{ID: "t4", UserID: "usr_gone", CreatedAt: now.Add(-4 * time.Minute)}},
+			},
+		}
+		return userRepo, tweetRepo
+	}
+
+	t.Run("loads the page authors in one batch", func(t *testing.T) {
+		userRepo, tweetRepo := newRepos()
+		service := NewGetTimelineService(tweetRepo, userRepo, &mocks.FakeLikeRepo{}, nil)
+
+		result, err := service.Execute(ctx, Input{UserID: "test_user", ExpandAuthor: true})
+
+		assert.NoError(t, err)
+		assert.Len(t, userRepo.GetByIDsCalls, 1)
+		assert.ElementsMatch(t, []string{"usr_1", "usr_2", "usr_gone"}, userRepo.GetByIDsCalls[0])
+		assert.Equal(t, &Author{ID: "usr_1", Name: "Alice"}, result[0].Author)
+		assert.Equal(t, &Author{ID: "usr_1", Name: "Alice"}, result[1].Author)
+		assert.Equal(t, &Author{ID: "usr_2", Name: "Bob"}, result[2].Author)
+		assert.Nil(t, result[3].Author)
+	})
+
+	t.Run("only loads authors of the requested page", func(t *testing.T) {
+		userRepo, tweetRepo := newRepos()
+		service := NewGetTimelineService(tweetRepo, userRepo, &mocks.FakeLikeRepo{}, nil)
+
+		_, err := service.Execute(ctx, Input{UserID: "test_user", Limit: 2, ExpandAuthor: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"usr_1"}}, userRepo.GetByIDsCalls)
+	})
+
+	t.Run("does not load authors unless asked", func(t *testing.T) {
+		userRepo, tweetRepo := newRepos()
+		service := NewGetTimelineService(tweetRepo, userRepo, &mocks.FakeLikeRepo{}, nil)
+
+		result, err := service.Execute(ctx, Input{UserID: "test_user"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, userRepo.GetByIDsCalls)
+		assert.Nil(t, result[0].Author)
+	})
+
+	t.Run("author lookup failure is an internal error", func(t *testing.T) {
+		userRepo, tweetRepo := newRepos()
+		userRepo.GetByIDsErr = errors.New("db failure")
+		service := NewGetTimelineService(tweetRepo, userRepo, &mocks.FakeLikeRepo{}, nil)
+
+		_, err := service.Execute(ctx, Input{UserID: "test_user", ExpandAuthor: true})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to fetch tweet authors")
+	})
+}
+
+func TestGetTimelineService_ViewerFlags(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	userRepo := &mocks.FakeUserRepo{
+		Users:     map[string]*user.User{"test_user": {ID: "test_user"}},
+		Followees: map[string][]string{"test_user": {"usr_1"}},
+	}
+	tweetRepo := &mocks.FakeTweetRepo{
+		TweetsByUser: map[string][]tweet.Tweet{
+			"usr_1": {
+				{ID: "t1", UserID: "usr_1", CreatedAt: now.Add(-1 * time.Minute)},
+				{ID: "t2", UserID: "usr_1", CreatedAt: now.Add(-2 * time.Minute)},
+			},
+		},
+	}
+
+	t.Run("marks tweets the viewer liked with one batched lookup", func(t *testing.T) {
+		likeRepo := &mocks.FakeLikeRepo{LikedByUser: map[string][]string{"test_user": {"t2"}}}
+		service := NewGetTimelineService(tweetRepo, userRepo, likeRepo, nil)
+
+		result, err := service.Execute(ctx, Input{UserID: "test_user"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, likeRepo.ManyCalls)
+		assert.False(t, result[0].LikedByMe)
+		assert.True(t, result[1].LikedByMe)
+	})
+
+	t.Run("empty page skips the lookup", func(t *testing.T) {
+		likeRepo := &mocks.FakeLikeRepo{}
+		service := NewGetTimelineService(tweetRepo, userRepo, likeRepo, nil)
+
+		result, err := service.Execute(ctx, Input{UserID: "test_user", Offset: 10})
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+		assert.Zero(t, likeRepo.ManyCalls)
+	})
+
+	t.Run("like lookup failure is an internal error", func(t *testing.T) {
+		likeRepo := &mocks.FakeLikeRepo{ManyErr: errors.New("db failure")}
+		service := NewGetTimelineService(tweetRepo, userRepo, likeRepo, nil)
+
+		_, err := service.Execute(ctx, Input{UserID: "test_user"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to fetch like status")
+	})
+}
+
+type fanInRecorder struct {
+	followees, tweets []int
+}
+
+func (r *fanInRecorder) ObserveTimelineFanIn(followees, tweets int) {
+	r.followees = append(r.followees, followees)
+	r.tweets = append(r.tweets, tweets)
+}
+
+func TestGetTimelineService_FanIn(t *testing.T) {
+	now := time.Now()
+	userRepo := &mocks.FakeUserRepo{
+		Users:     map[string]*user.User{"test_user": {ID: "test_user"}},
+		Followees: map[string][]string{"test_user": {"usr_1", "usr_2"}},
+	}
+	tweetRepo := &mocks.FakeTweetRepo{
+		TweetsByUser: map[string][]tweet.Tweet{
+			"usr_1": {
+				{ID: "t1", UserID: "usr_1", CreatedAt: now.Add(-1 * time.Minute)},
+				{ID: "t2", UserID: "usr_1", CreatedAt: now.Add(-2 * time.Minute)},
+			},
+			"usr_2": {{ID: "t3", UserID: "usr_2", CreatedAt: now}},
+		},
+	}
+	recorder := &fanInRecorder{}
+	service := NewGetTimelineService(tweetRepo, userRepo, &mocks.FakeLikeRepo{}, nil)
+	service.FanIn = recorder
+
+	_, err := service.Execute(context.Background(), Input{UserID: "test_user", Limit: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, recorder.followees)
+	assert.Equal(t, []int{3}, recorder.tweets, "counted before pagination")
+}
+
+func TestGetTimelineService_Pagination(t *testing.T) {
+	now := time.Now()
+	userRepo := &mocks.FakeUserRepo{
+		Users:     map[string]*user.User{"test_user": {ID: "test_user"}},
+		Followees: map[string][]string{"test_user": {"usr_1"}},
+	}
+	tweetRepo := &mocks.FakeTweetRepo{
+		TweetsByUser: map[string][]tweet.Tweet{
+			"usr_1": {
+				{ID: "t1", UserID: "usr_1", CreatedAt: now},
+				{ID: "t2", UserID: "usr_1", CreatedAt: now.Add(-1 * time.Minute)},
+				{ID: "t3", UserID: "usr_1", CreatedAt: now.Add(-2 * time.Minute)},
+			},
+		},
+	}
+	service := NewGetTimelineService(tweetRepo, userRepo, &mocks.FakeLikeRepo{}, nil)
+	service.Pagination = Pagination{DefaultLimit: 1, MaxLimit: 2, MaxOffset: 1}
+
+	page, err := service.Execute(context.Background(), Input{UserID: "test_user"})
+	assert.NoError(t, err)
+	assert.Len(t, page, 1, "default limit")
+
+	page, err = service.Execute(context.Background(), Input{UserID: "test_user", Limit: 50})
+	assert.NoError(t, err)
+	assert.Len(t, page, 2, "capped at max limit")
+
+	page, err = service.Execute(context.Background(), Input{UserID: "test_user", Offset: 2, Limit: 50})
+	assert.NoError(t, err)
+	if assert.Len(t, page, 2) {
+		assert.Equal(t, "t2", page[0].ID, "offset capped at max offset")
+	}
+}
+
+func TestGetTimelineService_TracesFolloweeFetches(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	userRepo := &mocks.FakeUserRepo{
+		Users:     map[string]*user.User{"test_user": {ID: "test_user"}},
+		Followees: map[string][]string{"test_user": {"usr_1", "usr_2"}},
+	}
+	tweetRepo := &mocks.FakeTweetRepo{
+		TweetsByUser: map[string][]tweet.Tweet{
+			"usr_1": {{ID: "t1", UserID: "usr_1", CreatedAt: time.Now()}},
+		},
+	}
+	service := NewGetTimelineService(tweetRepo, userRepo, &mocks.FakeLikeRepo{}, nil)
+
+	_, err := service.Execute(context.Background(), Input{UserID: "test_user"})
+
+	assert.NoError(t, err)
+	var followees []string
+	for _, span := range spans.Ended() {
+		assert.Equal(t, "get_timeline.fetch_followee_tweets", span.Name())
+		for _, kv := range span.Attributes() {
+			if kv.Key == "followee.id" {
+				followees = append(followees, kv.Value.AsString())
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"usr_1", "usr_2"}, followees)
+}
@@ -0,0 +1,8 @@
+package import_dataset
+
+import "io"
+
+type Input struct {
+	// Data is the dataset as JSON Lines.
+	Data io.Reader
+}
@@ -0,0 +1,74 @@
+package import_dataset
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/cache"
+	"ualaTwitter/internal/platform/dataset"
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/platform/logger"
+)
+
+// Backend stores the imported entities and lists the users whose cached
+// timelines the import may have changed.
+type Backend interface {
+	dataset.Sink
+	Users(ctx context.Context) ([]user.User, error)
+}
+
+type ImportDatasetService struct {
+	Backend   Backend
+	Timelines cache.Invalidator
+	// MaxContentLength caps tweet length, in characters.
+	MaxContentLength int
+}
+
+func NewImportDatasetService(backend Backend, timelines cache.Invalidator) *ImportDatasetService {
+	return &ImportDatasetService{
+		Backend:          backend,
+		Timelines:        timelines,
+		MaxContentLength: tweet.MaxContentLength,
+	}
+}
+
+// Execute imports every valid line; rejected lines are reported, not
+// failed.
+func (s *ImportDatasetService) Execute(ctx context.Context, input Input) (dataset.Report, error) {
+	importer := dataset.NewImporter(s.Backend)
+	importer.MaxContentLength = s.MaxContentLength
+
+	report, err := importer.Import(ctx, input.Data)
+	if report.Imported.Total() > 0 {
+		s.invalidateTimelines(ctx)
+	}
+	switch {
+	case ctx.Err() != nil:
+		return report, usecase.InternalServerError("import interrupted", err)
+	case err != nil:
+		return report, usecase.InvalidParam("dataset could not be read to the end", err)
+	}
+	return report, nil
+}
+
+// invalidateTimelines drops every cached timeline, since any of them may
+// show imported tweets, likes or follows. The entities are already stored,
+// so failures are only logged.
+func (s *ImportDatasetService) invalidateTimelines(ctx context.Context) {
+	users, err := s.Backend.Users(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to list users for timeline invalidation", zap.Error(err))
+		return
+	}
+
+	ids := make([]string, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+	if err := s.Timelines.Invalidate(ctx, ids...); err != nil {
+		logger.FromContext(ctx).Warn("failed to invalidate timelines after import", zap.Error(err))
+	}
+}
@@ -0,0 +1,73 @@
+package import_dataset
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"ualaTwitter/internal/platform/dataset"
+	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/platform/repository/memory"
+	"ualaTwitter/internal/test/mocks"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+func newBackend() *dataset.MemoryBackend {
+	return dataset.NewMemoryBackend(memory.NewInMemoryUserRepository(), memory.NewInMemoryTweetRepository(), memory.NewInMemoryLikeRepository(), memory.NewInMemoryUserRepository())
+}
+
+func TestImportDatasetService_Execute(t *testing.T) {
+	ctx := context.Background()
+	users := `{"type":"user","id":"usr_1111111","name":"Ana","document":"1111111"}
+{"type":"user","id":"usr_2222222","name":"Bruno","document":"2222222"}
+`
+
+	t.Run("imports and invalidates every timeline", func(t *testing.T) {
+		invalidator := &mocks.FakeInvalidator{}
+		service := NewImportDatasetService(newBackend(), invalidator)
+
+		report, err := service.Execute(ctx, Input{Data: strings.NewReader(users)})
+		require.NoError(t, err)
+		assert.Equal(t, dataset.Counts{dataset.TypeUser: 2}, report.Imported)
+		assert.ElementsMatch(t, []string{"usr_1111111", "usr_2222222"}, invalidator.Invalidated)
+	})
+
+	t.Run("nothing imported invalidates nothing", func(t *testing.T) {
+		invalidator := &mocks.FakeInvalidator{}
+		service := NewImportDatasetService(newBackend(), invalidator)
+
+		report, err := service.Execute(ctx, Input{Data: strings.NewReader(`{"type":"user","id":"usr_1","name":"","document":"1"}`)})
+		require.NoError(t, err)
+		assert.Len(t, report.Rejected, 1)
+		assert.Empty(t, invalidator.Invalidated)
+	})
+
+	t.Run("applies the tweet length limit", func(t *testing.T) {
+		service := NewImportDatasetService(newBackend(), &mocks.FakeInvalidator{})
+		service.MaxContentLength = 2
+
+		report, err := service.Execute(ctx, Input{Data: strings.NewReader(users +
+			`{"type":"tweet","id":"t1","user_id":"usr_1111111","content":"hello","created_at":"2024-03-01T10:00:00Z"}`)})
+		require.NoError(t, err)
+		require.Len(t, report.Rejected, 1)
+		assert.Contains(t, report.Rejected[0].Reason, "too long")
+	})
+
+	t.Run("unreadable dataset keeps the lines before the failure", func(t *testing.T) {
+		invalidator := &mocks.FakeInvalidator{}
+		service := NewImportDatasetService(newBackend(), invalidator)
+		long := `{"type":"user","name":"` + strings.Repeat("a", 2<<20) + `"}`
+
+		report, err := service.Execute(ctx, Input{Data: strings.NewReader(users + long)})
+		assert.ErrorContains(t, err, "dataset could not be read to the end")
+		assert.Equal(t, 2, report.Imported.Total())
+		assert.Len(t, invalidator.Invalidated, 2)
+	})
+}
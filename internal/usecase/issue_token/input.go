@@ -0,0 +1,6 @@
+package issue_token
+
+type Input struct {
+	UserID   string
+	Password string
+}
@@ -0,0 +1,8 @@
+package issue_token
+
+import "time"
+
+type Output struct {
+	Token     string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,106 @@
+package issue_token
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"ualaTwitter/internal/domain/credential"
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/platform/logger"
+)
+
+const (
+	DefaultMaxFailedAttempts = 5
+	DefaultLockoutDuration   = 15 * time.Minute
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrAccountLocked      = errors.New("account temporarily locked")
+)
+
+type TokenIssuer interface {
+	Issue(subject string, sessionVersion int64) (string, time.Time, error)
+}
+
+type IssueTokenService struct {
+	Credentials credential.Repository
+	Hasher      credential.Hasher
+	Tokens      TokenIssuer
+
+	MaxFailedAttempts int
+	LockoutDuration   time.Duration
+	Now               func() time.Time
+
+	// dummyHash is verified when the user has no credentials, so unknown
+	// and known users take about as long to reject.
+	dummyHash string
+}
+
+func NewIssueTokenService(credentials credential.Repository, hasher credential.Hasher, tokens TokenIssuer) *IssueTokenService {
+	dummyHash, _ := hasher.Hash("dummy-password-0")
+
+	return &IssueTokenService{
+		Credentials:       credentials,
+		Hasher:            hasher,
+		Tokens:            tokens,
+		MaxFailedAttempts: DefaultMaxFailedAttempts,
+		LockoutDuration:   DefaultLockoutDuration,
+		Now:               time.Now,
+		dummyHash:         dummyHash,
+	}
+}
+
+func (s *IssueTokenService) Execute(ctx context.Context, input Input) (Output, error) {
+	if input.UserID == "" || input.Password == "" {
+		return Output{}, usecase.InvalidParam("user ID and password must not be empty")
+	}
+
+	cred, err := s.Credentials.GetByUserID(ctx, input.UserID)
+	if err != nil {
+		if !errors.Is(err, credential.ErrNotFound) {
+			return Output{}, usecase.InternalServerError("failed to load credentials", err)
+		}
+		// Unknown users and wrong passwords answer the same way so the
+		// endpoint can't be used to enumerate accounts.
+		_, _ = s.Hasher.Verify(input.Password, s.dummyHash)
+		return Output{}, usecase.Unauthorized("invalid credentials", ErrInvalidCredentials)
+	}
+
+	now := s.Now().UTC()
+	if cred.IsLocked(now) {
+		return Output{}, usecase.Forbidden("account temporarily locked", ErrAccountLocked)
+	}
+
+	ok, err := s.Hasher.Verify(input.Password, cred.PasswordHash)
+	if err != nil {
+		return Output{}, usecase.InternalServerError("failed to verify password", err)
+	}
+
+	// cred may already be stale: the repository updates the stored row in
+	// place, so a concurrent password change or failure is never undone.
+	if !ok {
+		updated, err := s.Credentials.RecordFailure(ctx, cred.UserID, now, s.MaxFailedAttempts, s.LockoutDuration)
+		if err != nil {
+			logger.FromContext(ctx).Warn("failed to record login failure", zap.String("user_id", cred.UserID), zap.Error(err))
+		} else if updated.IsLocked(now) {
+			logger.FromContext(ctx).Info("account locked after repeated login failures", zap.String("user_id", cred.UserID))
+		}
+		return Output{}, usecase.Unauthorized("invalid credentials", ErrInvalidCredentials)
+	}
+
+	if cred.FailedAttempts > 0 {
+		if err := s.Credentials.ResetFailures(ctx, cred.UserID, now); err != nil {
+			logger.FromContext(ctx).Warn("failed to reset login failures", zap.String("user_id", cred.UserID), zap.Error(err))
+		}
+	}
+
+	token, expiresAt, err := s.Tokens.Issue(cred.UserID, cred.SessionVersion)
+	if err != nil {
+		return Output{}, usecase.InternalServerError("failed to issue token", err)
+	}
+
+	return Output{Token: token, ExpiresAt: expiresAt}, nil
+}
@@ -0,0 +1,178 @@
+package issue_token
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"ualaTwitter/internal/domain/credential"
+	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+type fakeTokenIssuer struct {
+	Token          string
+	Err            error
+	Subject        string
+	SessionVersion int64
+}
+
+func (f *fakeTokenIssuer) Issue(subject string, sessionVersion int64) (string, time.Time, error) {
+	f.Subject = subject
+	f.SessionVersion = sessionVersion
+	return f.Token, time.Unix(1700000000, 0), f.Err
+}
+
+func TestIssueTokenService_Execute(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	existing := credential.Credential{UserID: "usr_12345678", PasswordHash: "hashed:correcthorse42", SessionVersion: 3}
+
+	tests := []struct {
+		name        string
+		input       Input
+		stored      credential.Credential
+		issuer      *fakeTokenIssuer
+		expectErr   string
+		expectToken string
+	}{
+		{
+			name:        "issues a token for matching credentials",
+			input:       Input{UserID: existing.UserID, Password: "correcthorse42"},
+			stored:      existing,
+			issuer:      &fakeTokenIssuer{Token: "jwt"},
+			expectToken: "jwt",
+		},
+		{
+			name:      "empty credentials",
+			input:     Input{},
+			stored:    existing,
+			issuer:    &fakeTokenIssuer{},
+			expectErr: "must not be empty",
+		},
+		{
+			name:      "unknown user",
+			input:     Input{UserID: "usr_ghost", Password: "correcthorse42"},
+			stored:    existing,
+			issuer:    &fakeTokenIssuer{},
+			expectErr: "unauthorized: invalid credentials",
+		},
+		{
+			name:      "wrong password",
+			input:     Input{UserID: existing.UserID, Password: "wronghorse42"},
+			stored:    existing,
+			issuer:    &fakeTokenIssuer{},
+			expectErr: "unauthorized: invalid credentials",
+		},
+		{
+			name:  "locked account rejects even the right password",
+			input: Input{UserID: existing.UserID, Password: "correcthorse42"},
+			stored: credential.Credential{
+				UserID:       existing.UserID,
+				PasswordHash: existing.PasswordHash,
+				LockedUntil:  now.Add(time.Minute),
+			},
+			issuer:    &fakeTokenIssuer{},
+			expectErr: "forbidden: account temporarily locked",
+		},
+		{
+			name:      "issuer failure",
+			input:     Input{UserID: existing.UserID, Password: "correcthorse42"},
+			stored:    existing,
+			issuer:    &fakeTokenIssuer{Err: errors.New("no key")},
+			expectErr: "failed to issue token",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &mocks.FakeCredentialRepo{Credentials: map[string]credential.Credential{tc.stored.UserID: tc.stored}}
+			service := NewIssueTokenService(repo, &mocks.FakeHasher{}, tc.issuer)
+			service.Now = func() time.Time { return now }
+
+			out, err := service.Execute(ctx, tc.input)
+
+			if tc.expectErr != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectToken, out.Token)
+			assert.Equal(t, existing.UserID, tc.issuer.Subject)
+			assert.Equal(t, existing.SessionVersion, tc.issuer.SessionVersion)
+		})
+	}
+}
+
+func TestIssueTokenService_Lockout(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo := &mocks.FakeCredentialRepo{Credentials: map[string]credential.Credential{
+		"usr_1": {UserID: "usr_1", PasswordHash: "hashed:correcthorse42"},
+	}}
+
+	service := NewIssueTokenService(repo, &mocks.FakeHasher{}, &fakeTokenIssuer{Token: "jwt"})
+	service.MaxFailedAttempts = 3
+	service.LockoutDuration = 10 * time.Minute
+	service.Now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		_, err := service.Execute(ctx, Input{UserID: "usr_1", Password: "guess"})
+		require.ErrorContains(t, err, "invalid credentials")
+	}
+
+	_, err := service.Execute(ctx, Input{UserID: "usr_1", Password: "correcthorse42"})
+	assert.ErrorContains(t, err, "account temporarily locked")
+
+	now = now.Add(10 * time.Minute)
+	out, err := service.Execute(ctx, Input{UserID: "usr_1", Password: "correcthorse42"})
+	require.NoError(t, err)
+	assert.Equal(t, "jwt", out.Token)
+}
+
+func TestIssueTokenService_SuccessResetsFailures(t *testing.T) {
+	ctx := context.Background()
+	repo := &mocks.FakeCredentialRepo{Credentials: map[string]credential.Credential{
+		"usr_1": {UserID: "usr_1", PasswordHash: "hashed:correcthorse42", FailedAttempts: 4},
+	}}
+
+	service := NewIssueTokenService(repo, &mocks.FakeHasher{}, &fakeTokenIssuer{Token: "jwt"})
+	_, err := service.Execute(ctx, Input{UserID: "usr_1", Password: "correcthorse42"})
+
+	require.NoError(t, err)
+	assert.Zero(t, repo.Credentials["usr_1"].FailedAttempts)
+}
+
+func TestIssueTokenService_FailureKeepsConcurrentPasswordChange(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo := &mocks.FakeCredentialRepo{Credentials: map[string]credential.Credential{
+		"usr_1": {UserID: "usr_1", PasswordHash: "hashed:correcthorse42", SessionVersion: 3},
+	}}
+	hasher := &mocks.FakeHasher{OnVerify: func() {
+		// The password changes while the wrong guess is being checked.
+		c := repo.Credentials["usr_1"]
+		c.ChangePassword("hashed:batterystaple77", now)
+		repo.Credentials["usr_1"] = c
+	}}
+
+	service := NewIssueTokenService(repo, hasher, &fakeTokenIssuer{Token: "jwt"})
+	service.Now = func() time.Time { return now }
+	_, err := service.Execute(ctx, Input{UserID: "usr_1", Password: "guess"})
+
+	require.ErrorContains(t, err, "invalid credentials")
+	stored := repo.Credentials["usr_1"]
+	assert.Equal(t, "hashed:batterystaple77", stored.PasswordHash)
+	assert.Equal(t, int64(4), stored.SessionVersion)
+	assert.Equal(t, 1, stored.FailedAttempts)
+}
@@ -8,18 +8,24 @@ import (
 
 	"ualaTwitter/internal/domain/like"
 	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/cache"
 	"ualaTwitter/internal/platform/errors/usecase"
 )
 
 type LikeTweetService struct {
 	TweetRepo tweet.Repository
 	LikeRepo  like.Repository
+	UserRepo  user.InMemoryRepository
+	Timelines cache.Invalidator
 }
 
-func NewLikeTweetService(tweetRepo tweet.Repository, likeRepo like.Repository) *LikeTweetService {
+func NewLikeTweetService(tweetRepo tweet.Repository, likeRepo like.Repository, userRepo user.InMemoryRepository, timelines cache.Invalidator) *LikeTweetService {
 	return &LikeTweetService{
 		TweetRepo: tweetRepo,
 		LikeRepo:  likeRepo,
+		UserRepo:  userRepo,
+		Timelines: timelines,
 	}
 }
 
@@ -28,12 +34,12 @@ func (s *LikeTweetService) Execute(ctx context.Context, input Input) error {
 		return usecase.InvalidParam("user ID and tweet ID must not be empty", like.ErrInvalidInput)
 	}
 
-	alreadyLiked, err := s.LikeRepo.HasLiked(ctx, input.TweetID, input.UserID)
+	alreadyLiked, err := s.LikeRepo.HasLiked(ctx, input.UserID, input.TweetID)
 	if err != nil {
 		return usecase.InternalServerError("failed to check like status", err)
 	}
 	if alreadyLiked {
-		logger.Log.Warn("duplicate like attempt",
+		logger.FromContext(ctx).Warn("duplicate like attempt",
 			zap.String("user_id", input.UserID),
 			zap.String("tweet_id", input.TweetID),
 		)
@@ -49,9 +55,47 @@ func (s *LikeTweetService) Execute(ctx context.Context, input Input) error {
 		}
 	}
 
-	if err := s.LikeRepo.Like(ctx, input.TweetID, input.UserID); err != nil {
+	if err := s.LikeRepo.Like(ctx, input.UserID, input.TweetID); err != nil {
 		return usecase.InternalServerError("failed to persist like", err)
 	}
 
+	s.invalidateTimelines(ctx, input)
+
 	return nil
 }
+
+// invalidateTimelines drops the cached timelines that show the tweet: the
+// liker's, which would keep liked_by_me=false, and those of the author's
+// followers, which would keep the old like count and ETag. The like is
+// already saved, so failures are only logged.
+func (s *LikeTweetService) invalidateTimelines(ctx context.Context, input Input) {
+	userIDs := []string{input.UserID}
+
+	followers, err := s.followersOfAuthor(ctx, input.TweetID)
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to fetch followers for timeline invalidation",
+			zap.String("tweet_id", input.TweetID),
+			zap.Error(err),
+		)
+	}
+	for _, id := range followers {
+		if id != input.UserID {
+			userIDs = append(userIDs, id)
+		}
+	}
+
+	if err := s.Timelines.Invalidate(ctx, userIDs...); err != nil {
+		logger.FromContext(ctx).Warn("failed to invalidate timelines",
+			zap.String("tweet_id", input.TweetID),
+			zap.Error(err),
+		)
+	}
+}
+
+func (s *LikeTweetService) followersOfAuthor(ctx context.Context, tweetID string) ([]string, error) {
+	t, err := s.TweetRepo.GetByID(ctx, tweetID)
+	if err != nil {
+		return nil, err
+	}
+	return s.UserRepo.GetFollowersOf(ctx, t.UserID)
+}
@@ -25,7 +25,7 @@ func TestLikeTweetService_Execute(t *testing.T) {
 		likeRepo := &mocks.FakeLikeRepo{}
 		tweetRepo := &mocks.FakeTweetRepo{}
 
-		service := NewLikeTweetService(tweetRepo, likeRepo)
+		service := NewLikeTweetService(tweetRepo, likeRepo, &mocks.FakeUserRepo{}, &mocks.FakeInvalidator{})
 
 		err := service.Execute(ctx, Input{
 			UserID:  validUserID,
@@ -39,7 +39,7 @@ func TestLikeTweetService_Execute(t *testing.T) {
 	})
 
 	t.Run("missing user or tweet ID", func(t *testing.T) {
-		service := NewLikeTweetService(&mocks.FakeTweetRepo{}, &mocks.FakeLikeRepo{})
+		service := NewLikeTweetService(&mocks.FakeTweetRepo{}, &mocks.FakeLikeRepo{}, &mocks.FakeUserRepo{}, &mocks.FakeInvalidator{})
 
 		err := service.Execute(ctx, Input{
 			UserID:  "",
@@ -52,7 +52,7 @@ func TestLikeTweetService_Execute(t *testing.T) {
 
 	t.Run("already liked tweet", func(t *testing.T) {
 		likeRepo := &mocks.FakeLikeRepo{AlreadyLiked: true}
-		service := NewLikeTweetService(&mocks.FakeTweetRepo{}, likeRepo)
+		service := NewLikeTweetService(&mocks.FakeTweetRepo{}, likeRepo, &mocks.FakeUserRepo{}, &mocks.FakeInvalidator{})
 
 		err := service.Execute(ctx, Input{
 			UserID:  validUserID,
@@ -65,7 +65,7 @@ func TestLikeTweetService_Execute(t *testing.T) {
 
 	t.Run("error checking like status", func(t *testing.T) {
 		likeRepo := &mocks.FakeLikeRepo{HasLikedErr: errors.New("db error")}
-		service := NewLikeTweetService(&mocks.FakeTweetRepo{}, likeRepo)
+		service := NewLikeTweetService(&mocks.FakeTweetRepo{}, likeRepo, &mocks.FakeUserRepo{}, &mocks.FakeInvalidator{})
 
 		err := service.Execute(ctx, Input{
 			UserID:  validUserID,
@@ -78,7 +78,7 @@ func TestLikeTweetService_Execute(t *testing.T) {
 
 	t.Run("tweet not found on increment", func(t *testing.T) {
 		tweetRepo := &mocks.FakeTweetRepo{IncrementLikesErr: tweet.ErrNotFound}
-		service := NewLikeTweetService(tweetRepo, &mocks.FakeLikeRepo{})
+		service := NewLikeTweetService(tweetRepo, &mocks.FakeLikeRepo{}, &mocks.FakeUserRepo{}, &mocks.FakeInvalidator{})
 
 		err := service.Execute(ctx, Input{
 			UserID:  validUserID,
@@ -92,7 +92,7 @@ func TestLikeTweetService_Execute(t *testing.T) {
 	t.Run("error persisting like", func(t *testing.T) {
 		likeRepo := &mocks.FakeLikeRepo{LikeErr: errors.New("db error")}
 		tweetRepo := &mocks.FakeTweetRepo{}
-		service := NewLikeTweetService(tweetRepo, likeRepo)
+		service := NewLikeTweetService(tweetRepo, likeRepo, &mocks.FakeUserRepo{}, &mocks.FakeInvalidator{})
 
 		err := service.Execute(ctx, Input{
 			UserID:  validUserID,
@@ -102,4 +102,60 @@ func TestLikeTweetService_Execute(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "persist like")
 	})
+
+	t.Run("invalidates the liker's cached timeline", func(t *testing.T) {
+		invalidator := &mocks.FakeInvalidator{}
+		service := NewLikeTweetService(&mocks.FakeTweetRepo{}, &mocks.FakeLikeRepo{}, &mocks.FakeUserRepo{}, invalidator)
+
+		err := service.Execute(ctx, Input{
+			UserID:  validUserID,
+			TweetID: validTweetID,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{validUserID}, invalidator.Invalidated)
+	})
+
+	t.Run("invalidates the timelines of the author's followers", func(t *testing.T) {
+		invalidator := &mocks.FakeInvalidator{}
+		tweetRepo := &mocks.FakeTweetRepo{TweetsByID: map[string]tweet.Tweet{validTweetID: {ID: validTweetID, UserID: "usr_author"}}}
+		userRepo := &mocks.FakeUserRepo{Followees: map[string][]string{
+			validUserID: {"usr_author"},
+			"usr_other": {"usr_author"},
+		}}
+		service := NewLikeTweetService(tweetRepo, &mocks.FakeLikeRepo{}, userRepo, invalidator)
+
+		err := service.Execute(ctx, Input{
+			UserID:  validUserID,
+			TweetID: validTweetID,
+		})
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{validUserID, "usr_other"}, invalidator.Invalidated)
+	})
+
+	t.Run("follower lookup failure still invalidates the liker's timeline", func(t *testing.T) {
+		invalidator := &mocks.FakeInvalidator{}
+		userRepo := &mocks.FakeUserRepo{FollowersErr: errors.New("boom")}
+		service := NewLikeTweetService(&mocks.FakeTweetRepo{}, &mocks.FakeLikeRepo{}, userRepo, invalidator)
+
+		err := service.Execute(ctx, Input{
+			UserID:  validUserID,
+			TweetID: validTweetID,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{validUserID}, invalidator.Invalidated)
+	})
+
+	t.Run("invalidation failure does not fail the like", func(t *testing.T) {
+		service := NewLikeTweetService(&mocks.FakeTweetRepo{}, &mocks.FakeLikeRepo{}, &mocks.FakeUserRepo{}, &mocks.FakeInvalidator{Err: errors.New("cache down")})
+
+		err := service.Execute(ctx, Input{
+			UserID:  validUserID,
+			TweetID: validTweetID,
+		})
+
+		assert.NoError(t, err)
+	})
 }
@@ -0,0 +1,5 @@
+package list_api_keys
+
+type Input struct {
+	UserID string
+}
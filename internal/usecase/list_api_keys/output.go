@@ -0,0 +1,13 @@
+package list_api_keys
+
+import "time"
+
+type APIKey struct {
+	ID         string
+	Name       string
+	Prefix     string
+	Scopes     []string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
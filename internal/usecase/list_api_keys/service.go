@@ -0,0 +1,51 @@
+package list_api_keys
+
+import (
+	"context"
+	"time"
+
+	"ualaTwitter/internal/domain/apikey"
+	"ualaTwitter/internal/platform/errors/usecase"
+)
+
+type ListAPIKeysService struct {
+	KeyRepo apikey.Repository
+}
+
+func NewListAPIKeysService(keyRepo apikey.Repository) *ListAPIKeysService {
+	return &ListAPIKeysService{
+		KeyRepo: keyRepo,
+	}
+}
+
+func (s *ListAPIKeysService) Execute(ctx context.Context, input Input) ([]APIKey, error) {
+	if input.UserID == "" {
+		return nil, usecase.InvalidParam("user ID must not be empty")
+	}
+
+	keys, err := s.KeyRepo.ListByUser(ctx, input.UserID)
+	if err != nil {
+		return nil, usecase.InternalServerError("failed to list api keys", err)
+	}
+
+	result := make([]APIKey, len(keys))
+	for i, k := range keys {
+		result[i] = APIKey{
+			ID:         k.ID,
+			Name:       k.Name,
+			Prefix:     k.Prefix,
+			Scopes:     k.Scopes,
+			CreatedAt:  k.CreatedAt,
+			LastUsedAt: optionalTime(k.LastUsedAt),
+			RevokedAt:  optionalTime(k.RevokedAt),
+		}
+	}
+	return result, nil
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
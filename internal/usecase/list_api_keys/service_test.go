@@ -0,0 +1,49 @@
+package list_api_keys
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ualaTwitter/internal/domain/apikey"
+	"ualaTwitter/internal/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAPIKeysService_Execute(t *testing.T) {
+	ctx := context.Background()
+	used := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("lists the user's keys without secrets", func(t *testing.T) {
+		repo := &mocks.FakeAPIKeyRepo{Keys: map[string]apikey.APIKey{
+			"k1": {ID: "k1", UserID: "usr_1", Name: "bot", Prefix: "uak_abcdef", Hash: "secret-hash", LastUsedAt: used},
+			"k2": {ID: "k2", UserID: "usr_2", Name: "other"},
+		}}
+
+		keys, err := NewListAPIKeysService(repo).Execute(ctx, Input{UserID: "usr_1"})
+
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		assert.Equal(t, "k1", keys[0].ID)
+		assert.Equal(t, "uak_abcdef", keys[0].Prefix)
+		assert.Equal(t, &used, keys[0].LastUsedAt)
+		assert.Nil(t, keys[0].RevokedAt)
+	})
+
+	t.Run("no keys returns an empty list", func(t *testing.T) {
+		keys, err := NewListAPIKeysService(&mocks.FakeAPIKeyRepo{}).Execute(ctx, Input{UserID: "usr_1"})
+
+		require.NoError(t, err)
+		assert.NotNil(t, keys)
+		assert.Empty(t, keys)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		_, err := NewListAPIKeysService(&mocks.FakeAPIKeyRepo{ListErr: errors.New("db down")}).Execute(ctx, Input{UserID: "usr_1"})
+
+		assert.ErrorContains(t, err, "failed to list api keys")
+	})
+}
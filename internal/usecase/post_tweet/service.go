@@ -4,8 +4,13 @@ import (
 	"context"
 	"errors"
 	"time"
+
+	"go.uber.org/zap"
+
 	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/cache"
 	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/platform/logger"
 
 	"ualaTwitter/internal/domain/tweet"
 )
@@ -13,12 +18,17 @@ import (
 type PostTweetService struct {
 	TweetRepo tweet.Repository
 	UserRepo  user.InMemoryRepository
+	Timelines cache.Invalidator
+	// MaxContentLength caps tweet length, in characters.
+	MaxContentLength int
 }
 
-func NewPostTweetService(tweetRepo tweet.Repository, memoryUserRepository user.InMemoryRepository) *PostTweetService {
+func NewPostTweetService(tweetRepo tweet.Repository, memoryUserRepository user.InMemoryRepository, timelines cache.Invalidator) *PostTweetService {
 	return &PostTweetService{
-		TweetRepo: tweetRepo,
-		UserRepo:  memoryUserRepository,
+		TweetRepo:        tweetRepo,
+		UserRepo:         memoryUserRepository,
+		Timelines:        timelines,
+		MaxContentLength: tweet.MaxContentLength,
 	}
 
 }
@@ -28,7 +38,7 @@ func (s *PostTweetService) Execute(ctx context.Context, input Input) (string, er
 		return "", usecase.NotFound("", user.ErrUserNotFound)
 	}
 
-	newTweet, err := tweet.New(input.UserID, input.Content, time.Now())
+	newTweet, err := tweet.NewWithMaxLength(input.UserID, input.Content, time.Now(), s.MaxContentLength)
 	if err != nil {
 		switch {
 		case errors.Is(err, tweet.ErrEmptyTweet), errors.Is(err, tweet.ErrTooLong):
@@ -42,5 +52,27 @@ func (s *PostTweetService) Execute(ctx context.Context, input Input) (string, er
 		return "", usecase.InternalServerError("failed to persist tweet", err)
 	}
 
+	s.invalidateFollowerTimelines(ctx, input.UserID)
+
 	return newTweet.ID, nil
 }
+
+// invalidateFollowerTimelines drops the cached timelines that now miss the
+// new tweet. The tweet is already saved, so failures are only logged.
+func (s *PostTweetService) invalidateFollowerTimelines(ctx context.Context, authorID string) {
+	followers, err := s.UserRepo.GetFollowersOf(ctx, authorID)
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to fetch followers for timeline invalidation",
+			zap.String("user_id", authorID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	if err := s.Timelines.Invalidate(ctx, followers...); err != nil {
+		logger.FromContext(ctx).Warn("failed to invalidate follower timelines",
+			zap.String("user_id", authorID),
+			zap.Error(err),
+		)
+	}
+}
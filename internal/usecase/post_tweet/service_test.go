@@ -4,12 +4,18 @@ import (
 	"context"
 	"errors"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 	"testing"
 	"ualaTwitter/internal/domain/tweet"
 	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/logger"
 	"ualaTwitter/internal/test/mocks"
 )
 
+func init() {
+	logger.Log = zap.NewNop()
+}
+
 func TestPostTweetService_Execute(t *testing.T) {
 	validUser := &user.User{ID: "usr_123", Name: "Test User", Document: "12345678"}
 	ctx := context.Background()
@@ -18,7 +24,7 @@ func TestPostTweetService_Execute(t *testing.T) {
 		userRepo := &mocks.FakeUserRepo{Users: map[string]*user.User{validUser.ID: validUser}}
 		tweetRepo := &mocks.FakeTweetRepo{}
 
-		service := NewPostTweetService(tweetRepo, userRepo)
+		service := NewPostTweetService(tweetRepo, userRepo, &mocks.FakeInvalidator{})
 
 		input := Input{
 			UserID:  validUser.ID,
@@ -36,7 +42,7 @@ func TestPostTweetService_Execute(t *testing.T) {
 		userRepo := &mocks.FakeUserRepo{Users: map[string]*user.User{validUser.ID: validUser}}
 		tweetRepo := &mocks.FakeTweetRepo{}
 
-		service := NewPostTweetService(tweetRepo, userRepo)
+		service := NewPostTweetService(tweetRepo, userRepo, &mocks.FakeInvalidator{})
 
 		_, err := service.Execute(ctx, Input{
 			UserID:  "nonexistent",
@@ -51,7 +57,7 @@ func TestPostTweetService_Execute(t *testing.T) {
 		userRepo := &mocks.FakeUserRepo{Users: map[string]*user.User{validUser.ID: validUser}}
 		tweetRepo := &mocks.FakeTweetRepo{}
 
-		service := NewPostTweetService(tweetRepo, userRepo)
+		service := NewPostTweetService(tweetRepo, userRepo, &mocks.FakeInvalidator{})
 
 		_, err := service.Execute(ctx, Input{
 			UserID:  validUser.ID,
@@ -66,7 +72,7 @@ func TestPostTweetService_Execute(t *testing.T) {
 		userRepo := &mocks.FakeUserRepo{Users: map[string]*user.User{validUser.ID: validUser}}
 		tweetRepo := &mocks.FakeTweetRepo{}
 
-		service := NewPostTweetService(tweetRepo, userRepo)
+		service := NewPostTweetService(tweetRepo, userRepo, &mocks.FakeInvalidator{})
 
 		tooLong := make([]byte, tweet.MaxContentLength+1)
 		for i := range tooLong {
@@ -82,11 +88,26 @@ func TestPostTweetService_Execute(t *testing.T) {
 		assert.Contains(t, err.Error(), "invalid tweet content")
 	})
 
+	t.Run("content length limit is configurable", func(t *testing.T) {
+		userRepo := &mocks.FakeUserRepo{Users: map[string]*user.User{validUser.ID: validUser}}
+		tweetRepo := &mocks.FakeTweetRepo{}
+
+		service := NewPostTweetService(tweetRepo, userRepo, &mocks.FakeInvalidator{})
+		service.MaxContentLength = 5
+
+		_, err := service.Execute(ctx, Input{UserID: validUser.ID, Content: "123456"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid tweet content")
+
+		_, err = service.Execute(ctx, Input{UserID: validUser.ID, Content: "12345"})
+		assert.NoError(t, err)
+	})
+
 	t.Run("tweet repo fails to save", func(t *testing.T) {
 		userRepo := &mocks.FakeUserRepo{Users: map[string]*user.User{validUser.ID: validUser}}
 		tweetRepo := &mocks.FakeTweetRepo{SaveErr: errors.New("db failure")}
 
-		service := NewPostTweetService(tweetRepo, userRepo)
+		service := NewPostTweetService(tweetRepo, userRepo, &mocks.FakeInvalidator{})
 
 		_, err := service.Execute(ctx, Input{
 			UserID:  validUser.ID,
@@ -96,4 +117,29 @@ func TestPostTweetService_Execute(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to persist tweet")
 	})
+
+	t.Run("invalidates the timelines of the author's followers", func(t *testing.T) {
+		userRepo := &mocks.FakeUserRepo{
+			Users:     map[string]*user.User{validUser.ID: validUser},
+			Followees: map[string][]string{"fan_1": {validUser.ID}, "fan_2": {validUser.ID}, "other": {"usr_999"}},
+		}
+		invalidator := &mocks.FakeInvalidator{}
+		service := NewPostTweetService(&mocks.FakeTweetRepo{}, userRepo, invalidator)
+
+		_, err := service.Execute(ctx, Input{UserID: validUser.ID, Content: "fresh"})
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"fan_1", "fan_2"}, invalidator.Invalidated)
+	})
+
+	t.Run("invalidation failure does not fail the post", func(t *testing.T) {
+		userRepo := &mocks.FakeUserRepo{Users: map[string]*user.User{validUser.ID: validUser}}
+		invalidator := &mocks.FakeInvalidator{Err: errors.New("cache down")}
+		service := NewPostTweetService(&mocks.FakeTweetRepo{}, userRepo, invalidator)
+
+		tweetID, err := service.Execute(ctx, Input{UserID: validUser.ID, Content: "still posted"})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, tweetID)
+	})
 }
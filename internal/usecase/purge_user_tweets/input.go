@@ -0,0 +1,5 @@
+package purge_user_tweets
+
+type Input struct {
+	UserID string
+}
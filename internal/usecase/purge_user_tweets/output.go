@@ -0,0 +1,7 @@
+package purge_user_tweets
+
+type Output struct {
+	// Tweets and Likes are how many of each were deleted.
+	Tweets int
+	Likes  int
+}
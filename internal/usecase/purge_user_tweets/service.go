@@ -0,0 +1,87 @@
+package purge_user_tweets
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/cache"
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/platform/logger"
+)
+
+// TweetPurger deletes every tweet of an author.
+type TweetPurger interface {
+	DeleteAuthoredBy(ctx context.Context, userID string) ([]string, error)
+}
+
+// LikePurger deletes the likes of deleted tweets.
+type LikePurger interface {
+	DeleteForTweets(ctx context.Context, tweetIDs []string) (int, error)
+}
+
+// PurgeUserTweetsService deletes a user's tweets along with their likes.
+// The account and its follow edges are kept.
+type PurgeUserTweetsService struct {
+	Tweets    TweetPurger
+	Likes     LikePurger
+	UserRepo  user.InMemoryRepository
+	Timelines cache.Invalidator
+}
+
+func NewPurgeUserTweetsService(tweets TweetPurger, likes LikePurger, userRepo user.InMemoryRepository, timelines cache.Invalidator) *PurgeUserTweetsService {
+	return &PurgeUserTweetsService{
+		Tweets:    tweets,
+		Likes:     likes,
+		UserRepo:  userRepo,
+		Timelines: timelines,
+	}
+}
+
+func (s *PurgeUserTweetsService) Execute(ctx context.Context, input Input) (Output, error) {
+	if input.UserID == "" {
+		return Output{}, usecase.InvalidParam("user ID must not be empty")
+	}
+
+	if _, err := s.UserRepo.GetByID(ctx, input.UserID); err != nil {
+		return Output{}, usecase.NotFound("user not found", user.ErrUserNotFound)
+	}
+
+	tweetIDs, err := s.Tweets.DeleteAuthoredBy(ctx, input.UserID)
+	if err != nil {
+		return Output{}, usecase.InternalServerError("failed to delete tweets", err)
+	}
+	if len(tweetIDs) == 0 {
+		return Output{}, nil
+	}
+
+	likes, err := s.Likes.DeleteForTweets(ctx, tweetIDs)
+	if err != nil {
+		return Output{}, usecase.InternalServerError("failed to delete likes of purged tweets", err)
+	}
+
+	s.invalidateFollowerTimelines(ctx, input.UserID)
+
+	return Output{Tweets: len(tweetIDs), Likes: likes}, nil
+}
+
+// invalidateFollowerTimelines drops the cached timelines still showing the
+// purged tweets. The tweets are already gone, so failures are only logged.
+func (s *PurgeUserTweetsService) invalidateFollowerTimelines(ctx context.Context, authorID string) {
+	followers, err := s.UserRepo.GetFollowersOf(ctx, authorID)
+	if err != nil {
+		logger.FromContext(ctx).Warn("failed to fetch followers for timeline invalidation",
+			zap.String("user_id", authorID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	if err := s.Timelines.Invalidate(ctx, followers...); err != nil {
+		logger.FromContext(ctx).Warn("failed to invalidate follower timelines",
+			zap.String("user_id", authorID),
+			zap.Error(err),
+		)
+	}
+}
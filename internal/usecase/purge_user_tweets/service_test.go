@@ -0,0 +1,101 @@
+package purge_user_tweets
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/test/mocks"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+func TestPurgeUserTweetsService_Execute(t *testing.T) {
+	ctx := context.Background()
+	users := func() *mocks.FakeUserRepo {
+		return &mocks.FakeUserRepo{
+			Users:     map[string]*user.User{"author": {ID: "author"}, "reader": {ID: "reader"}},
+			Followees: map[string][]string{"reader": {"author"}},
+		}
+	}
+
+	t.Run("deletes tweets and their likes", func(t *testing.T) {
+		tweets := &mocks.FakeTweetRepo{TweetsByUser: map[string][]tweet.Tweet{
+			"author": {{ID: "t1", UserID: "author"}, {ID: "t2", UserID: "author"}},
+			"reader": {{ID: "t3", UserID: "reader"}},
+		}}
+		likes := &mocks.FakeLikeRepo{Counts: map[string]int{"t1": 2, "t3": 1}}
+		invalidator := &mocks.FakeInvalidator{}
+
+		out, err := NewPurgeUserTweetsService(tweets, likes, users(), invalidator).Execute(ctx, Input{UserID: "author"})
+		require.NoError(t, err)
+		assert.Equal(t, Output{Tweets: 2, Likes: 2}, out)
+		assert.Equal(t, []string{"t1", "t2"}, likes.Deleted)
+		assert.NotContains(t, tweets.TweetsByUser, "author")
+		assert.Contains(t, tweets.TweetsByUser, "reader")
+		assert.Equal(t, []string{"reader"}, invalidator.Invalidated)
+	})
+
+	t.Run("user without tweets", func(t *testing.T) {
+		likes := &mocks.FakeLikeRepo{}
+		out, err := NewPurgeUserTweetsService(&mocks.FakeTweetRepo{}, likes, users(), &mocks.FakeInvalidator{}).Execute(ctx, Input{UserID: "reader"})
+		require.NoError(t, err)
+		assert.Equal(t, Output{}, out)
+		assert.Empty(t, likes.Deleted)
+	})
+
+	tests := []struct {
+		name      string
+		input     Input
+		tweets    *mocks.FakeTweetRepo
+		likes     *mocks.FakeLikeRepo
+		expectErr string
+	}{
+		{
+			name:      "empty user ID",
+			expectErr: "must not be empty",
+		},
+		{
+			name:      "unknown user",
+			input:     Input{UserID: "ghost"},
+			expectErr: "user not found",
+		},
+		{
+			name:      "deleting tweets fails",
+			input:     Input{UserID: "author"},
+			tweets:    &mocks.FakeTweetRepo{DeleteErr: errors.New("boom")},
+			expectErr: "failed to delete tweets",
+		},
+		{
+			name:  "deleting likes fails",
+			input: Input{UserID: "author"},
+			tweets: &mocks.FakeTweetRepo{TweetsByUser: map[string][]tweet.Tweet{
+				"author": {{ID: "t1", UserID: "author"}},
+			}},
+			likes:     &mocks.FakeLikeRepo{DeleteErr: errors.New("boom")},
+			expectErr: "failed to delete likes",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.tweets == nil {
+				tc.tweets = &mocks.FakeTweetRepo{}
+			}
+			if tc.likes == nil {
+				tc.likes = &mocks.FakeLikeRepo{}
+			}
+			_, err := NewPurgeUserTweetsService(tc.tweets, tc.likes, users(), &mocks.FakeInvalidator{}).Execute(ctx, tc.input)
+			assert.ErrorContains(t, err, tc.expectErr)
+		})
+	}
+}
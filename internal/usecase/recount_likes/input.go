@@ -0,0 +1,3 @@
+package recount_likes
+
+type Input struct{}
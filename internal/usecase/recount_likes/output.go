@@ -0,0 +1,14 @@
+package recount_likes
+
+type Output struct {
+	// Checked is the number of tweets compared with the like set.
+	Checked   int
+	Corrected []Correction
+}
+
+// Correction is a tweet whose counter disagreed with the like set.
+type Correction struct {
+	TweetID string
+	Was     int
+	Now     int
+}
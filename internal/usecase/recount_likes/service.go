@@ -0,0 +1,98 @@
+package recount_likes
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/cache"
+	"ualaTwitter/internal/platform/errors/usecase"
+	"ualaTwitter/internal/platform/logger"
+)
+
+// TweetCounters lists tweets and overwrites their like counters.
+type TweetCounters interface {
+	All(ctx context.Context) ([]tweet.Tweet, error)
+	SetLikesIf(ctx context.Context, tweetID string, expected, likes int) (bool, error)
+}
+
+// LikeTally counts the like set per tweet.
+type LikeTally interface {
+	CountByTweet(ctx context.Context) (map[string]int, error)
+}
+
+// RecountLikesService rebuilds every tweet's like counter from the like
+// set, which is the source of truth when the two drift apart.
+type RecountLikesService struct {
+	Tweets    TweetCounters
+	Likes     LikeTally
+	UserRepo  user.InMemoryRepository
+	Timelines cache.Invalidator
+}
+
+func NewRecountLikesService(tweets TweetCounters, likes LikeTally, userRepo user.InMemoryRepository, timelines cache.Invalidator) *RecountLikesService {
+	return &RecountLikesService{
+		Tweets:    tweets,
+		Likes:     likes,
+		UserRepo:  userRepo,
+		Timelines: timelines,
+	}
+}
+
+func (s *RecountLikesService) Execute(ctx context.Context, _ Input) (Output, error) {
+	tweets, err := s.Tweets.All(ctx)
+	if err != nil {
+		return Output{}, usecase.InternalServerError("failed to list tweets", err)
+	}
+	counts, err := s.Likes.CountByTweet(ctx)
+	if err != nil {
+		return Output{}, usecase.InternalServerError("failed to count likes", err)
+	}
+
+	out := Output{Checked: len(tweets), Corrected: []Correction{}}
+	authors := make(map[string]struct{})
+	for _, t := range tweets {
+		if t.Likes == counts[t.ID] {
+			continue
+		}
+		// A like landing since All moved the counter; the count read for it
+		// may already be stale, so the tweet is left for the next recount.
+		set, err := s.Tweets.SetLikesIf(ctx, t.ID, t.Likes, counts[t.ID])
+		if err != nil {
+			return Output{}, usecase.InternalServerError("failed to update like counter", err)
+		}
+		if !set {
+			logger.FromContext(ctx).Info("like counter changed during recount, skipped",
+				zap.String("tweet_id", t.ID),
+			)
+			continue
+		}
+		out.Corrected = append(out.Corrected, Correction{TweetID: t.ID, Was: t.Likes, Now: counts[t.ID]})
+		authors[t.UserID] = struct{}{}
+	}
+	sort.Slice(out.Corrected, func(i, j int) bool { return out.Corrected[i].TweetID < out.Corrected[j].TweetID })
+
+	s.invalidateFollowerTimelines(ctx, authors)
+
+	return out, nil
+}
+
+// invalidateFollowerTimelines drops the cached timelines showing the old
+// counters. The counters are already fixed, so failures are only logged.
+func (s *RecountLikesService) invalidateFollowerTimelines(ctx context.Context, authors map[string]struct{}) {
+	for authorID := range authors {
+		followers, err := s.UserRepo.GetFollowersOf(ctx, authorID)
+		if err == nil {
+			err = s.Timelines.Invalidate(ctx, followers...)
+		}
+		if err != nil {
+			logger.FromContext(ctx).Warn("failed to invalidate follower timelines",
+				zap.String("user_id", authorID),
+				zap.Error(err),
+			)
+		}
+	}
+}
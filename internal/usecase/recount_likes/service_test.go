@@ -0,0 +1,93 @@
+package recount_likes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"ualaTwitter/internal/domain/tweet"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/logger"
+	"ualaTwitter/internal/test/mocks"
+)
+
+func init() {
+	logger.Log = zap.NewNop()
+}
+
+func TestRecountLikesService_Execute(t *testing.T) {
+	ctx := context.Background()
+	users := func() *mocks.FakeUserRepo {
+		return &mocks.FakeUserRepo{
+			Users:     map[string]*user.User{"author": {ID: "author"}, "reader": {ID: "reader"}},
+			Followees: map[string][]string{"reader": {"author"}},
+		}
+	}
+
+	t.Run("fixes drifted counters and invalidates follower timelines", func(t *testing.T) {
+		tweets := &mocks.FakeTweetRepo{TweetsByID: map[string]tweet.Tweet{
+			"t1": {ID: "t1", UserID: "author", Likes: 5},
+			"t2": {ID: "t2", UserID: "author", Likes: 1},
+			"t3": {ID: "t3", UserID: "reader", Likes: 0},
+		}}
+		likes := &mocks.FakeLikeRepo{Counts: map[string]int{"t1": 2, "t2": 1, "t3": 1}}
+		invalidator := &mocks.FakeInvalidator{}
+
+		out, err := NewRecountLikesService(tweets, likes, users(), invalidator).Execute(ctx, Input{})
+		require.NoError(t, err)
+		assert.Equal(t, 3, out.Checked)
+		assert.Equal(t, []Correction{
+			{TweetID: "t1", Was: 5, Now: 2},
+			{TweetID: "t3", Was: 0, Now: 1},
+		}, out.Corrected)
+		assert.Equal(t, map[string]int{"t1": 2, "t3": 1}, tweets.LikesSet)
+		assert.Equal(t, []string{"reader"}, invalidator.Invalidated)
+	})
+
+	t.Run("consistent counters change nothing", func(t *testing.T) {
+		tweets := &mocks.FakeTweetRepo{TweetsByID: map[string]tweet.Tweet{"t1": {ID: "t1", UserID: "author"}}}
+		invalidator := &mocks.FakeInvalidator{}
+
+		out, err := NewRecountLikesService(tweets, &mocks.FakeLikeRepo{}, users(), invalidator).Execute(ctx, Input{})
+		require.NoError(t, err)
+		assert.Empty(t, out.Corrected)
+		assert.Nil(t, tweets.LikesSet)
+		assert.Empty(t, invalidator.Invalidated)
+	})
+
+	t.Run("counters that moved during the recount are left alone", func(t *testing.T) {
+		tweets := &mocks.FakeTweetRepo{
+			TweetsByID: map[string]tweet.Tweet{
+				"t1": {ID: "t1", UserID: "author", Likes: 5},
+				"t2": {ID: "t2", UserID: "author", Likes: 4},
+			},
+			ConcurrentLikes: map[string]int{"t1": 1},
+		}
+		likes := &mocks.FakeLikeRepo{Counts: map[string]int{"t1": 2, "t2": 1}}
+
+		out, err := NewRecountLikesService(tweets, likes, users(), &mocks.FakeInvalidator{}).Execute(ctx, Input{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, out.Checked)
+		assert.Equal(t, []Correction{{TweetID: "t2", Was: 4, Now: 1}}, out.Corrected)
+		assert.Equal(t, map[string]int{"t2": 1}, tweets.LikesSet)
+	})
+
+	t.Run("counting fails", func(t *testing.T) {
+		likes := &mocks.FakeLikeRepo{CountErr: errors.New("boom")}
+		_, err := NewRecountLikesService(&mocks.FakeTweetRepo{}, likes, users(), &mocks.FakeInvalidator{}).Execute(ctx, Input{})
+		assert.ErrorContains(t, err, "failed to count likes")
+	})
+
+	t.Run("update fails", func(t *testing.T) {
+		tweets := &mocks.FakeTweetRepo{
+			TweetsByID:  map[string]tweet.Tweet{"t1": {ID: "t1", UserID: "author", Likes: 3}},
+			SetLikesErr: errors.New("boom"),
+		}
+		_, err := NewRecountLikesService(tweets, &mocks.FakeLikeRepo{}, users(), &mocks.FakeInvalidator{}).Execute(ctx, Input{})
+		assert.ErrorContains(t, err, "failed to update like counter")
+	})
+}
@@ -0,0 +1,6 @@
+package reset_password
+
+type Input struct {
+	UserID   string
+	Password string
+}
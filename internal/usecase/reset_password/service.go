@@ -0,0 +1,60 @@
+package reset_password
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ualaTwitter/internal/domain/credential"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/platform/errors/usecase"
+)
+
+// ResetPasswordService sets a user's password without the current one, for
+// operators. Users loaded from a dataset have no credentials until then.
+type ResetPasswordService struct {
+	Credentials credential.Repository
+	UserRepo    user.InMemoryRepository
+	Hasher      credential.Hasher
+	Now         func() time.Time
+}
+
+func NewResetPasswordService(credentials credential.Repository, userRepo user.InMemoryRepository, hasher credential.Hasher) *ResetPasswordService {
+	return &ResetPasswordService{
+		Credentials: credentials,
+		UserRepo:    userRepo,
+		Hasher:      hasher,
+		Now:         time.Now,
+	}
+}
+
+// Execute replaces the user's password, revoking every token issued before,
+// or creates the credentials when the user has none.
+func (s *ResetPasswordService) Execute(ctx context.Context, input Input) error {
+	if input.UserID == "" || input.Password == "" {
+		return usecase.InvalidParam("user ID and password must not be empty")
+	}
+
+	u, err := s.UserRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return usecase.NotFound("user not found", user.ErrUserNotFound)
+	}
+	if err := user.ValidatePassword(input.Password, u.Document); err != nil {
+		return usecase.InvalidParam(err.Error(), err)
+	}
+
+	hash, err := s.Hasher.Hash(input.Password)
+	if err != nil {
+		return usecase.InternalServerError("failed to hash password", err)
+	}
+
+	now := s.Now().UTC()
+	err = s.Credentials.ChangePassword(ctx, u.ID, hash, now)
+	if errors.Is(err, credential.ErrNotFound) {
+		err = s.Credentials.Create(ctx, credential.Credential{UserID: u.ID, PasswordHash: hash, UpdatedAt: now})
+	}
+	if err != nil {
+		return usecase.InternalServerError("failed to update credentials", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package reset_password
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ualaTwitter/internal/domain/credential"
+	"ualaTwitter/internal/domain/user"
+	"ualaTwitter/internal/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResetPasswordService_Execute(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	stored := credential.Credential{UserID: "usr_12345678", PasswordHash: "hashed:correcthorse42", SessionVersion: 1, FailedAttempts: 2}
+	newService := func(creds *mocks.FakeCredentialRepo, hasher *mocks.FakeHasher) *ResetPasswordService {
+		users := &mocks.FakeUserRepo{Users: map[string]*user.User{
+			"usr_12345678": {ID: "usr_12345678", Name: "Alice", Document: "12345678"},
+			"usr_87654321": {ID: "usr_87654321", Name: "Imported", Document: "87654321"},
+		}}
+		service := NewResetPasswordService(creds, users, hasher)
+		service.Now = func() time.Time { return now }
+		return service
+	}
+
+	t.Run("replaces the password and revokes sessions", func(t *testing.T) {
+		creds := &mocks.FakeCredentialRepo{Credentials: map[string]credential.Credential{stored.UserID: stored}}
+
+		require.NoError(t, newService(creds, &mocks.FakeHasher{}).Execute(ctx, Input{UserID: stored.UserID, Password: "batterystaple77"}))
+		updated := creds.Credentials[stored.UserID]
+		assert.Equal(t, "hashed:batterystaple77", updated.PasswordHash)
+		assert.Equal(t, stored.SessionVersion+1, updated.SessionVersion)
+		assert.Zero(t, updated.FailedAttempts)
+	})
+
+	t.Run("creates credentials for a user without them", func(t *testing.T) {
+		creds := &mocks.FakeCredentialRepo{Credentials: map[string]credential.Credential{}}
+
+		require.NoError(t, newService(creds, &mocks.FakeHasher{}).Execute(ctx, Input{UserID: "usr_87654321", Password: "batterystaple77"}))
+		assert.Equal(t, credential.Credential{UserID: "usr_87654321", PasswordHash: "hashed:batterystaple77", UpdatedAt: now},
+			creds.Credentials["usr_87654321"])
+	})
+
+	tests := []struct {
+		name      string
+		input     Input
+		hashErr   error
+		createErr error
+		expectErr string
+	}{
+		{
+			name:      "empty fields",
+			input:     Input{UserID: stored.UserID},
+			expectErr: "must not be empty",
+		},
+		{
+			name:      "unknown user",
+			input:     Input{UserID: "usr_ghost", Password: "batterystaple77"},
+			expectErr: "user not found",
+		},
+		{
+			name:      "password containing the document",
+			input:     Input{UserID: stored.UserID, Password: "pass12345678"},
+			expectErr: "password must be",
+		},
+		{
+			name:      "hash failure",
+			input:     Input{UserID: stored.UserID, Password: "batterystaple77"},
+			hashErr:   errors.New("rng failure"),
+			expectErr: "failed to hash password",
+		},
+		{
+			name:      "create failure",
+			input:     Input{UserID: "usr_87654321", Password: "batterystaple77"},
+			createErr: errors.New("db down"),
+			expectErr: "failed to update credentials",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			creds := &mocks.FakeCredentialRepo{
+				Credentials: map[string]credential.Credential{stored.UserID: stored},
+				CreateErr:   tc.createErr,
+			}
+
+			err := newService(creds, &mocks.FakeHasher{HashErr: tc.hashErr}).Execute(ctx, tc.input)
+			assert.ErrorContains(t, err, tc.expectErr)
+			assert.Equal(t, map[string]credential.Credential{stored.UserID: stored}, creds.Credentials)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package revoke_api_key
+
+type Input struct {
+	UserID string
+	KeyID  string
+}
@@ -0,0 +1,38 @@
+package revoke_api_key
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ualaTwitter/internal/domain/apikey"
+	"ualaTwitter/internal/platform/errors/usecase"
+)
+
+type RevokeAPIKeyService struct {
+	KeyRepo apikey.Repository
+	Now     func() time.Time
+}
+
+func NewRevokeAPIKeyService(keyRepo apikey.Repository) *RevokeAPIKeyService {
+	return &RevokeAPIKeyService{
+		KeyRepo: keyRepo,
+		Now:     time.Now,
+	}
+}
+
+// Execute revokes one of the user's keys. Revoking an already revoked key is
+// a no-op.
+func (s *RevokeAPIKeyService) Execute(ctx context.Context, input Input) error {
+	if input.UserID == "" || input.KeyID == "" {
+		return usecase.InvalidParam("user ID and key ID must not be empty")
+	}
+
+	if err := s.KeyRepo.Revoke(ctx, input.UserID, input.KeyID, s.Now().UTC()); err != nil {
+		if errors.Is(err, apikey.ErrNotFound) {
+			return usecase.NotFound("api key not found", err)
+		}
+		return usecase.InternalServerError("failed to revoke api key", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package revoke_api_key
+
+import (
+	"context"
+	"testing"
+
+	"ualaTwitter/internal/domain/apikey"
+	"ualaTwitter/internal/test/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokeAPIKeyService_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		input     Input
+		expectErr string
+	}{
+		{name: "revokes own key", input: Input{UserID: "usr_1", KeyID: "k1"}},
+		{name: "someone else's key", input: Input{UserID: "usr_2", KeyID: "k1"}, expectErr: "not_found"},
+		{name: "unknown key", input: Input{UserID: "usr_1", KeyID: "nope"}, expectErr: "not_found"},
+		{name: "missing key ID", input: Input{UserID: "usr_1"}, expectErr: "invalid_param"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &mocks.FakeAPIKeyRepo{Keys: map[string]apikey.APIKey{"k1": {ID: "k1", UserID: "usr_1"}}}
+
+			err := NewRevokeAPIKeyService(repo).Execute(ctx, tc.input)
+
+			if tc.expectErr != "" {
+				assert.ErrorContains(t, err, tc.expectErr)
+				assert.False(t, repo.Keys["k1"].IsRevoked())
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, repo.Keys["k1"].IsRevoked())
+		})
+	}
+}